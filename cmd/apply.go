@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+type applyFlags struct {
+	file        string
+	prune       bool
+	pruneTokens bool
+	force       bool
+	noprompt    bool
+	outputType  string
+	noHeaders   bool
+}
+
+func newApplyCmd() *cobra.Command {
+	f := applyFlags{}
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Apply a manifest describing the configuration of FME Flow.",
+		Long: `Apply a manifest to FME Flow. A manifest is a yaml file describing the repositories, connections, deployment parameters, queues and tokens that should exist on FME Flow.
+The manifest is compared with FME Flow and a plan of the changes is printed before anything is changed. Items in the manifest that don't exist are created and items that are different are updated. Only the kinds of items that are in the manifest are managed.
+Use --prune to delete items of the kinds in the manifest that exist on FME Flow but not in the manifest. The Default queue is never deleted.
+Tokens are only deleted with --prune-tokens. FME Flow doesn't say which token a request was made with, so the manifest must list the token fmeflow is using or it is deleted as well.
+Deployment parameters that are used by workspaces are not deleted unless --force is also given. Use "fmeflow deploymentparameters usages" to see which references are checked.
+Properties left out of the manifest, such as a description or the routing rules of a queue, keep their current value on FME Flow.
+Values in the manifest can reference environment variables as ${env:NAME}, which is useful for keeping passwords out of the manifest. Passwords can't be read back from FME Flow, so a change to only the password of a connection is not detected. A username or password left out of the manifest keeps its value on FME Flow.
+Use "fmeflow diff" to see the plan without making any changes, and "fmeflow export" to create a manifest from the current configuration of FME Flow.
+
+An example manifest:
+
+  repositories:
+    - name: Production
+      description: Production workspaces
+  connections:
+    - name: warehouse
+      category: database
+      type: PostgreSQL
+      username: fme
+      password: ${env:PG_PASSWORD}
+      parameters:
+        HOST: db.example.com
+        PORT: "5432"
+        DATASET: warehouse
+  deploymentParameters:
+    - name: WAREHOUSE_DB
+      type: database
+      value: warehouse
+      databaseType: PostgreSQL
+  queues:
+    - name: Priority
+      priority: 1
+      rules:
+        - Production
+        - Samples/austinApartments.fmw
+  tokens:
+    - name: ci
+      description: Token for the build server
+      expirationDate: 2030-01-01T00:00:00Z`,
+		Example: `
+  # Apply the manifest in config.yaml, prompting before making changes
+  fmeflow apply -f config.yaml
+
+  # Apply the manifest and delete anything not in it, with no confirmation
+  fmeflow apply -f config.yaml --prune --no-prompt
+
+  # Apply a manifest read from stdin
+  cat config.yaml | fmeflow apply -f - -y
+
+  # Copy the repositories and connections from one FME Flow to another
+  fmeflow export --kinds repositories,connections | fmeflow apply -f - --config prod-config.yaml -y`,
+		Args: NoArgs,
+		RunE: applyRun(&f),
+	}
+
+	cmd.Flags().StringVarP(&f.file, "file", "f", "", "Path to the manifest to apply. Use - to read from stdin.")
+	cmd.Flags().BoolVar(&f.prune, "prune", false, "Delete items of the kinds in the manifest that are not in the manifest.")
+	cmd.Flags().BoolVar(&f.pruneTokens, "prune-tokens", false, "Delete tokens that are not in the manifest. The manifest must list the token fmeflow is using or it is deleted too.")
+	cmd.Flags().BoolVar(&f.force, "force", false, "Delete deployment parameters that are not in the manifest even if they are used by workspaces.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation before applying the plan.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func applyRun(f *applyFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		manifest, err := loadManifest(cmd, f.file)
+		if err != nil {
+			return err
+		}
+
+		plan, err := planManifest(client, manifest, f.prune, f.pruneTokens)
+		if err != nil {
+			return err
+		}
+
+		if len(plan) == 0 {
+			if f.outputType == "table" {
+				fmt.Fprintln(cmd.OutOrStdout(), "FME Flow already matches the manifest.")
+				return nil
+			}
+			return printManifestPlan(cmd, plan, f.outputType, f.noHeaders)
+		}
+
+		// the plan is output after applying for json so that any new tokens are included
+		if f.outputType != "json" {
+			if err := printManifestPlan(cmd, plan, f.outputType, f.noHeaders); err != nil {
+				return err
+			}
+		}
+
+		// make sure nothing that is still in use is deleted
+		for _, change := range plan {
+			if change.unsafe == "" {
+				continue
+			}
+			if !f.force {
+				return errors.New(change.unsafe + ". Use --force to delete it anyway")
+			}
+			fmt.Fprintln(cmd.ErrOrStderr(), "Warning: "+change.unsafe)
+		}
+
+		if !f.noprompt {
+			// prompt to confirm the changes
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: "Apply " + strconv.Itoa(len(plan)) + " change(s) to FME Flow?",
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		if err := applyManifestPlan(client, plan); err != nil {
+			return err
+		}
+
+		if f.outputType == "json" {
+			return printManifestPlan(cmd, plan, f.outputType, f.noHeaders)
+		}
+		for _, change := range plan {
+			if change.Token != "" {
+				fmt.Fprintln(cmd.OutOrStdout(), "Token "+change.Name+" created. Save it now as it can't be retrieved again: "+change.Token)
+			}
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), "Manifest successfully applied.")
+		return nil
+	}
+}
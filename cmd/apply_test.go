@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestManifest(t *testing.T) string {
+	manifest := `repositories:
+  - name: Samples
+    description: Sample workspaces
+  - name: Production
+    description: Production workspaces
+connections:
+  - name: warehouse
+    category: database
+    type: PostgreSQL
+    username: fme
+    password: ${env:FMEFLOW_TEST_PASSWORD}
+    parameters:
+      HOST: new.example.com
+      PORT: "5432"
+deploymentParameters:
+  - name: WAREHOUSE_DB
+    type: database
+    value: warehouse
+    databaseType: PostgreSQL
+  - name: NEW_PARAM
+    value: ${env:FMEFLOW_TEST_VALUE}
+queues:
+  - name: Priority
+    priority: 1
+tokens:
+  - name: ci
+    description: CI
+  - name: deploy
+`
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(manifest), 0644))
+	return file
+}
+
+func TestApply(t *testing.T) {
+	t.Setenv("FMEFLOW_TEST_PASSWORD", "secret")
+	t.Setenv("FMEFLOW_TEST_VALUE", "from-env")
+	manifestFile := writeTestManifest(t)
+
+	dir := t.TempDir()
+	duplicateFile := filepath.Join(dir, "duplicate.yaml")
+	require.NoError(t, os.WriteFile(duplicateFile, []byte("queues:\n  - name: Priority\n  - name: Priority\n"), 0644))
+	badTypeFile := filepath.Join(dir, "badtype.yaml")
+	require.NoError(t, os.WriteFile(badTypeFile, []byte("deploymentParameters:\n  - name: P\n    type: number\n    value: \"1\"\n"), 0644))
+	missingEnvFile := filepath.Join(dir, "missingenv.yaml")
+	require.NoError(t, os.WriteFile(missingEnvFile, []byte("connections:\n  - name: c\n    category: basic\n    password: ${env:FMEFLOW_TEST_NOT_SET}\n"), 0644))
+	rulesFile := filepath.Join(dir, "rules.yaml")
+	require.NoError(t, os.WriteFile(rulesFile, []byte("queues:\n  - name: Priority\n    rules:\n      - Production\n      - Samples/austinApartments.fmw\n"), 0644))
+	badRuleFile := filepath.Join(dir, "badrule.yaml")
+	require.NoError(t, os.WriteFile(badRuleFile, []byte("queues:\n  - name: Priority\n    rules:\n      - a/b/c\n"), 0644))
+	tokensFile := filepath.Join(dir, "tokens.yaml")
+	require.NoError(t, os.WriteFile(tokensFile, []byte("tokens:\n  - name: ci\n    description: CI\n"), 0644))
+	usernameFile := filepath.Join(dir, "username.yaml")
+	require.NoError(t, os.WriteFile(usernameFile, []byte("connections:\n  - name: warehouse\n    category: database\n    username: etl\n"), 0644))
+	noCredentialsFile := filepath.Join(dir, "nocredentials.yaml")
+	require.NoError(t, os.WriteFile(noCredentialsFile, []byte("connections:\n  - name: warehouse\n    category: database\n    parameters:\n      HOST: new.example.com\n"), 0644))
+	noDescriptionFile := filepath.Join(dir, "nodescription.yaml")
+	require.NoError(t, os.WriteFile(noDescriptionFile, []byte("repositories:\n  - name: Samples\n"), 0644))
+	unchangedFile := filepath.Join(dir, "unchanged.yaml")
+	require.NoError(t, os.WriteFile(unchangedFile, []byte("repositories:\n  - name: Samples\n    description: Sample workspaces\n"), 0644))
+
+	repositoriesResponse := `{"items":[{"name":"Samples","description":"Sample workspaces","sharable":true},{"name":"Old","description":"","sharable":true}],"totalCount":2,"limit":100,"offset":0}`
+	connectionsResponse := `{"items":[{"name":"warehouse","category":"database","type":"PostgreSQL","username":"fme","parameters":{"HOST":"old.example.com","PORT":5432}}],"totalCount":1,"limit":100,"offset":0}`
+	deploymentParametersResponse := `{"items":[{"name":"WAREHOUSE_DB","type":"dropdown","value":"warehouse","choiceSettings":{"choiceSet":"dbConnections","family":"PostgreSQL"}},{"name":"OLD_PARAM","type":"text","value":"x"}],"totalCount":2,"limit":100,"offset":0}`
+	queuesResponse := `{"items":[{"name":"Default","description":"","priority":5},{"name":"Priority","description":"High priority jobs","priority":2,"rules":[{"repository":"Samples"}]}],"totalCount":2,"limit":100,"offset":0}`
+	workspacesResponse := `{"items":[{"name":"loader.fmw","repositoryName":"ETL"}],"totalCount":1,"limit":100,"offset":0}`
+	loaderResponse := `{"name":"loader.fmw","parameters":[{"name":"DB_CONNECTION","type":"dbConnection","defaultValue":"$(WAREHOUSE_DB)"}],"properties":[]}`
+	tokensResponse := `{"items":[{"name":"ci","description":"CI","enabled":true,"expirationDate":"2030-01-01T00:00:00Z"}],"totalCount":1,"limit":100,"offset":0}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond.
+	// Anything the manifest shouldn't change returns a 404 so that the command fails
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			responses := map[string]string{
+				"/fmeapiv4/repositories":              repositoriesResponse,
+				"/fmeapiv4/connections":               connectionsResponse,
+				"/fmeapiv4/deploymentparameters":      deploymentParametersResponse,
+				"/fmeapiv4/queues":                    queuesResponse,
+				"/fmeapiv4/tokens":                    tokensResponse,
+				"/fmeapiv4/workspaces":                workspacesResponse,
+				"/fmeapiv4/workspaces/ETL/loader.fmw": loaderResponse,
+			}
+			if response, ok := responses[r.URL.Path]; ok {
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(response))
+				require.NoError(t, err)
+				return
+			}
+		}
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/repositories" {
+			require.JSONEq(t, `{"name":"Production","description":"Production workspaces"}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+		} else if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/connections/warehouse" {
+			require.JSONEq(t, `{"category":"database","username":"fme","password":"secret","parameters":{"HOST":"new.example.com","PORT":"5432"}}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		} else if r.Method == "POST" && r.URL.Path == "/fmeapiv4/deploymentparameters" {
+			require.JSONEq(t, `{"name":"NEW_PARAM","type":"text","value":"from-env","choiceSettings":{"choiceSet":""}}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+		} else if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/queues/Priority" {
+			// the description and rules of the queue are kept, as the manifest doesn't list them
+			require.JSONEq(t, `{"description":"High priority jobs","priority":1,"rules":[{"repository":"Samples"}]}`, string(body))
+			w.WriteHeader(http.StatusOK)
+		} else if r.Method == "POST" && r.URL.Path == "/fmeapiv4/tokens" {
+			require.JSONEq(t, `{"name":"deploy","description":"","enabled":true}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, err := w.Write([]byte(`{"token":"abc123"}`))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// the same, but items that aren't in the manifest can be deleted
+	customHttpServerHandlerPrune := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" && (r.URL.Path == "/fmeapiv4/repositories/Old" || r.URL.Path == "/fmeapiv4/deploymentparameters/OLD_PARAM") {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// the same, but a workspace uses the deployment parameter that would be pruned
+	customHttpServerHandlerInUse := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/workspaces/ETL/loader.fmw" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"name":"loader.fmw","parameters":[{"name":"DB_CONNECTION","type":"dbConnection","defaultValue":"$(OLD_PARAM)"}],"properties":[]}`))
+			require.NoError(t, err)
+			return
+		}
+		customHttpServerHandlerPrune(w, r)
+	}
+
+	// a manifest that replaces the rules of a queue
+	customHttpServerHandlerRules := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/queues/Priority" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"description":"High priority jobs","priority":2,"rules":[{"repository":"Production"},{"repository":"Samples","workspace":"austinApartments.fmw"}]}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// a manifest that only changes the username of a connection
+	customHttpServerHandlerUsername := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/connections/warehouse" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"category":"database","username":"etl","parameters":{"HOST":"old.example.com","PORT":5432}}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// a manifest without credentials leaves the ones on FME Flow alone
+	customHttpServerHandlerNoCredentials := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/connections/warehouse" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"category":"database","parameters":{"HOST":"new.example.com","PORT":5432}}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// tokens that aren't in the manifest
+	customHttpServerHandlerTokens := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/tokens" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items":[{"name":"ci","description":"CI","enabled":true},{"name":"old","description":"","enabled":true}],"totalCount":2,"limit":100,"offset":0}`))
+			require.NoError(t, err)
+		} else if r.Method == "DELETE" && r.URL.Path == "/fmeapiv4/tokens/old" {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"apply", "-f", manifestFile, "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flag",
+			wantErrText: "required flag(s) \"file\" not set",
+			args:        []string{"apply"},
+		},
+		{
+			name:        "duplicate names",
+			wantErrText: "queue Priority is specified more than once in the manifest",
+			args:        []string{"apply", "-f", duplicateFile, "-y"},
+		},
+		{
+			name:        "invalid deployment parameter type",
+			wantErrText: "invalid type \"number\" for deployment parameter P. Must be one of text, database or web",
+			args:        []string{"apply", "-f", badTypeFile, "-y"},
+		},
+		{
+			name:        "invalid queue rule",
+			wantErrText: "queue Priority in the manifest has an invalid rule \"a/b/c\". Must be in the form repository or repository/workspace",
+			args:        []string{"apply", "-f", badRuleFile, "-y"},
+		},
+		{
+			name:        "missing environment variable",
+			wantErrText: "environment variable FMEFLOW_TEST_NOT_SET referenced in the manifest is not set",
+			args:        []string{"apply", "-f", missingEnvFile, "-y"},
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"apply", "-f", manifestFile, "-y"},
+		},
+		{
+			name:            "already matches",
+			args:            []string{"apply", "-f", unchangedFile, "-y"},
+			wantOutputRegex: "^FME Flow already matches the manifest.[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+		},
+		{
+			name:            "description left out of the manifest is kept",
+			args:            []string{"apply", "-f", noDescriptionFile, "-y"},
+			wantOutputRegex: "^FME Flow already matches the manifest.[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+		},
+		{
+			name:            "apply manifest",
+			args:            []string{"apply", "-f", manifestFile, "-y"},
+			wantOutputRegex: "^[\\s]*KIND[\\s]*NAME[\\s]*ACTION[\\s]*CHANGES[\\s]*repository[\\s]*Production[\\s]*create[\\s]*connection[\\s]*warehouse[\\s]*update[\\s]*parameters.HOST[\\s]*deploymentparameter[\\s]*NEW_PARAM[\\s]*create[\\s]*queue[\\s]*Priority[\\s]*update[\\s]*priority[\\s]*token[\\s]*deploy[\\s]*create[\\s]*Token deploy created. Save it now as it can't be retrieved again: abc123\nManifest successfully applied.[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+		},
+		{
+			name:        "prune deployment parameter in use",
+			args:        []string{"apply", "-f", manifestFile, "--prune", "-y"},
+			wantErrText: "deployment parameter OLD_PARAM is used by 1 workspace(s): ETL/loader.fmw. Use --force to delete it anyway",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandlerInUse)),
+		},
+		{
+			name:               "force prune deployment parameter in use",
+			args:               []string{"apply", "-f", manifestFile, "--prune", "--force", "-y", "--no-headers"},
+			wantOutputRegex:    "deploymentparameter[\\s]*OLD_PARAM[\\s]*delete[\\s]*used by 1 workspace\\(s\\): ETL/loader.fmw[\\s\\S]*Manifest successfully applied.[\\s]*$",
+			wantErrOutputRegex: "^Warning: deployment parameter OLD_PARAM is used by 1 workspace\\(s\\): ETL/loader.fmw\n$",
+			httpServer:         httptest.NewServer(http.HandlerFunc(customHttpServerHandlerInUse)),
+		},
+		{
+			name:            "replace queue rules",
+			args:            []string{"apply", "-f", rulesFile, "-y", "--no-headers"},
+			wantOutputRegex: "^[\\s]*queue[\\s]*Priority[\\s]*update[\\s]*rules[\\s]*Manifest successfully applied.[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerRules)),
+		},
+		{
+			name:            "update connection username",
+			args:            []string{"apply", "-f", usernameFile, "-y", "--no-headers"},
+			wantOutputRegex: "^[\\s]*connection[\\s]*warehouse[\\s]*update[\\s]*username[\\s]*Manifest successfully applied.[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerUsername)),
+		},
+		{
+			name:            "update connection without credentials",
+			args:            []string{"apply", "-f", noCredentialsFile, "-y", "--no-headers"},
+			wantOutputRegex: "^[\\s]*connection[\\s]*warehouse[\\s]*update[\\s]*parameters.HOST[\\s]*Manifest successfully applied.[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerNoCredentials)),
+		},
+		{
+			name:            "apply manifest with prune",
+			args:            []string{"apply", "-f", manifestFile, "--prune", "-y", "--no-headers"},
+			wantOutputRegex: "repository[\\s]*Old[\\s]*delete[\\s]*connection[\\s]*warehouse[\\s\\S]*deploymentparameter[\\s]*OLD_PARAM[\\s]*delete[\\s]*queue[\\s]*Priority[\\s\\S]*Manifest successfully applied.[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerPrune)),
+		},
+		{
+			name:            "prune does not delete tokens",
+			args:            []string{"apply", "-f", tokensFile, "--prune", "-y", "--no-headers"},
+			wantOutputRegex: "^FME Flow already matches the manifest.[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerTokens)),
+		},
+		{
+			name:            "prune tokens",
+			args:            []string{"apply", "-f", tokensFile, "--prune-tokens", "-y", "--no-headers"},
+			wantOutputRegex: "^[\\s]*token[\\s]*old[\\s]*delete[\\s]*Manifest successfully applied.[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerTokens)),
+		},
+		{
+			name:       "apply manifest json",
+			args:       []string{"apply", "-f", manifestFile, "-y", "--json"},
+			httpServer: httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			wantOutputJson: `[
+				{"kind":"repository","name":"Production","action":"create","changes":[]},
+				{"kind":"connection","name":"warehouse","action":"update","changes":["parameters.HOST"]},
+				{"kind":"deploymentparameter","name":"NEW_PARAM","action":"create","changes":[]},
+				{"kind":"queue","name":"Priority","action":"update","changes":["priority"]},
+				{"kind":"token","name":"deploy","action":"create","changes":[],"token":"abc123"}
+			]`,
+		},
+	}
+
+	runTests(cases, t)
+
+}
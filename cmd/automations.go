@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type AutomationV4 struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	State       string             `json:"state"`
+	Owner       string             `json:"owner"`
+	LastUpdated time.Time          `json:"lastUpdated"`
+	Triggers    []AutomationNodeV4 `json:"triggers,omitempty"`
+	Actions     []AutomationNodeV4 `json:"actions,omitempty"`
+}
+
+// a trigger or action in an automation. Actions that run a workspace have the repository and workspace set
+type AutomationNodeV4 struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Repository string `json:"repository,omitempty"`
+	Workspace  string `json:"workspace,omitempty"`
+}
+
+type AutomationsV4 struct {
+	Offset     int            `json:"offset"`
+	Limit      int            `json:"limit"`
+	TotalCount int            `json:"totalCount"`
+	Items      []AutomationV4 `json:"items"`
+}
+
+// the states FME Flow reports for automations
+const (
+	automationStateRunning = "running"
+	automationStateStopped = "stopped"
+)
+
+type automationsFlags struct {
+	state      string
+	outputType string
+	noHeaders  bool
+}
+
+func newAutomationsCmd() *cobra.Command {
+	f := automationsFlags{}
+	cmd := &cobra.Command{
+		Use:   "automations",
+		Short: "List and manage automations",
+		Long: `Lists the automations on FME Flow. Use the subcommands to describe, start, stop, export, import and delete automations.
+Automations can be identified by name or by id. If more than one automation has the same name, use the id.`,
+		Example: `
+  # List all automations
+  fmeflow automations
+
+  # List the ids of the running automations, to restart them after maintenance
+  fmeflow automations --state running --output=custom-columns=ID:.id --no-headers > running.txt
+
+  # Stop all automations for a maintenance window
+  fmeflow automations stop --all -y`,
+		Args:    NoArgs,
+		PreRunE: automationsPreRun(&f),
+		RunE:    automationsRun(&f),
+	}
+
+	addAutomationsListFlags(cmd, &f)
+	cmd.AddCommand(newAutomationListCmd())
+	cmd.AddCommand(newAutomationDescribeCmd())
+	cmd.AddCommand(newAutomationStartCmd())
+	cmd.AddCommand(newAutomationStopCmd())
+	cmd.AddCommand(newAutomationExportCmd())
+	cmd.AddCommand(newAutomationImportCmd())
+	cmd.AddCommand(newAutomationDeleteCmd())
+	return cmd
+}
+
+func newAutomationListCmd() *cobra.Command {
+	f := automationsFlags{}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List automations",
+		Long:  `Lists the automations on FME Flow. This is the same as running "fmeflow automations".`,
+		Example: `
+  # List all automations
+  fmeflow automations list
+
+  # List the stopped automations in json format
+  fmeflow automations list --state stopped --json`,
+		Args:    NoArgs,
+		PreRunE: automationsPreRun(&f),
+		RunE:    automationsRun(&f),
+	}
+
+	addAutomationsListFlags(cmd, &f)
+	return cmd
+}
+
+func addAutomationsListFlags(cmd *cobra.Command, f *automationsFlags) {
+	cmd.Flags().StringVar(&f.state, "state", "", "If specified, only automations in this state will be returned. One of running or stopped.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+}
+
+func automationsPreRun(f *automationsFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if f.state != "" && f.state != automationStateRunning && f.state != automationStateStopped {
+			return fmt.Errorf("invalid value %q for --state. Must be one of %s, %s", f.state, automationStateRunning, automationStateStopped)
+		}
+		return nil
+	}
+}
+
+func automationsRun(f *automationsFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		automations, err := getAllItemsV4[AutomationV4](client, "/fmeapiv4/automations")
+		if err != nil {
+			return err
+		}
+		if f.state != "" {
+			filtered := []AutomationV4{}
+			for _, automation := range automations {
+				if strings.EqualFold(automation.State, f.state) {
+					filtered = append(filtered, automation)
+				}
+			}
+			automations = filtered
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Name", "ID", "State", "Owner", "Last Updated"})
+
+			for _, element := range automations {
+				t.AppendRow(table.Row{element.Name, element.ID, element.State, element.Owner, element.LastUpdated})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			responseData, err := json.Marshal(AutomationsV4{Items: automations, TotalCount: len(automations), Limit: len(automations)})
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			// we have to marshal the Items array, then create an array of marshalled items
+			// to pass to the creation of the table.
+			marshalledItems := [][]byte{}
+			for _, element := range automations {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// find the ids of the automations with the given names and ids. Names must match exactly one automation
+func resolveAutomationIDs(client *http.Client, names []string, ids []string) ([]string, error) {
+	if len(names) == 0 {
+		return append([]string{}, ids...), nil
+	}
+
+	automations, err := getAllItemsV4[AutomationV4](client, "/fmeapiv4/automations")
+	if err != nil {
+		return nil, err
+	}
+	return matchAutomationIDs(automations, names, ids)
+}
+
+// the same as resolveAutomationIDs, looking up the names in automations that have already been listed
+func matchAutomationIDs(automations []AutomationV4, names []string, ids []string) ([]string, error) {
+	resolved := append([]string{}, ids...)
+	for _, name := range names {
+		matches := []string{}
+		for _, automation := range automations {
+			if automation.Name == name {
+				matches = append(matches, automation.ID)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("automation %s does not exist", name)
+		case 1:
+			resolved = append(resolved, matches[0])
+		default:
+			return nil, fmt.Errorf("there are %d automations named %s. Use --id to choose one: %s", len(matches), name, strings.Join(matches, ", "))
+		}
+	}
+	return resolved, nil
+}
+
+// get a single automation by id, along with the raw response
+func getAutomationV4(client *http.Client, id string) (AutomationV4, []byte, error) {
+	var result AutomationV4
+
+	request, err := buildFmeFlowRequest(automationEndpoint(id), "GET", nil)
+	if err != nil {
+		return result, nil, err
+	}
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return result, nil, err
+	} else if response.StatusCode != http.StatusOK {
+		if response.StatusCode == http.StatusNotFound {
+			return result, nil, fmt.Errorf("%w: check that the automation %s exists", errors.New(response.Status), id)
+		}
+		return result, nil, parseResponseMessage(response)
+	}
+
+	responseData, err := io.ReadAll(response.Body)
+	if err != nil {
+		return result, nil, err
+	}
+
+	err = json.Unmarshal(responseData, &result)
+	return result, responseData, err
+}
+
+func automationEndpoint(id string) string {
+	return "/fmeapiv4/automations/" + url.PathEscape(id)
+}
+
+// find the id of a single automation from its name or id
+func resolveAutomationID(client *http.Client, name string, id string) (string, error) {
+	if id != "" {
+		return id, nil
+	}
+	ids, err := resolveAutomationIDs(client, []string{name}, nil)
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+// add the --name and --id flags used to choose a single automation
+func addAutomationSelectFlags(cmd *cobra.Command, name *string, id *string, action string) {
+	cmd.Flags().StringVar(name, "name", "", "Name of the automation to "+action+".")
+	cmd.Flags().StringVar(id, "id", "", "Id of the automation to "+action+". Use this if more than one automation has the same name.")
+	cmd.MarkFlagsOneRequired("name", "id")
+	cmd.MarkFlagsMutuallyExclusive("name", "id")
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+type automationControlFlags struct {
+	names    []string
+	ids      []string
+	all      bool
+	noprompt bool
+}
+
+const (
+	automationActionStart = "start"
+	automationActionStop  = "stop"
+)
+
+func newAutomationStartCmd() *cobra.Command {
+	f := automationControlFlags{}
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Start automations",
+		Long:  `Start automations so that their triggers begin to fire. Automations that are already running are left alone.`,
+		Example: `
+  # Start the automation "Process Uploads"
+  fmeflow automations start --name "Process Uploads"
+
+  # Restart the automations that were running before a maintenance window
+  fmeflow automations --state running --output=custom-columns=ID:.id --no-headers > running.txt
+  fmeflow automations stop --all -y
+  fmeflow automations start $(sed 's/^/--id /' running.txt)
+
+  # Start every stopped automation
+  fmeflow automations start --all`,
+		Args: NoArgs,
+		RunE: automationControlRun(automationActionStart, &f),
+	}
+	addAutomationControlFlags(cmd, &f, "start")
+	return cmd
+}
+
+func newAutomationStopCmd() *cobra.Command {
+	f := automationControlFlags{}
+	cmd := &cobra.Command{
+		Use:   "stop",
+		Short: "Stop automations",
+		Long:  `Stop automations so that their triggers no longer fire. Jobs that have already been submitted are not affected. Automations that are already stopped are left alone.`,
+		Example: `
+  # Stop the automation "Process Uploads"
+  fmeflow automations stop --name "Process Uploads"
+
+  # Stop every automation for a maintenance window without prompting
+  fmeflow automations stop --all --no-prompt`,
+		Args: NoArgs,
+		RunE: automationControlRun(automationActionStop, &f),
+	}
+	addAutomationControlFlags(cmd, &f, "stop")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	return cmd
+}
+
+func addAutomationControlFlags(cmd *cobra.Command, f *automationControlFlags, action string) {
+	cmd.Flags().StringArrayVar(&f.names, "name", []string{}, "Name of the automation to "+action+". Can be passed in multiple times.")
+	cmd.Flags().StringArrayVar(&f.ids, "id", []string{}, "Id of the automation to "+action+". Can be passed in multiple times.")
+	cmd.Flags().BoolVar(&f.all, "all", false, "Use every automation instead of choosing them by name or id.")
+	cmd.MarkFlagsOneRequired("name", "id", "all")
+	cmd.MarkFlagsMutuallyExclusive("name", "all")
+	cmd.MarkFlagsMutuallyExclusive("id", "all")
+}
+
+func automationControlRun(action string, f *automationControlFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		automations, err := getAllItemsV4[AutomationV4](client, "/fmeapiv4/automations")
+		if err != nil {
+			return err
+		}
+
+		ids := []string{}
+		if f.all {
+			for _, automation := range automations {
+				ids = append(ids, automation.ID)
+			}
+		} else {
+			ids, err = matchAutomationIDs(automations, f.names, f.ids)
+			if err != nil {
+				return err
+			}
+		}
+
+		wantState := automationStateRunning
+		if action == automationActionStop {
+			wantState = automationStateStopped
+		}
+
+		targets := []AutomationV4{}
+		alreadyDone := []string{}
+		for _, id := range ids {
+			index := slices.IndexFunc(automations, func(a AutomationV4) bool { return a.ID == id })
+			if index == -1 {
+				return fmt.Errorf("automation %s does not exist", id)
+			}
+			if strings.EqualFold(automations[index].State, wantState) {
+				alreadyDone = append(alreadyDone, automations[index].Name)
+				continue
+			}
+			targets = append(targets, automations[index])
+		}
+
+		if action == automationActionStop && !f.noprompt && len(targets) != 0 {
+			// prompt to confirm
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: "Are you sure you want to stop " + strconv.Itoa(len(targets)) + " automation(s)?",
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		for _, automation := range targets {
+			if _, err := sendFmeFlowJSON(client, automationEndpoint(automation.ID)+"/"+action, "POST", nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent); err != nil {
+				return fmt.Errorf("could not %s automation %s: %w", action, automation.Name, err)
+			}
+			if !jsonOutput {
+				fmt.Fprintf(cmd.OutOrStdout(), "Automation %s successfully %s.\n", automation.Name, automationActionPastTense(action))
+			}
+		}
+
+		if !jsonOutput {
+			for _, name := range alreadyDone {
+				fmt.Fprintf(cmd.OutOrStdout(), "Automation %s is already %s.\n", name, wantState)
+			}
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
+
+func automationActionPastTense(action string) string {
+	if action == automationActionStop {
+		return "stopped"
+	}
+	return "started"
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomationsControl(t *testing.T) {
+	automationsListBody := `{
+		"items": [
+		  {
+			"id": "63f2489a-f3fc-4fa0-8df8-198de602b922",
+			"name": "Process Uploads",
+			"description": "Process files uploaded to the uploads folder",
+			"state": "running",
+			"owner": "admin",
+			"lastUpdated": "2026-09-01T12:00:00Z"
+		  },
+		  {
+			"id": "0b7e6f54-7d8c-4c38-8a8b-3f2d6b1a9c01",
+			"name": "Nightly Cleanup",
+			"description": "",
+			"state": "stopped",
+			"owner": "admin",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  },
+		  {
+			"id": "c1",
+			"name": "Copy",
+			"description": "",
+			"state": "stopped",
+			"owner": "author",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  },
+		  {
+			"id": "c2",
+			"name": "Copy",
+			"description": "",
+			"state": "stopped",
+			"owner": "author",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  }
+		],
+		"limit": 4,
+		"offset": 0,
+		"totalCount": 4
+	  }`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/automations" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(automationsListBody))
+			require.NoError(t, err)
+		} else if r.Method == "POST" && (strings.HasSuffix(r.URL.Path, "/start") || strings.HasSuffix(r.URL.Path, "/stop")) {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// the same, counting how many times the automations are listed
+	listed := 0
+	customHttpServerHandlerCountLists := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/automations" {
+			listed++
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"automations", "start", "--name", "Nightly Cleanup", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "nothing selected",
+			args:        []string{"automations", "stop", "-y"},
+			wantErrText: "at least one of the flags in the group [name id all] is required",
+		},
+		{
+			name:        "name and all",
+			args:        []string{"automations", "stop", "--name", "Nightly Cleanup", "--all", "-y"},
+			wantErrText: "if any flags in the group [name all] are set none of the others can be; [all name] were all set",
+		},
+		{
+			name:        "missing id",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"automations", "start", "--id", "missing"},
+			wantErrText: "automation missing does not exist",
+		},
+		{
+			name:            "start automation",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerCountLists)),
+			args:            []string{"automations", "start", "--name", "Nightly Cleanup"},
+			wantOutputRegex: "^Automation Nightly Cleanup successfully started.\n$",
+		},
+		{
+			name:            "start running automation",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"automations", "start", "--id", "63f2489a-f3fc-4fa0-8df8-198de602b922"},
+			wantOutputRegex: "^Automation Process Uploads is already running.\n$",
+		},
+		{
+			name:            "stop automation",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"automations", "stop", "--name", "Process Uploads", "-y"},
+			wantOutputRegex: "^Automation Process Uploads successfully stopped.\n$",
+		},
+		{
+			name:           "stop all json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"automations", "stop", "--all", "-y", "--json"},
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+	// the names are looked up in the list the automations are checked against
+	require.Equal(t, 1, listed)
+}
+
+func TestAutomationsStartAll(t *testing.T) {
+	automationsListBody := `{
+		"items": [
+		  {
+			"id": "63f2489a-f3fc-4fa0-8df8-198de602b922",
+			"name": "Process Uploads",
+			"description": "Process files uploaded to the uploads folder",
+			"state": "running",
+			"owner": "admin",
+			"lastUpdated": "2026-09-01T12:00:00Z"
+		  },
+		  {
+			"id": "0b7e6f54-7d8c-4c38-8a8b-3f2d6b1a9c01",
+			"name": "Nightly Cleanup",
+			"description": "",
+			"state": "stopped",
+			"owner": "admin",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  },
+		  {
+			"id": "c1",
+			"name": "Copy",
+			"description": "",
+			"state": "stopped",
+			"owner": "author",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  },
+		  {
+			"id": "c2",
+			"name": "Copy",
+			"description": "",
+			"state": "stopped",
+			"owner": "author",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  }
+		],
+		"limit": 4,
+		"offset": 0,
+		"totalCount": 4
+	  }`
+
+	// every start and stop request is recorded in actions
+	actions := []string{}
+
+	// this mock up records the automations that are started and stopped
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/automations" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(automationsListBody))
+			require.NoError(t, err)
+		} else if r.Method == "POST" && (strings.HasSuffix(r.URL.Path, "/start") || strings.HasSuffix(r.URL.Path, "/stop")) {
+			actions = append(actions, strings.TrimPrefix(r.URL.Path, "/fmeapiv4/automations/"))
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:            "start all",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"automations", "start", "--all"},
+			wantOutputRegex: "^Automation Nightly Cleanup successfully started.\nAutomation Copy successfully started.\nAutomation Copy successfully started.\nAutomation Process Uploads is already running.\n$",
+		},
+	}
+
+	runTests(cases, t)
+	require.Equal(t, []string{"0b7e6f54-7d8c-4c38-8a8b-3f2d6b1a9c01/start", "c1/start", "c2/start"}, actions)
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+type automationDeleteFlags struct {
+	name     string
+	id       string
+	noprompt bool
+}
+
+func newAutomationDeleteCmd() *cobra.Command {
+	f := automationDeleteFlags{}
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete an automation",
+		Long:  `Delete an automation. Use "fmeflow automations export" first to keep a copy of it.`,
+		Example: `
+  # Delete the automation "Process Uploads"
+  fmeflow automations delete --name "Process Uploads"
+
+  # Delete an automation by id with no confirmation
+  fmeflow automations delete --id 63f2489a-f3fc-4fa0-8df8-198de602b922 --no-prompt`,
+		Args: NoArgs,
+		RunE: automationDeleteRun(&f),
+	}
+
+	addAutomationSelectFlags(cmd, &f.name, &f.id, "delete")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	return cmd
+}
+
+func automationDeleteRun(f *automationDeleteFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		id, err := resolveAutomationID(client, f.name, f.id)
+		if err != nil {
+			return err
+		}
+		automation, _, err := getAutomationV4(client, id)
+		if err != nil {
+			return err
+		}
+
+		if !f.noprompt {
+			// prompt to confirm deletion
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: "Are you sure you want to delete the automation " + automation.Name + "?",
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		if _, err := sendFmeFlowJSON(client, automationEndpoint(id), "DELETE", nil, http.StatusNoContent, http.StatusOK); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Automation successfully deleted.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomationsDelete(t *testing.T) {
+	automationsListBody := `{
+		"items": [
+		  {
+			"id": "63f2489a-f3fc-4fa0-8df8-198de602b922",
+			"name": "Process Uploads",
+			"description": "Process files uploaded to the uploads folder",
+			"state": "running",
+			"owner": "admin",
+			"lastUpdated": "2026-09-01T12:00:00Z"
+		  },
+		  {
+			"id": "0b7e6f54-7d8c-4c38-8a8b-3f2d6b1a9c01",
+			"name": "Nightly Cleanup",
+			"description": "",
+			"state": "stopped",
+			"owner": "admin",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  },
+		  {
+			"id": "c1",
+			"name": "Copy",
+			"description": "",
+			"state": "stopped",
+			"owner": "author",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  },
+		  {
+			"id": "c2",
+			"name": "Copy",
+			"description": "",
+			"state": "stopped",
+			"owner": "author",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  }
+		],
+		"limit": 4,
+		"offset": 0,
+		"totalCount": 4
+	  }`
+
+	automationProcessUploadsBody := `{
+		"id": "63f2489a-f3fc-4fa0-8df8-198de602b922",
+		"name": "Process Uploads",
+		"description": "Process files uploaded to the uploads folder",
+		"state": "running",
+		"owner": "admin",
+		"lastUpdated": "2026-09-01T12:00:00Z",
+		"triggers": [
+		  {"name": "Uploads Watcher", "type": "Resource or Network Directory (updated)"}
+		],
+		"actions": [
+		  {"name": "Load", "type": "Run Workspace", "repository": "Samples", "workspace": "austinApartments.fmw"},
+		  {"name": "Notify", "type": "Email (send)"}
+		]
+	  }`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/automations" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(automationsListBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/automations/63f2489a-f3fc-4fa0-8df8-198de602b922" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(automationProcessUploadsBody))
+			require.NoError(t, err)
+		} else if r.Method == "DELETE" && r.URL.Path == "/fmeapiv4/automations/63f2489a-f3fc-4fa0-8df8-198de602b922" {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"automations", "delete", "--name", "Process Uploads", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing name and id",
+			args:        []string{"automations", "delete", "-y"},
+			wantErrText: "at least one of the flags in the group [name id] is required",
+		},
+		{
+			name:        "automation not found",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"automations", "delete", "--name", "Missing", "-y"},
+			wantErrText: "automation Missing does not exist",
+		},
+		{
+			name:            "delete automation",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"automations", "delete", "--name", "Process Uploads", "-y"},
+			wantOutputRegex: "^Automation successfully deleted.\n$",
+		},
+		{
+			name:           "delete automation json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"automations", "delete", "--id", "63f2489a-f3fc-4fa0-8df8-198de602b922", "-y", "--json"},
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+}
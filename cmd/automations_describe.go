@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type AutomationLogEntryV4 struct {
+	Timestamp time.Time `json:"timestamp"`
+	Level     string    `json:"level"`
+	Message   string    `json:"message"`
+}
+
+// a row in the summary of the triggers and actions of an automation
+type AutomationNodeSummary struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Workspace string `json:"workspace"`
+}
+
+type automationDescribeFlags struct {
+	name       string
+	id         string
+	log        bool
+	outputType string
+	noHeaders  bool
+}
+
+func newAutomationDescribeCmd() *cobra.Command {
+	f := automationDescribeFlags{}
+	cmd := &cobra.Command{
+		Use:   "describe",
+		Short: "Show the triggers and actions of an automation",
+		Long: `Show a summary of the triggers and actions of an automation, including the workspaces it runs. The json output contains the full automation.
+Use --log to show the automation log instead.`,
+		Example: `
+  # Describe the automation "Process Uploads"
+  fmeflow automations describe --name "Process Uploads"
+
+  # Show the log of an automation by id
+  fmeflow automations describe --id 63f2489a-f3fc-4fa0-8df8-198de602b922 --log`,
+		Args: NoArgs,
+		RunE: automationDescribeRun(&f),
+	}
+
+	addAutomationSelectFlags(cmd, &f.name, &f.id, "describe")
+	cmd.Flags().BoolVar(&f.log, "log", false, "Show the automation log instead of the triggers and actions.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	return cmd
+}
+
+func automationDescribeRun(f *automationDescribeFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		id, err := resolveAutomationID(client, f.name, f.id)
+		if err != nil {
+			return err
+		}
+		automation, responseData, err := getAutomationV4(client, id)
+		if err != nil {
+			return err
+		}
+
+		// build up the rows and items for the selected mode
+		var header table.Row
+		rows := []table.Row{}
+		items := []interface{}{}
+		if f.log {
+			entries, err := getAllItemsV4[AutomationLogEntryV4](client, automationEndpoint(id)+"/log")
+			if err != nil {
+				return err
+			}
+			header = table.Row{"Time", "Level", "Message"}
+			for _, entry := range entries {
+				rows = append(rows, table.Row{entry.Timestamp, entry.Level, entry.Message})
+				items = append(items, entry)
+			}
+			responseData, err = json.Marshal(entries)
+			if err != nil {
+				return err
+			}
+		} else {
+			header = table.Row{"Kind", "Name", "Type", "Workspace"}
+			for _, node := range automationNodeSummaries(automation) {
+				rows = append(rows, table.Row{node.Kind, node.Name, node.Type, node.Workspace})
+				items = append(items, node)
+			}
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(header)
+			t.AppendRows(rows)
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			// we have to marshal the Items array, then create an array of marshalled items
+			// to pass to the creation of the table.
+			marshalledItems := [][]byte{}
+			for _, element := range items {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+func automationNodeSummaries(automation AutomationV4) []AutomationNodeSummary {
+	summaries := []AutomationNodeSummary{}
+	for _, trigger := range automation.Triggers {
+		summaries = append(summaries, AutomationNodeSummary{Kind: "trigger", Name: trigger.Name, Type: trigger.Type})
+	}
+	for _, action := range automation.Actions {
+		summary := AutomationNodeSummary{Kind: "action", Name: action.Name, Type: action.Type}
+		if action.Workspace != "" {
+			summary.Workspace = action.Repository + "/" + action.Workspace
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomationsDescribe(t *testing.T) {
+	automationsListBody := `{
+		"items": [
+		  {
+			"id": "63f2489a-f3fc-4fa0-8df8-198de602b922",
+			"name": "Process Uploads",
+			"description": "Process files uploaded to the uploads folder",
+			"state": "running",
+			"owner": "admin",
+			"lastUpdated": "2026-09-01T12:00:00Z"
+		  },
+		  {
+			"id": "0b7e6f54-7d8c-4c38-8a8b-3f2d6b1a9c01",
+			"name": "Nightly Cleanup",
+			"description": "",
+			"state": "stopped",
+			"owner": "admin",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  },
+		  {
+			"id": "c1",
+			"name": "Copy",
+			"description": "",
+			"state": "stopped",
+			"owner": "author",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  },
+		  {
+			"id": "c2",
+			"name": "Copy",
+			"description": "",
+			"state": "stopped",
+			"owner": "author",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  }
+		],
+		"limit": 4,
+		"offset": 0,
+		"totalCount": 4
+	  }`
+
+	automationProcessUploadsBody := `{
+		"id": "63f2489a-f3fc-4fa0-8df8-198de602b922",
+		"name": "Process Uploads",
+		"description": "Process files uploaded to the uploads folder",
+		"state": "running",
+		"owner": "admin",
+		"lastUpdated": "2026-09-01T12:00:00Z",
+		"triggers": [
+		  {"name": "Uploads Watcher", "type": "Resource or Network Directory (updated)"}
+		],
+		"actions": [
+		  {"name": "Load", "type": "Run Workspace", "repository": "Samples", "workspace": "austinApartments.fmw"},
+		  {"name": "Notify", "type": "Email (send)"}
+		]
+	  }`
+
+	automationLogBody := `{
+		"items": [
+		  {"timestamp": "2026-10-19T10:00:00Z", "level": "INFORM", "message": "Automation started"},
+		  {"timestamp": "2026-10-19T10:05:00Z", "level": "ERROR", "message": "Workspace austinApartments.fmw failed"}
+		],
+		"limit": 2,
+		"offset": 0,
+		"totalCount": 2
+	  }`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/automations" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(automationsListBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/automations/63f2489a-f3fc-4fa0-8df8-198de602b922" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(automationProcessUploadsBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/automations/63f2489a-f3fc-4fa0-8df8-198de602b922/log" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(automationLogBody))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"automations", "describe", "--name", "Process Uploads", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing name and id",
+			args:        []string{"automations", "describe"},
+			wantErrText: "at least one of the flags in the group [name id] is required",
+		},
+		{
+			name:        "automation not found",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"automations", "describe", "--name", "Missing"},
+			wantErrText: "automation Missing does not exist",
+		},
+		{
+			name:        "id not found",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"automations", "describe", "--id", "missing"},
+			wantErrText: "404 Not Found: check that the automation missing exists",
+		},
+		{
+			name:        "ambiguous name",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"automations", "describe", "--name", "Copy"},
+			wantErrText: "there are 2 automations named Copy. Use --id to choose one: c1, c2",
+		},
+		{
+			name:            "describe automation",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"automations", "describe", "--name", "Process Uploads"},
+			wantOutputRegex: "^[\\s]*KIND[\\s]*NAME[\\s]*TYPE[\\s]*WORKSPACE[\\s]*trigger[\\s]*Uploads Watcher[\\s]*Resource or Network Directory \\(updated\\)[\\s]*action[\\s]*Load[\\s]*Run Workspace[\\s]*Samples/austinApartments.fmw[\\s]*action[\\s]*Notify[\\s]*Email \\(send\\)[\\s]*$",
+		},
+		{
+			name:            "describe automation custom columns",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"automations", "describe", "--id", "63f2489a-f3fc-4fa0-8df8-198de602b922", "--output=custom-columns=NAME:.name,KIND:.kind", "--no-headers"},
+			wantOutputRegex: "^[\\s]*Uploads Watcher[\\s]*trigger[\\s]*Load[\\s]*action[\\s]*Notify[\\s]*action[\\s]*$",
+		},
+		{
+			name:           "describe automation json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"automations", "describe", "--name", "Process Uploads", "--json"},
+			wantOutputJson: automationProcessUploadsBody,
+		},
+		{
+			name:            "describe automation log",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"automations", "describe", "--name", "Process Uploads", "--log"},
+			wantOutputRegex: "^[\\s]*TIME[\\s]*LEVEL[\\s]*MESSAGE[\\s]*2026-10-19 10:00:00 \\+0000 UTC[\\s]*INFORM[\\s]*Automation started[\\s]*2026-10-19 10:05:00 \\+0000 UTC[\\s]*ERROR[\\s]*Workspace austinApartments.fmw failed[\\s]*$",
+		},
+		{
+			name:           "describe automation log json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"automations", "describe", "--name", "Process Uploads", "--log", "--json"},
+			wantOutputJson: `[{"timestamp": "2026-10-19T10:00:00Z", "level": "INFORM", "message": "Automation started"}, {"timestamp": "2026-10-19T10:05:00Z", "level": "ERROR", "message": "Workspace austinApartments.fmw failed"}]`,
+		},
+	}
+
+	runTests(cases, t)
+}
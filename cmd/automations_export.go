@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+type automationExportFlags struct {
+	name string
+	id   string
+	file string
+}
+
+func newAutomationExportCmd() *cobra.Command {
+	f := automationExportFlags{}
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export an automation to a file",
+		Long:  `Export the definition of an automation as json so that it can be imported into another FME Flow with "fmeflow automations import". The definition is written to stdout unless --file is given.`,
+		Example: `
+  # Export the automation "Process Uploads" to a file
+  fmeflow automations export --name "Process Uploads" --file process-uploads.json
+
+  # Copy an automation from one FME Flow to another
+  fmeflow automations export --name "Process Uploads" | fmeflow automations import -f - --config prod-config.yaml`,
+		Args: NoArgs,
+		RunE: automationExportRun(&f),
+	}
+
+	addAutomationSelectFlags(cmd, &f.name, &f.id, "export")
+	cmd.Flags().StringVar(&f.file, "file", "", "Path to write the automation to. Defaults to stdout.")
+	return cmd
+}
+
+func automationExportRun(f *automationExportFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		id, err := resolveAutomationID(client, f.name, f.id)
+		if err != nil {
+			return err
+		}
+
+		responseData, err := sendFmeFlowJSON(client, automationEndpoint(id)+"/export", "GET", nil, http.StatusOK)
+		if err != nil {
+			return err
+		}
+		prettyJSON, err := prettyPrintJSON(responseData)
+		if err != nil {
+			return err
+		}
+
+		if f.file == "" {
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+			return nil
+		}
+
+		if err := os.WriteFile(f.file, []byte(prettyJSON+"\n"), 0644); err != nil {
+			return err
+		}
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Automation exported to "+f.file)
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomationsExport(t *testing.T) {
+	exportFile := filepath.Join(t.TempDir(), "process-uploads.json")
+
+	automationsListBody := `{
+		"items": [
+		  {
+			"id": "63f2489a-f3fc-4fa0-8df8-198de602b922",
+			"name": "Process Uploads",
+			"description": "Process files uploaded to the uploads folder",
+			"state": "running",
+			"owner": "admin",
+			"lastUpdated": "2026-09-01T12:00:00Z"
+		  },
+		  {
+			"id": "0b7e6f54-7d8c-4c38-8a8b-3f2d6b1a9c01",
+			"name": "Nightly Cleanup",
+			"description": "",
+			"state": "stopped",
+			"owner": "admin",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  },
+		  {
+			"id": "c1",
+			"name": "Copy",
+			"description": "",
+			"state": "stopped",
+			"owner": "author",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  },
+		  {
+			"id": "c2",
+			"name": "Copy",
+			"description": "",
+			"state": "stopped",
+			"owner": "author",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  }
+		],
+		"limit": 4,
+		"offset": 0,
+		"totalCount": 4
+	  }`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/automations" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(automationsListBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/automations/63f2489a-f3fc-4fa0-8df8-198de602b922/export" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"name": "Process Uploads", "nodes": []}`))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"automations", "export", "--name", "Process Uploads", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "name and id",
+			args:        []string{"automations", "export", "--name", "Process Uploads", "--id", "c1"},
+			wantErrText: "if any flags in the group [name id] are set none of the others can be; [id name] were all set",
+		},
+		{
+			name:           "export to stdout",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"automations", "export", "--name", "Process Uploads"},
+			wantOutputJson: `{"name": "Process Uploads", "nodes": []}`,
+		},
+		{
+			name:            "export to file",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"automations", "export", "--name", "Process Uploads", "--file", exportFile},
+			wantOutputRegex: "^Automation exported to .*process-uploads.json\n$",
+		},
+	}
+
+	runTests(cases, t)
+
+	contents, err := os.ReadFile(exportFile)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name": "Process Uploads", "nodes": []}`, string(contents))
+}
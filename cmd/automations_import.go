@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+type automationImportFlags struct {
+	file  string
+	name  string
+	start bool
+}
+
+func newAutomationImportCmd() *cobra.Command {
+	f := automationImportFlags{}
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import an automation from a file",
+		Long:  `Import an automation from a file created by "fmeflow automations export". The imported automation is stopped unless --start is given, so that it can be checked first.`,
+		Example: `
+  # Import an automation
+  fmeflow automations import -f process-uploads.json
+
+  # Import an automation with a new name and start it
+  fmeflow automations import -f process-uploads.json --name "Process Uploads (copy)" --start`,
+		Args: NoArgs,
+		RunE: automationImportRun(&f),
+	}
+
+	cmd.Flags().StringVarP(&f.file, "file", "f", "", "Path to the automation to import. Use - to read from stdin.")
+	cmd.Flags().StringVar(&f.name, "name", "", "Import the automation with this name instead of the name in the file.")
+	cmd.Flags().BoolVar(&f.start, "start", false, "Start the automation after importing it.")
+	cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func automationImportRun(f *automationImportFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		var contents []byte
+		var err error
+		if f.file == "-" {
+			contents, err = io.ReadAll(cmd.InOrStdin())
+		} else {
+			contents, err = os.ReadFile(f.file)
+		}
+		if err != nil {
+			return err
+		}
+
+		definition := map[string]interface{}{}
+		if err := json.Unmarshal(contents, &definition); err != nil {
+			return fmt.Errorf("could not parse automation %s: %w", f.file, err)
+		}
+		if f.name != "" {
+			definition["name"] = f.name
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		responseData, err := sendFmeFlowJSON(client, "/fmeapiv4/automations/import", "POST", definition, http.StatusCreated)
+		if err != nil {
+			return err
+		}
+		var imported AutomationV4
+		if err := json.Unmarshal(responseData, &imported); err != nil {
+			return err
+		}
+
+		if f.start {
+			if _, err := sendFmeFlowJSON(client, automationEndpoint(imported.ID)+"/"+automationActionStart, "POST", nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent); err != nil {
+				return fmt.Errorf("automation was imported with id %s but could not be started: %w", imported.ID, err)
+			}
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Automation successfully imported with id "+imported.ID+".")
+		} else {
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomationsImport(t *testing.T) {
+	importFile := filepath.Join(t.TempDir(), "process-uploads.json")
+	os.WriteFile(importFile, []byte(`{"name": "Process Uploads", "nodes": []}`), 0644)
+
+	// every start and stop request is recorded in actions
+	actions := []string{}
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/automations/import" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name": "Process Uploads (copy)", "nodes": []}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, err = w.Write([]byte(`{"id": "d4", "name": "Process Uploads (copy)"}`))
+			require.NoError(t, err)
+		} else if r.Method == "POST" && (strings.HasSuffix(r.URL.Path, "/start") || strings.HasSuffix(r.URL.Path, "/stop")) {
+			actions = append(actions, strings.TrimPrefix(r.URL.Path, "/fmeapiv4/automations/"))
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"automations", "import", "-f", importFile, "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing file",
+			args:        []string{"automations", "import"},
+			wantErrText: "required flag(s) \"file\" not set",
+		},
+		{
+			name:        "invalid file",
+			args:        []string{"automations", "import", "-f", "-"},
+			stdin:       "not json",
+			wantErrText: "could not parse automation -: invalid character 'o' in literal null (expecting 'u')",
+		},
+		{
+			name:            "import and start",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"automations", "import", "-f", importFile, "--name", "Process Uploads (copy)", "--start"},
+			wantOutputRegex: "^Automation successfully imported with id d4.\n$",
+		},
+		{
+			name:           "import from stdin json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"automations", "import", "-f", "-", "--name", "Process Uploads (copy)", "--json"},
+			stdin:          `{"name": "Process Uploads", "nodes": []}`,
+			wantOutputJson: `{"id": "d4", "name": "Process Uploads (copy)"}`,
+		},
+	}
+
+	runTests(cases, t)
+	require.Equal(t, []string{"d4/start"}, actions)
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomations(t *testing.T) {
+	automationsListBody := `{
+		"items": [
+		  {
+			"id": "63f2489a-f3fc-4fa0-8df8-198de602b922",
+			"name": "Process Uploads",
+			"description": "Process files uploaded to the uploads folder",
+			"state": "running",
+			"owner": "admin",
+			"lastUpdated": "2026-09-01T12:00:00Z"
+		  },
+		  {
+			"id": "0b7e6f54-7d8c-4c38-8a8b-3f2d6b1a9c01",
+			"name": "Nightly Cleanup",
+			"description": "",
+			"state": "stopped",
+			"owner": "admin",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  },
+		  {
+			"id": "c1",
+			"name": "Copy",
+			"description": "",
+			"state": "stopped",
+			"owner": "author",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  },
+		  {
+			"id": "c2",
+			"name": "Copy",
+			"description": "",
+			"state": "stopped",
+			"owner": "author",
+			"lastUpdated": "2026-08-15T08:30:00Z"
+		  }
+		],
+		"limit": 4,
+		"offset": 0,
+		"totalCount": 4
+	  }`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/automations" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(automationsListBody))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"automations", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"automations"},
+		},
+		{
+			name:        "invalid state",
+			args:        []string{"automations", "--state", "paused"},
+			wantErrText: "invalid value \"paused\" for --state. Must be one of running, stopped",
+		},
+		{
+			name:            "list automations",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"automations"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*ID[\\s]*STATE[\\s]*OWNER[\\s]*LAST UPDATED[\\s]*Process Uploads[\\s]*63f2489a-f3fc-4fa0-8df8-198de602b922[\\s]*running[\\s]*admin[\\s]*2026-09-01 12:00:00 \\+0000 UTC[\\s]*Nightly Cleanup[\\s]*0b7e6f54-7d8c-4c38-8a8b-3f2d6b1a9c01[\\s]*stopped[\\s]*admin[\\s]*2026-08-15 08:30:00 \\+0000 UTC[\\s]*Copy[\\s]*c1.*[\\s]*Copy[\\s]*c2.*[\\s]*$",
+		},
+		{
+			name:            "list running automation ids",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"automations", "list", "--state", "running", "--output=custom-columns=ID:.id", "--no-headers"},
+			wantOutputRegex: "^[\\s]*63f2489a-f3fc-4fa0-8df8-198de602b922[\\s]*$",
+		},
+		{
+			name:           "list automations json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"automations", "--json"},
+			wantOutputJson: automationsListBody,
+		},
+	}
+
+	runTests(cases, t)
+}
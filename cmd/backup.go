@@ -8,8 +8,10 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -34,6 +36,12 @@ type backupFlags struct {
 	backupSuccessTopic  string
 	backupResource      bool
 	suppressFileRename  bool
+	wait                bool
+	timeout             time.Duration
+	verify              bool
+	timestamp           bool
+	keep                int
+	keepWithin          string
 	apiVersion          apiVersionFlag
 }
 
@@ -49,9 +57,21 @@ func newBackupCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "backup",
 		Short: "Backs up the FME Server configuration",
-		Long:  "Backs up the FME Server configuration to a local file or to a shared resource location on the FME Server.",
+		Long: `Backs up the FME Server configuration to a local file or to a shared resource location on the FME Server.
+Use --verify to check that a downloaded backup is a complete package, and --wait to wait for a backup to a shared resource to finish.
+Use --keep or --keep-within to rotate backups. A timestamp is added to the name of the package, and older timestamped packages with the same name are deleted from the directory or shared resource folder the backup is written to.`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-
+			if f.keep < 0 {
+				return errors.New("--keep must be 0 or greater")
+			}
+			if f.keepWithin != "" {
+				if _, err := parseRetentionAge(f.keepWithin); err != nil {
+					return err
+				}
+			}
+			if f.backupResource && (f.keep > 0 || f.keepWithin != "") && !f.wait {
+				return errors.New("--keep and --keep-within require --wait when backing up to a shared resource")
+			}
 			return nil
 		},
 		Example: `
@@ -59,7 +79,13 @@ func newBackupCmd() *cobra.Command {
   fmeflow backup -f my_local_backup.fsconfig
 	
   # back up to the "Backup" folder in the FME Server Shared Resources with the file name my_fme_backup.fsconfig
-  fmeflow backup --resource --export-package my_fme_backup.fsconfig`,
+  fmeflow backup --resource --export-package my_fme_backup.fsconfig
+
+  # back up to a local file, check the downloaded package and keep only the 7 most recent backups
+  fmeflow backup -f backups/nightly.fsconfig --verify --keep 7
+
+  # back up to a shared resource, wait for it to finish and delete backups older than 30 days
+  fmeflow backup --resource --export-package nightly.fsconfig --wait --keep-within 30d`,
 		Args: NoArgs,
 		RunE: backupRun(&f),
 	}
@@ -70,12 +96,20 @@ func newBackupCmd() *cobra.Command {
 	cmd.Flags().StringVar(&f.backupFailureTopic, "failure-topic", "", "Topic to notify on failure of the backup. In V3, default is MIGRATION_ASYNC_JOB_FAILURE")
 	cmd.Flags().StringVar(&f.backupSuccessTopic, "success-topic", "", "Topic to notify on success of the backup. In V3, default is MIGRATION_ASYNC_JOB_SUCCESS")
 	cmd.Flags().BoolVar(&f.suppressFileRename, "suppress-file-rename", false, "Specify this flag to not add .fsconfig to the output file automatically")
+	cmd.Flags().BoolVar(&f.wait, "wait", false, "Wait for the backup to a shared resource to finish. Must be used with --resource.")
+	cmd.Flags().DurationVar(&f.timeout, "timeout", 1*time.Hour, "How long to wait for the backup to finish.")
+	cmd.Flags().BoolVar(&f.verify, "verify", false, "Check that the downloaded backup is a valid package and report what it contains.")
+	cmd.Flags().BoolVar(&f.timestamp, "timestamp", false, "Add a timestamp to the name of the backup package. This is always done when --keep or --keep-within is used.")
+	cmd.Flags().IntVar(&f.keep, "keep", 0, "Number of timestamped backups to keep. Older backups are deleted.")
+	cmd.Flags().StringVar(&f.keepWithin, "keep-within", "", "Keep timestamped backups newer than this age, such as 30d, 2w or 12h. Older backups are deleted.")
 	cmd.Flags().Var(&f.apiVersion, "api-version", "The api version to use when contacting FME Server. Must be one of v3 or v4")
 	cmd.MarkFlagsMutuallyExclusive("file", "resource")
 	cmd.MarkFlagsMutuallyExclusive("file", "resource-name")
 	cmd.MarkFlagsMutuallyExclusive("file", "export-package")
 	cmd.MarkFlagsMutuallyExclusive("file", "failure-topic")
 	cmd.MarkFlagsMutuallyExclusive("file", "success-topic")
+	cmd.MarkFlagsMutuallyExclusive("file", "wait")
+	cmd.MarkFlagsMutuallyExclusive("resource", "verify")
 	cmd.Flags().MarkHidden("suppress-file-rename")
 	return cmd
 }
@@ -93,6 +127,16 @@ func backupRun(f *backupFlags) func(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		keepWithin := time.Duration(0)
+		if f.keepWithin != "" {
+			keepWithin, _ = parseRetentionAge(f.keepWithin)
+		}
+		if f.timestamp || f.keep > 0 || keepWithin > 0 {
+			now := time.Now()
+			f.outputBackupFile = timestampBackupName(f.outputBackupFile, now)
+			f.backupExportPackage = timestampBackupName(f.backupExportPackage, now)
+		}
+
 		if f.apiVersion == "" {
 			if viper.GetInt("build") < backupV4BuildThreshold {
 				f.apiVersion = apiVersionFlagV3
@@ -140,9 +184,14 @@ func backupRun(f *backupFlags) func(cmd *cobra.Command, args []string) error {
 				if err != nil {
 					return err
 				}
+				out.Close()
 
 				fmt.Fprintln(cmd.OutOrStdout(), "FME Server backed up to "+f.outputBackupFile)
 
+				if err := finishLocalBackup(cmd, f, keepWithin); err != nil {
+					return err
+				}
+
 			} else {
 				// backup to a resource
 				var backupRequest backupResourceV4
@@ -191,6 +240,12 @@ func backupRun(f *backupFlags) func(cmd *cobra.Command, args []string) error {
 					}
 				}
 
+				if f.wait {
+					if err := finishResourceBackup(cmd, f, client, result.Id, keepWithin); err != nil {
+						return err
+					}
+				}
+
 			}
 
 		} else if f.apiVersion == apiVersionFlagV3 {
@@ -231,8 +286,13 @@ func backupRun(f *backupFlags) func(cmd *cobra.Command, args []string) error {
 				if err != nil {
 					return err
 				}
+				out.Close()
 
 				fmt.Fprintln(cmd.OutOrStdout(), "FME Server backed up to "+f.outputBackupFile)
+
+				if err := finishLocalBackup(cmd, f, keepWithin); err != nil {
+					return err
+				}
 			} else {
 				// backup to a resource
 				// add mandatory values
@@ -278,9 +338,62 @@ func backupRun(f *backupFlags) func(cmd *cobra.Command, args []string) error {
 						fmt.Fprintln(cmd.OutOrStdout(), string(responseData))
 					}
 				}
+
+				if f.wait {
+					if err := finishResourceBackup(cmd, f, client, result.Id, keepWithin); err != nil {
+						return err
+					}
+				}
 			}
 		}
 
 		return nil
 	}
 }
+
+// verify and rotate a backup that has been downloaded
+func finishLocalBackup(cmd *cobra.Command, f *backupFlags, keepWithin time.Duration) error {
+	if f.verify {
+		p, err := openPackage(f.outputBackupFile)
+		if err != nil {
+			return err
+		}
+		defer p.Close()
+		if err := p.verify(); err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), "Verified "+f.outputBackupFile+": "+p.summary())
+	}
+
+	if f.keep == 0 && keepWithin == 0 {
+		return nil
+	}
+	deleted, err := pruneLocalBackups(f.outputBackupFile, f.keep, keepWithin)
+	for _, name := range deleted {
+		fmt.Fprintln(cmd.OutOrStdout(), "Deleted old backup "+name)
+	}
+	return err
+}
+
+// wait for a backup to a shared resource to finish, then rotate the backups in the resource
+func finishResourceBackup(cmd *cobra.Command, f *backupFlags, client *http.Client, id int, keepWithin time.Duration) error {
+	// progress goes to stderr so that json output can still be parsed
+	if err := waitForMigrationTask(client, f.apiVersion, id, f.timeout, cmd.ErrOrStderr()); err != nil {
+		return err
+	}
+	p := resourcePath{resource: f.backupResourceName, path: path.Join("/", f.backupExportPackage)}
+	if !jsonOutput {
+		fmt.Fprintln(cmd.OutOrStdout(), "FME Server backed up to "+p.String())
+	}
+
+	if f.keep == 0 && keepWithin == 0 {
+		return nil
+	}
+	deleted, err := pruneResourceBackups(client, p, f.keep, keepWithin)
+	if !jsonOutput {
+		for _, name := range deleted {
+			fmt.Fprintln(cmd.OutOrStdout(), "Deleted old backup "+name)
+		}
+	}
+	return err
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the timestamp added to the names of backup packages so that old ones can be pruned
+const backupTimestampLayout = "20060102T150405Z"
+
+var retentionAgeRegexp = regexp.MustCompile(`^(\d+)([dw])$`)
+
+// add a timestamp to a package name, such as nightly.fsconfig to nightly_20240102T030405Z.fsconfig
+func timestampBackupName(name string, t time.Time) string {
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + "_" + t.UTC().Format(backupTimestampLayout) + ext
+}
+
+// parse how long to keep backups for. As well as the units time.ParseDuration accepts,
+// days and weeks can be given, such as 30d or 2w
+func parseRetentionAge(age string) (time.Duration, error) {
+	if m := retentionAgeRegexp.FindStringSubmatch(age); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid age %q. Must be a positive duration such as 30d, 2w or 12h", age)
+		}
+		day := 24 * time.Hour
+		if m[2] == "w" {
+			return time.Duration(n) * 7 * day, nil
+		}
+		return time.Duration(n) * day, nil
+	}
+	d, err := time.ParseDuration(age)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid age %q. Must be a positive duration such as 30d, 2w or 12h", age)
+	}
+	return d, nil
+}
+
+// find the timestamped backups of a package among a list of file names and return the ones
+// that should be deleted. The newest keep backups are kept, as is any backup newer than within.
+// If neither is set nothing is deleted
+func backupsToPrune(names []string, packageName string, keep int, within time.Duration, now time.Time) []string {
+	if keep <= 0 && within <= 0 {
+		return nil
+	}
+	ext := path.Ext(packageName)
+	pattern := regexp.MustCompile("^" + regexp.QuoteMeta(strings.TrimSuffix(packageName, ext)) + `_(\d{8}T\d{6}Z)` + regexp.QuoteMeta(ext) + "$")
+
+	type backup struct {
+		name    string
+		created time.Time
+	}
+	backups := []backup{}
+	for _, name := range names {
+		m := pattern.FindStringSubmatch(name)
+		if m == nil {
+			continue
+		}
+		created, err := time.Parse(backupTimestampLayout, m[1])
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{name: name, created: created})
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].created.After(backups[j].created)
+	})
+
+	prune := []string{}
+	for i, b := range backups {
+		if keep > 0 && i < keep {
+			continue
+		}
+		if within > 0 && now.Sub(b.created) <= within {
+			continue
+		}
+		prune = append(prune, b.name)
+	}
+	return prune
+}
+
+// delete old timestamped backups of a package from the directory it was downloaded to
+func pruneLocalBackups(file string, keep int, within time.Duration) ([]string, error) {
+	dir := filepath.Dir(file)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+
+	deleted := []string{}
+	for _, name := range backupsToPrune(names, untimestampedBackupName(filepath.Base(file)), keep, within, time.Now()) {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, filepath.Join(dir, name))
+	}
+	return deleted, nil
+}
+
+// delete old timestamped backups of a package from the shared resource directory it was exported to
+func pruneResourceBackups(client *http.Client, p resourcePath, keep int, within time.Duration) ([]string, error) {
+	dir := resourcePath{resource: p.resource, path: path.Dir(p.path)}
+	items, err := getResourceItemsV4(client, dir)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, item := range items {
+		if item.Type != resourceItemDirectory {
+			names = append(names, item.Name)
+		}
+	}
+
+	deleted := []string{}
+	for _, name := range backupsToPrune(names, untimestampedBackupName(path.Base(p.path)), keep, within, time.Now()) {
+		old := dir.join(name)
+		if _, err := sendFmeFlowJSON(client, old.itemEndpoint("item"), "DELETE", nil, http.StatusNoContent, http.StatusOK); err != nil {
+			return deleted, fmt.Errorf("could not delete old backup %s: %w", old, err)
+		}
+		deleted = append(deleted, old.String())
+	}
+	return deleted, nil
+}
+
+// remove the timestamp from the name of a backup package
+func untimestampedBackupName(name string) string {
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	if i := strings.LastIndex(base, "_"); i != -1 {
+		if _, err := time.Parse(backupTimestampLayout, base[i+1:]); err == nil {
+			return base[:i] + ext
+		}
+	}
+	return name
+}
@@ -1,13 +1,57 @@
 package cmd
 
 import (
+	"archive/zip"
+	"bytes"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
+var testPackageManifest = `{
+  "fmeserver": {"version": "FME Flow 2024.1", "build": 24612},
+  "items": [
+    {"type": "repository", "name": "Samples", "path": "repositories/Samples"},
+    {"type": "workspace", "name": "Samples/austinApartments.fmw", "path": "repositories/Samples/austinApartments.fmw"},
+    {"type": "workspace", "name": "Samples/easyTranslator.fmw", "path": "repositories/Samples/easyTranslator.fmw"},
+    {"type": "connection", "name": "warehouse", "path": "connections/warehouse.json"},
+    {"type": "user", "name": "jsmith"}
+  ]
+}`
+
+var testPackageFiles = map[string]string{
+	"repositories/Samples/austinApartments.fmw": "austin",
+	"repositories/Samples/easyTranslator.fmw":   "translator",
+	"connections/warehouse.json":                `{"name": "warehouse"}`,
+}
+
+// build a package archive in memory with the given manifest and files
+func newTestPackage(t *testing.T, manifest string, files map[string]string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	if manifest != "" {
+		f, err := w.Create(packageManifestName)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(manifest))
+		require.NoError(t, err)
+	}
+	for _, name := range sortedKeys(files) {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(files[name]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
 func TestBackupV4(t *testing.T) {
 	// standard responses for v3 and v4
 	okResponseV4 := `Random file contents`
@@ -17,6 +61,86 @@ func TestBackupV4(t *testing.T) {
 	require.NoError(t, err)
 	defer os.Remove(f.Name()) // clean up
 
+	migrationTaskWaitInterval = time.Millisecond
+
+	validPackage := string(newTestPackage(t, testPackageManifest, testPackageFiles))
+	incompleteFiles := map[string]string{"repositories/Samples/austinApartments.fmw": "austin"}
+	incompletePackage := string(newTestPackage(t, testPackageManifest, incompleteFiles))
+	noManifestPackage := string(newTestPackage(t, "", testPackageFiles))
+
+	// a directory of old backups to rotate
+	backupDir := t.TempDir()
+	for _, name := range []string{"nightly_20200101T000000Z.fsconfig", "nightly_20210101T000000Z.fsconfig", "nightly_20220101T000000Z.fsconfig", "weekly_20200101T000000Z.fsconfig", "nightly.fsconfig"} {
+		require.NoError(t, os.WriteFile(filepath.Join(backupDir, name), []byte("old"), 0644))
+	}
+
+	resourceItems := `{"items": [
+		{"name": "nightly_20200101T000000Z.fsconfig", "path": "/backups/nightly_20200101T000000Z.fsconfig", "type": "FILE"},
+		{"name": "nightly_20230101T000000Z.fsconfig", "path": "/backups/nightly_20230101T000000Z.fsconfig", "type": "FILE"},
+		{"name": "other.fsconfig", "path": "/backups/other.fsconfig", "type": "FILE"}
+	], "totalCount": 3, "limit": 3, "offset": 0}`
+
+	// every old backup that is deleted from the shared resource is recorded in deleted
+	deleted := []string{}
+
+	// this is the generic mock up for backing up to a shared resource
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/migrations/backup/resource" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Regexp(t, `"packagePath":"backups/nightly_\d{8}T\d{6}Z.fsconfig"`, string(body))
+			w.WriteHeader(http.StatusAccepted)
+			_, err = w.Write([]byte(`{"id":4}`))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/resources/connections/FME_SHAREDRESOURCE_BACKUP/items" {
+			require.Equal(t, "/backups", r.URL.Query().Get("path"))
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(resourceItems))
+			require.NoError(t, err)
+		} else if r.Method == "DELETE" && r.URL.Path == "/fmeapiv4/resources/connections/FME_SHAREDRESOURCE_BACKUP/item" {
+			deleted = append(deleted, r.URL.Query().Get("path"))
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// the migration task is running the first time it is checked and then succeeds
+	successChecks := 0
+	customHttpServerHandlerSuccess := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/migrations/tasks/4" {
+			successChecks++
+			w.WriteHeader(http.StatusOK)
+			if successChecks == 1 {
+				_, err := w.Write([]byte(`{"id": 4, "status": "RUNNING"}`))
+				require.NoError(t, err)
+			} else {
+				_, err := w.Write([]byte(`{"id": 4, "status": "SUCCESS", "result": "Export finished"}`))
+				require.NoError(t, err)
+			}
+			return
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// the migration task is running the first time it is checked and then fails
+	failedChecks := 0
+	customHttpServerHandlerFailed := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/migrations/tasks/4" {
+			failedChecks++
+			w.WriteHeader(http.StatusOK)
+			if failedChecks == 1 {
+				_, err := w.Write([]byte(`{"id": 4, "status": "RUNNING"}`))
+				require.NoError(t, err)
+			} else {
+				_, err := w.Write([]byte(`{"id": 4, "status": "FAILED", "result": "Export finished"}`))
+				require.NoError(t, err)
+			}
+			return
+		}
+		customHttpServerHandler(w, r)
+	}
+
 	cases := []testCase{
 		{
 			name:               "unknown flag",
@@ -138,8 +262,101 @@ func TestBackupV4(t *testing.T) {
 			wantErrOutputRegex: "flag needs an argument: --failure-topic",
 			fmeflowBuild:       26000,
 		},
+		{
+			name:             "verify downloaded backup",
+			statusCode:       http.StatusOK,
+			args:             []string{"backup", "--file", f.Name(), "--verify"},
+			body:             validPackage,
+			wantOutputRegex:  "Verified .*fmeflow-backup.fsconfig: 5 items \\(1 repository, 2 workspaces, 1 connection, 1 user\\) from FME Flow 2024.1 \\(build 24612\\)\n$",
+			wantFileContents: fileContents{file: f.Name(), contents: validPackage},
+			fmeflowBuild:     26000,
+		},
+		{
+			name:         "verify backup that isn't an archive",
+			statusCode:   http.StatusOK,
+			args:         []string{"backup", "--file", f.Name(), "--verify"},
+			body:         okResponseV4,
+			wantErrText:  f.Name() + " is not a valid package: zip: not a valid zip file",
+			fmeflowBuild: 26000,
+		},
+		{
+			name:         "verify backup with no manifest",
+			statusCode:   http.StatusOK,
+			args:         []string{"backup", "--file", f.Name(), "--verify"},
+			body:         noManifestPackage,
+			wantErrText:  f.Name() + " is not a valid package: no manifest.json found",
+			fmeflowBuild: 26000,
+		},
+		{
+			name:         "verify incomplete backup",
+			statusCode:   http.StatusOK,
+			args:         []string{"backup", "--file", f.Name(), "--verify"},
+			body:         incompletePackage,
+			wantErrText:  f.Name() + " is incomplete: the contents of workspace Samples/easyTranslator.fmw are missing",
+			fmeflowBuild: 26000,
+		},
+		{
+			name:            "keep the newest backups",
+			statusCode:      http.StatusOK,
+			args:            []string{"backup", "--file", filepath.Join(backupDir, "nightly.fsconfig"), "--keep", "2"},
+			body:            validPackage,
+			wantOutputRegex: "FME Server backed up to .*nightly_\\d{8}T\\d{6}Z.fsconfig\nDeleted old backup .*nightly_20210101T000000Z.fsconfig\nDeleted old backup .*nightly_20200101T000000Z.fsconfig\n$",
+			fmeflowBuild:    26000,
+		},
+		{
+			name:         "negative keep",
+			args:         []string{"backup", "--keep", "-1"},
+			wantErrText:  "--keep must be 0 or greater",
+			fmeflowBuild: 26000,
+		},
+		{
+			name:         "invalid keep within",
+			args:         []string{"backup", "--keep-within", "soon"},
+			wantErrText:  "invalid age \"soon\". Must be a positive duration such as 30d, 2w or 12h",
+			fmeflowBuild: 26000,
+		},
+		{
+			name:         "keep requires wait for a shared resource",
+			args:         []string{"backup", "--resource", "--keep", "3"},
+			wantErrText:  "--keep and --keep-within require --wait when backing up to a shared resource",
+			fmeflowBuild: 26000,
+		},
+		{
+			name:         "don't allow file and wait flags",
+			args:         []string{"backup", "--file", f.Name(), "--wait"},
+			wantErrText:  "if any flags in the group [file wait] are set none of the others can be; [file wait] were all set",
+			fmeflowBuild: 26000,
+		},
+		{
+			name:               "wait for backup to shared resource and rotate",
+			httpServer:         httptest.NewServer(http.HandlerFunc(customHttpServerHandlerSuccess)),
+			args:               []string{"backup", "--resource", "--export-package", "backups/nightly.fsconfig", "--wait", "--keep-within", "30d"},
+			wantOutputRegex:    "^Backup task submitted with id: 4\nFME Server backed up to FME_SHAREDRESOURCE_BACKUP:/backups/nightly_\\d{8}T\\d{6}Z.fsconfig\nDeleted old backup FME_SHAREDRESOURCE_BACKUP:/backups/nightly_20230101T000000Z.fsconfig\nDeleted old backup FME_SHAREDRESOURCE_BACKUP:/backups/nightly_20200101T000000Z.fsconfig\n$",
+			wantErrOutputRegex: "^Migration task 4 is RUNNING\nMigration task 4 is SUCCESS\n$",
+			fmeflowBuild:       26000,
+		},
+		{
+			name:         "wait for failed backup to shared resource",
+			httpServer:   httptest.NewServer(http.HandlerFunc(customHttpServerHandlerFailed)),
+			args:         []string{"backup", "--resource", "--export-package", "backups/nightly.fsconfig", "--wait", "--keep", "1"},
+			wantErrText:  "migration task 4 finished with status FAILED: Export finished. Use \"fmeflow migration tasks --id 4 --log\" to see the log",
+			fmeflowBuild: 26000,
+		},
 	}
 
 	runTests(cases, t)
 
+	// the new backup, the newest old backup and the ones that aren't nightly backups are left
+	entries, err := os.ReadDir(backupDir)
+	require.NoError(t, err)
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	require.Len(t, names, 4)
+	require.Regexp(t, `^nightly_\d{8}T\d{6}Z.fsconfig$`, names[2])
+	require.Equal(t, []string{"nightly.fsconfig", "nightly_20220101T000000Z.fsconfig", "weekly_20200101T000000Z.fsconfig"}, slices.DeleteFunc(names, func(name string) bool { return name == names[2] }))
+
+	// the failed backup doesn't delete anything
+	require.Equal(t, []string{"/backups/nightly_20230101T000000Z.fsconfig", "/backups/nightly_20200101T000000Z.fsconfig"}, deleted)
 }
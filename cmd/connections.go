@@ -32,6 +32,7 @@ type Connection struct {
 	Name       string                 `json:"name"`
 	Category   string                 `json:"category"`
 	Type       string                 `json:"type"`
+	Username   string                 `json:"username,omitempty"`
 	Owner      string                 `json:"owner"`
 	Shareable  bool                   `json:"shareable"`
 	Parameters map[string]interface{} `json:"parameters"`
@@ -42,7 +43,7 @@ func newConnectionsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "connections",
 		Short: "Lists connections on FME Flow",
-		Long:  "Lists connections on FME Flow. Pass in a name to retrieve information on a single connection.",
+		Long:  "Lists connections on FME Flow. Pass in a name to retrieve information on a single connection. Use the subcommands to create, update, delete, export, import or test connections.",
 		Example: `
   # List all connections
   fmeflow connections
@@ -71,6 +72,9 @@ func newConnectionsCmd() *cobra.Command {
 	cmd.AddCommand(newConnectionCreateCmd())
 	cmd.AddCommand(newConnectionUpdateCmd())
 	cmd.AddCommand(newConnectionDeleteCmd())
+	cmd.AddCommand(newConnectionExportCmd())
+	cmd.AddCommand(newConnectionImportCmd())
+	cmd.AddCommand(newConnectionTestCmd())
 
 	return cmd
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -29,6 +30,9 @@ type ConnectionCreateFlags struct {
 	authenticationMethod string
 	username             string
 	password             string
+	passwordFile         string
+	passwordStdin        bool
+	passwordEnv          string
 	parameter            []string
 }
 
@@ -42,13 +46,19 @@ func newConnectionCreateCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a connection",
-		Long:  `Create a connection.`,
+		Long:  `Create a connection. To keep the password out of the shell history and process list, use --password-file, --password-stdin or --password-env instead of --password.`,
 		Example: `
   # Create a PostgreSQL connection
   fmeflow connections create --name myPGSQLConnection --category database --type PostgreSQL --parameter HOST=myDBHost --parameter PORT=5432 --parameter DATASET=dbname --parameter USER_NAME=dbuser --parameter SSL_OPTIONS="" --parameter SSLMODE=prefer
 
   # Create a Google Drive connection (web service must already exist on FME Flow)
   fmeflow connections create --name googleDriveConn --category oauthV2 --type "Google Drive"
+
+  # Create a basic connection, reading the password from the environment variable SERVICE_PASSWORD
+  fmeflow connections create --name serviceAccount --category basic --username svc --password-env SERVICE_PASSWORD
+
+  # Create a basic connection, reading the password from stdin
+  cat password.txt | fmeflow connections create --name serviceAccount --category basic --username svc --password-stdin
 `,
 
 		Args: NoArgs,
@@ -61,10 +71,14 @@ func newConnectionCreateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&f.authenticationMethod, "authentication-method", "", "Authentication method of the connection to create.")
 	cmd.Flags().StringVar(&f.username, "username", "", "Username of the connection to create.")
 	cmd.Flags().StringVar(&f.password, "password", "", "Password of the connection to create.")
+	cmd.Flags().StringVar(&f.passwordFile, "password-file", "", "Path to a file containing the password of the connection to create.")
+	cmd.Flags().BoolVar(&f.passwordStdin, "password-stdin", false, "Read the password of the connection to create from stdin.")
+	cmd.Flags().StringVar(&f.passwordEnv, "password-env", "", "Name of an environment variable containing the password of the connection to create.")
 	cmd.Flags().StringArrayVar(&f.parameter, "parameter", []string{}, "Parameters of the connection to create. Must be of the form name=value. Can be specified multiple times.")
 
 	cmd.MarkFlagRequired("name")
 	cmd.MarkFlagRequired("category")
+	cmd.MarkFlagsMutuallyExclusive("password", "password-file", "password-stdin", "password-env")
 	return cmd
 }
 
@@ -82,7 +96,11 @@ func connectionCreateRun(f *ConnectionCreateFlags) func(cmd *cobra.Command, args
 			newConnection.AuthenticationMethod = f.authenticationMethod
 		}
 		newConnection.Username = f.username
-		newConnection.Password = f.password
+		password, err := readPasswordFlags(cmd, f.password, f.passwordFile, f.passwordStdin, f.passwordEnv)
+		if err != nil {
+			return err
+		}
+		newConnection.Password = password
 
 		if len(f.parameter) != 0 {
 			newConnection.Parameters = make(map[string]interface{})
@@ -154,3 +172,30 @@ func connectionCreateRun(f *ConnectionCreateFlags) func(cmd *cobra.Command, args
 		return nil
 	}
 }
+
+// get a password from whichever of the password flags was set. Reading the password from
+// a file, stdin or an environment variable keeps it out of the shell history and process list
+func readPasswordFlags(cmd *cobra.Command, password string, passwordFile string, passwordStdin bool, passwordEnv string) (string, error) {
+	if passwordFile != "" {
+		contents, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(contents), "\r\n"), nil
+	}
+	if passwordStdin {
+		contents, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(contents), "\r\n"), nil
+	}
+	if passwordEnv != "" {
+		value, found := os.LookupEnv(passwordEnv)
+		if !found {
+			return "", fmt.Errorf("environment variable %s is not set", passwordEnv)
+		}
+		return value, nil
+	}
+	return password, nil
+}
@@ -2,7 +2,11 @@ package cmd
 
 import (
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestConnectionsCreate(t *testing.T) {
@@ -22,6 +26,10 @@ func TestConnectionsCreate(t *testing.T) {
 		}
 	  }`
 
+	passwordFile := filepath.Join(t.TempDir(), "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("fromfile\n"), 0600))
+	t.Setenv("FMEFLOW_TEST_CONNECTION_PASSWORD", "fromenv")
+
 	cases := []testCase{
 		{
 			name:               "unknown flag",
@@ -68,6 +76,40 @@ func TestConnectionsCreate(t *testing.T) {
 			body:        responseParameterValidationFailed,
 			wantErrText: "Parameter Validation Failed\nauthenticationMethod: Connection authentication method must be supplied\ntype: must not be blank",
 		},
+		{
+			name:            "create connection password from file",
+			statusCode:      http.StatusCreated,
+			args:            []string{"connections", "create", "--name", "svc", "--category", "basic", "--username", "user", "--password-file", passwordFile},
+			wantBodyJson:    `{"category":"basic","name":"svc","type":"","username":"user","password":"fromfile"}`,
+			wantOutputRegex: "^[\\s]*Connection successfully created.[\\s]*$",
+		},
+		{
+			name:            "create connection password from stdin",
+			statusCode:      http.StatusCreated,
+			args:            []string{"connections", "create", "--name", "svc", "--category", "basic", "--username", "user", "--password-stdin"},
+			stdin:           "fromstdin\r\n",
+			wantBodyJson:    `{"category":"basic","name":"svc","type":"","username":"user","password":"fromstdin"}`,
+			wantOutputRegex: "^[\\s]*Connection successfully created.[\\s]*$",
+		},
+		{
+			name:            "create connection password from env",
+			statusCode:      http.StatusCreated,
+			args:            []string{"connections", "create", "--name", "svc", "--category", "basic", "--username", "user", "--password-env", "FMEFLOW_TEST_CONNECTION_PASSWORD"},
+			wantBodyJson:    `{"category":"basic","name":"svc","type":"","username":"user","password":"fromenv"}`,
+			wantOutputRegex: "^[\\s]*Connection successfully created.[\\s]*$",
+		},
+		{
+			name:        "create connection password env not set",
+			statusCode:  http.StatusCreated,
+			args:        []string{"connections", "create", "--name", "svc", "--category", "basic", "--password-env", "FMEFLOW_TEST_MISSING_PASSWORD"},
+			wantErrText: "environment variable FMEFLOW_TEST_MISSING_PASSWORD is not set",
+		},
+		{
+			name:        "create connection multiple password flags",
+			statusCode:  http.StatusCreated,
+			args:        []string{"connections", "create", "--name", "svc", "--category", "basic", "--password", "abc", "--password-stdin"},
+			wantErrText: "if any flags in the group [password password-file password-stdin password-env] are set none of the others can be; [password password-stdin] were all set",
+		},
 	}
 
 	runTests(cases, t)
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/spf13/cobra"
+)
+
+type connectionsExportFlags struct {
+	names      []string
+	outputType string
+}
+
+func newConnectionExportCmd() *cobra.Command {
+	f := connectionsExportFlags{}
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export connections to a file",
+		Long: `Export the definitions of connections on FME Flow so they can be imported into another FME Flow with "fmeflow connections import".
+Secrets can't be read back from FME Flow, so passwords and connection parameters that look like secrets are left out. Supply them when importing with --secrets-file.
+The output is a manifest, so it can also be used with "fmeflow apply".`,
+		Example: `
+  # Export all connections to a file
+  fmeflow connections export > connections.yaml
+
+  # Export two connections as json
+  fmeflow connections export --name warehouse --name googleDriveConn --output json`,
+		Args: NoArgs,
+		RunE: connectionsExportRun(&f),
+	}
+
+	cmd.Flags().StringArrayVar(&f.names, "name", []string{}, "Name of a connection to export. Can be passed in multiple times. Defaults to all connections.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "yaml", "Specify the output type. Should be one of yaml or json")
+	return cmd
+}
+
+func connectionsExportRun(f *connectionsExportFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+		if f.outputType != "yaml" && f.outputType != "json" {
+			return errors.New("invalid output format specified")
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		connections, err := exportConnections(client, false)
+		if err != nil {
+			return err
+		}
+
+		if len(f.names) != 0 {
+			for _, name := range f.names {
+				if !slices.ContainsFunc(connections, func(c ManifestConnection) bool { return c.Name == name }) {
+					return fmt.Errorf("connection %s does not exist", name)
+				}
+			}
+			connections = slices.DeleteFunc(connections, func(c ManifestConnection) bool { return !slices.Contains(f.names, c.Name) })
+		}
+
+		return writeManifest(cmd, FlowManifest{Connections: connections}, f.outputType)
+	}
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestConnectionsExport(t *testing.T) {
+	response := `{
+		"items": [
+		  {"name": "warehouse", "category": "database", "type": "PostgreSQL", "owner": "admin", "shareable": true, "parameters": {"HOST": "db.example.com", "PORT": "5432"}},
+		  {"name": "googleMaps", "category": "token", "type": "Google Maps", "owner": "admin", "shareable": true, "parameters": {"API_KEY": "abc123"}}
+		],
+		"totalCount": 2,
+		"limit": 100,
+		"offset": 0
+	  }`
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"connections", "export", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"connections", "export"},
+		},
+		{
+			name:            "export connections",
+			statusCode:      http.StatusOK,
+			body:            response,
+			args:            []string{"connections", "export"},
+			wantOutputRegex: "^connections:\n  - name: googleMaps\n    category: token\n    type: Google Maps\n  - name: warehouse\n    category: database\n    type: PostgreSQL\n    parameters:\n      HOST: db.example.com\n      PORT: \"5432\"\n$",
+		},
+		{
+			name:           "export single connection json",
+			statusCode:     http.StatusOK,
+			body:           response,
+			args:           []string{"connections", "export", "--name", "warehouse", "--json"},
+			wantOutputJson: `{"connections":[{"name":"warehouse","category":"database","type":"PostgreSQL","parameters":{"HOST":"db.example.com","PORT":"5432"}}]}`,
+		},
+		{
+			name:        "export connection that does not exist",
+			statusCode:  http.StatusOK,
+			body:        response,
+			args:        []string{"connections", "export", "--name", "missing"},
+			wantErrText: "connection missing does not exist",
+		},
+	}
+
+	runTests(cases, t)
+}
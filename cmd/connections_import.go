@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type ConnectionSecrets struct {
+	Password   string                 `yaml:"password"`
+	Parameters map[string]interface{} `yaml:"parameters"`
+}
+
+type ConnectionImportResult struct {
+	Name       string `json:"name"`
+	Action     string `json:"action"`
+	ImportedAs string `json:"importedAs"`
+}
+
+type connectionsImportFlags struct {
+	file        string
+	secretsFile string
+	onConflict  string
+	outputType  string
+	noHeaders   bool
+}
+
+var importConflictModes = []string{"skip", "overwrite", "rename"}
+
+func newConnectionImportCmd() *cobra.Command {
+	f := connectionsImportFlags{}
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import connections from a file",
+		Long: `Import connections from a file created by "fmeflow connections export".
+Secrets aren't included in an exported file. Use --secrets-file to supply the passwords and secret parameters of the connections from a separate yaml file in the form:
+
+  warehouse:
+    password: ${env:PG_PASSWORD}
+  googleMaps:
+    parameters:
+      API_KEY: abc123
+
+Values in either file can reference environment variables as ${env:NAME}.
+Use --on-conflict to choose what happens when a connection with the same name already exists:
+  skip: leave the existing connection unchanged
+  overwrite: replace the existing connection with the imported one
+  rename: import the connection with a new name, such as warehouse_2`,
+		Example: `
+  # Import the connections in connections.yaml, skipping any that already exist
+  fmeflow connections import -f connections.yaml
+
+  # Import the connections with their passwords, overwriting any that already exist
+  fmeflow connections import -f connections.yaml --secrets-file secrets.yaml --on-conflict overwrite
+
+  # Copy connections from one FME Flow to another
+  fmeflow connections export | fmeflow connections import -f - --config prod-config.yaml`,
+		Args: NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains(importConflictModes, f.onConflict) {
+				return fmt.Errorf("invalid value %q for --on-conflict. Must be one of %s", f.onConflict, strings.Join(importConflictModes, ", "))
+			}
+			return nil
+		},
+		RunE: connectionsImportRun(&f),
+	}
+
+	cmd.Flags().StringVarP(&f.file, "file", "f", "", "Path to the file of connections to import. Use - to read from stdin.")
+	cmd.Flags().StringVar(&f.secretsFile, "secrets-file", "", "Path to a yaml file containing the passwords and secret parameters of the connections to import.")
+	cmd.Flags().StringVar(&f.onConflict, "on-conflict", "skip", "What to do when a connection already exists. Should be one of skip, overwrite or rename.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.RegisterFlagCompletionFunc("on-conflict", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return importConflictModes, cobra.ShellCompDirectiveDefault
+	})
+	cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func connectionsImportRun(f *connectionsImportFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		manifest, err := loadManifest(cmd, f.file)
+		if err != nil {
+			return err
+		}
+		if len(manifest.Connections) == 0 {
+			return fmt.Errorf("no connections found in %s", f.file)
+		}
+
+		secrets := map[string]ConnectionSecrets{}
+		if f.secretsFile != "" {
+			secrets, err = loadConnectionSecrets(f.secretsFile)
+			if err != nil {
+				return err
+			}
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		items, err := getAllItemsV4[Connection](client, "/fmeapiv4/connections")
+		if err != nil {
+			return err
+		}
+		existing := map[string]Connection{}
+		for _, item := range items {
+			existing[item.Name] = item
+		}
+
+		results := []ConnectionImportResult{}
+		for _, connection := range manifest.Connections {
+			if secret, ok := secrets[connection.Name]; ok {
+				if secret.Password != "" {
+					connection.Password = secret.Password
+				}
+				if len(secret.Parameters) != 0 && connection.Parameters == nil {
+					connection.Parameters = map[string]interface{}{}
+				}
+				for name, value := range secret.Parameters {
+					connection.Parameters[name] = value
+				}
+			}
+
+			result := ConnectionImportResult{Name: connection.Name, ImportedAs: connection.Name}
+			current, exists := existing[connection.Name]
+			if !exists {
+				result.Action = "created"
+			} else {
+				switch f.onConflict {
+				case "skip":
+					result.Action = "skipped"
+					result.ImportedAs = ""
+				case "overwrite":
+					result.Action = "overwritten"
+				case "rename":
+					result.Action = "renamed"
+					for i := 2; ; i++ {
+						name := connection.Name + "_" + strconv.Itoa(i)
+						if _, taken := existing[name]; !taken {
+							result.ImportedAs = name
+							break
+						}
+					}
+				}
+			}
+
+			switch result.Action {
+			case "created", "renamed":
+				err = createImportedConnection(client, connection, result.ImportedAs)
+			case "overwritten":
+				if current.Category != connection.Category || (connection.Type != "" && current.Type != connection.Type) {
+					// the category and type can't be updated, so the connection has to be recreated
+					_, err = sendFmeFlowJSON(client, "/fmeapiv4/connections/"+connection.Name, "DELETE", nil, http.StatusNoContent)
+					if err == nil {
+						err = createImportedConnection(client, connection, connection.Name)
+					}
+				} else {
+					update := UpdateConnection{
+						Category:             current.Category,
+						AuthenticationMethod: connection.AuthenticationMethod,
+						Username:             connection.Username,
+						Password:             connection.Password,
+						Parameters:           connection.Parameters,
+					}
+					_, err = sendFmeFlowJSON(client, "/fmeapiv4/connections/"+connection.Name, "PUT", update, http.StatusNoContent)
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("could not import connection %s: %w", connection.Name, err)
+			}
+			if result.ImportedAs != "" {
+				// later connections in the file can't be renamed to this name
+				existing[result.ImportedAs] = Connection{Name: result.ImportedAs}
+			}
+			results = append(results, result)
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Name", "Action", "Imported As"})
+
+			for _, element := range results {
+				t.AppendRow(table.Row{element.Name, element.Action, element.ImportedAs})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			jsonData, err := json.Marshal(results)
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(jsonData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			// we have to marshal the Items array, then create an array of marshalled items
+			// to pass to the creation of the table.
+			marshalledItems := [][]byte{}
+			for _, element := range results {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+func createImportedConnection(client *http.Client, connection ManifestConnection, name string) error {
+	newConnection := NewConnection{
+		Category:             connection.Category,
+		Name:                 name,
+		Type:                 connection.Type,
+		AuthenticationMethod: connection.AuthenticationMethod,
+		Username:             connection.Username,
+		Password:             connection.Password,
+		Parameters:           connection.Parameters,
+	}
+	_, err := sendFmeFlowJSON(client, "/fmeapiv4/connections", "POST", newConnection, http.StatusCreated)
+	return err
+}
+
+// read a yaml file of connection names to their passwords and secret parameters
+func loadConnectionSecrets(path string) (map[string]ConnectionSecrets, error) {
+	secrets := map[string]ConnectionSecrets{}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return secrets, err
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(contents, &document); err != nil {
+		return secrets, fmt.Errorf("could not parse secrets file %s: %w", path, err)
+	}
+	if err := expandManifestEnv(&document); err != nil {
+		return secrets, err
+	}
+	if err := document.Decode(&secrets); err != nil {
+		return secrets, fmt.Errorf("could not parse secrets file %s: %w", path, err)
+	}
+	return secrets, nil
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionsImport(t *testing.T) {
+	dir := t.TempDir()
+	connectionsFile := filepath.Join(dir, "connections.yaml")
+	require.NoError(t, os.WriteFile(connectionsFile, []byte(`connections:
+  - name: warehouse
+    category: database
+    type: PostgreSQL
+    username: fme
+    parameters:
+      HOST: db.example.com
+  - name: googleMaps
+    category: token
+    type: Google Maps
+`), 0600))
+	secretsFile := filepath.Join(dir, "secrets.yaml")
+	require.NoError(t, os.WriteFile(secretsFile, []byte(`warehouse:
+  password: ${env:FMEFLOW_TEST_PASSWORD}
+googleMaps:
+  parameters:
+    API_KEY: abc123
+`), 0600))
+	emptyFile := filepath.Join(dir, "empty.yaml")
+	require.NoError(t, os.WriteFile(emptyFile, []byte("repositories: []\n"), 0600))
+	t.Setenv("FMEFLOW_TEST_PASSWORD", "secret")
+
+	responseList := `{"items":[{"name":"warehouse","category":"database","type":"PostgreSQL","parameters":{"HOST":"old.example.com"}},{"name":"warehouse_2","category":"database","type":"PostgreSQL"}],"totalCount":2,"limit":100,"offset":0}`
+
+	// a fake FME Flow that only accepts the requests in changes with the given bodies
+	newImportServer := func(changes map[string]string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "GET" && r.URL.Path == "/fmeapiv4/connections" {
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(responseList))
+				require.NoError(t, err)
+				return
+			}
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			request := r.Method + " " + r.URL.Path
+			wantBodies, ok := changes[request]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if wantBodies != "" {
+				require.Contains(t, wantBodies, string(body))
+			}
+			if r.Method == "POST" {
+				w.WriteHeader(http.StatusCreated)
+			} else {
+				w.WriteHeader(http.StatusNoContent)
+			}
+		}))
+	}
+
+	createGoogleMaps := `{"category":"token","name":"googleMaps","type":"Google Maps","username":"","password":"","parameters":{"API_KEY":"abc123"}}`
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"connections", "import", "-f", connectionsFile, "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing file flag",
+			wantErrText: "required flag(s) \"file\" not set",
+			args:        []string{"connections", "import"},
+		},
+		{
+			name:        "invalid conflict mode",
+			wantErrText: "invalid value \"merge\" for --on-conflict. Must be one of skip, overwrite, rename",
+			args:        []string{"connections", "import", "-f", connectionsFile, "--on-conflict", "merge"},
+		},
+		{
+			name:        "no connections in file",
+			wantErrText: "no connections found in " + emptyFile,
+			args:        []string{"connections", "import", "-f", emptyFile},
+		},
+		{
+			name: "import skip existing",
+			httpServer: newImportServer(map[string]string{
+				"POST /fmeapiv4/connections": `{"category":"token","name":"googleMaps","type":"Google Maps","username":"","password":""}`,
+			}),
+			args:            []string{"connections", "import", "-f", connectionsFile},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*ACTION[\\s]*IMPORTED AS[\\s]*\n[\\s]*warehouse[\\s]*skipped[\\s]*\n[\\s]*googleMaps[\\s]*created[\\s]*googleMaps[\\s]*$",
+		},
+		{
+			name: "import overwrite with secrets",
+			httpServer: newImportServer(map[string]string{
+				"PUT /fmeapiv4/connections/warehouse": `{"category":"database","username":"fme","password":"secret","parameters":{"HOST":"db.example.com"}}`,
+				"POST /fmeapiv4/connections":          createGoogleMaps,
+			}),
+			args:           []string{"connections", "import", "-f", connectionsFile, "--secrets-file", secretsFile, "--on-conflict", "overwrite", "--json"},
+			wantOutputJson: `[{"name":"warehouse","action":"overwritten","importedAs":"warehouse"},{"name":"googleMaps","action":"created","importedAs":"googleMaps"}]`,
+		},
+		{
+			name: "import rename",
+			httpServer: newImportServer(map[string]string{
+				"POST /fmeapiv4/connections": `{"category":"database","name":"warehouse_3","type":"PostgreSQL","username":"fme","password":"secret","parameters":{"HOST":"db.example.com"}}` + createGoogleMaps,
+			}),
+			args:            []string{"connections", "import", "-f", connectionsFile, "--secrets-file", secretsFile, "--on-conflict", "rename", "--output", "custom-columns=NAME:.name,NEW:.importedAs", "--no-headers"},
+			wantOutputRegex: "^[\\s]*warehouse[\\s]*warehouse_3[\\s]*\n[\\s]*googleMaps[\\s]*googleMaps[\\s]*$",
+		},
+		{
+			name:        "import failure",
+			httpServer:  newImportServer(map[string]string{}),
+			args:        []string{"connections", "import", "-f", connectionsFile, "--on-conflict", "overwrite"},
+			wantErrText: "could not import connection warehouse: 404 Not Found",
+		},
+	}
+
+	runTests(cases, t)
+}
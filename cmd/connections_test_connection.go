@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type ConnectionTestResult struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+	Method   string `json:"method"`
+	Latency  int64  `json:"latencyMs"`
+	Message  string `json:"message"`
+}
+
+type connectionTestResponse struct {
+	Success *bool  `json:"success"`
+	Message string `json:"message"`
+}
+
+type connectionsTestFlags struct {
+	names      []string
+	all        bool
+	timeout    time.Duration
+	outputType string
+	noHeaders  bool
+}
+
+const (
+	connectionTestOK       = "ok"
+	connectionTestFailed   = "failed"
+	connectionTestUntested = "untested"
+)
+
+func newConnectionTestCmd() *cobra.Command {
+	f := connectionsTestFlags{}
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Test connections",
+		Long: `Test that connections work and show how long each test took.
+Connections are tested by FME Flow where it supports it. If FME Flow can't test a connection, the command instead checks that the host and port of the connection can be reached from this machine. Connections that can't be tested either way are reported as untested.
+If any connection fails the test, the command exits with a non-zero exit code, which makes it suitable for scheduled checks.`,
+		Example: `
+  # Test the connection "myPGSQLConnection"
+  fmeflow connections test --name myPGSQLConnection
+
+  # Test every connection, for example in a nightly check
+  fmeflow connections test --all
+
+  # Test every connection and output the results as json
+  fmeflow connections test --all --json`,
+		Args: NoArgs,
+		RunE: connectionsTestRun(&f),
+	}
+
+	cmd.Flags().StringArrayVar(&f.names, "name", []string{}, "Name of the connection to test. Can be passed in multiple times.")
+	cmd.Flags().BoolVar(&f.all, "all", false, "Test every connection.")
+	cmd.Flags().DurationVar(&f.timeout, "timeout", 30*time.Second, "How long to wait for each connection test.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.MarkFlagsMutuallyExclusive("name", "all")
+	cmd.MarkFlagsOneRequired("name", "all")
+	return cmd
+}
+
+func connectionsTestRun(f *connectionsTestFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{Timeout: f.timeout}
+
+		connections, err := getAllItemsV4[Connection](client, "/fmeapiv4/connections")
+		if err != nil {
+			return err
+		}
+		if !f.all {
+			for _, name := range f.names {
+				if !slices.ContainsFunc(connections, func(c Connection) bool { return c.Name == name }) {
+					return fmt.Errorf("connection %s does not exist", name)
+				}
+			}
+			connections = slices.DeleteFunc(connections, func(c Connection) bool { return !slices.Contains(f.names, c.Name) })
+		}
+
+		results := []ConnectionTestResult{}
+		failed := 0
+		for _, connection := range sortedByName(connections, func(c Connection) string { return c.Name }) {
+			result := testConnection(client, connection, f.timeout)
+			if result.Status == connectionTestFailed {
+				failed++
+			}
+			results = append(results, result)
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Name", "Type", "Status", "Method", "Latency", "Message"})
+
+			for _, element := range results {
+				latency := ""
+				if element.Status != connectionTestUntested {
+					latency = fmt.Sprintf("%dms", element.Latency)
+				}
+				t.AppendRow(table.Row{element.Name, element.Type, element.Status, element.Method, latency, element.Message})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			jsonData, err := json.Marshal(results)
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(jsonData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			// we have to marshal the Items array, then create an array of marshalled items
+			// to pass to the creation of the table.
+			marshalledItems := [][]byte{}
+			for _, element := range results {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		// make sure we exit with a non-zero exit code if any connection failed
+		if failed != 0 {
+			return fmt.Errorf("%d of %d connections failed the test", failed, len(results))
+		}
+		return nil
+	}
+}
+
+// test a connection using FME Flow. If FME Flow can't test the connection, fall back to checking
+// that the host and port in the connection parameters can be reached
+func testConnection(client *http.Client, connection Connection, timeout time.Duration) ConnectionTestResult {
+	result := ConnectionTestResult{Name: connection.Name, Category: connection.Category, Type: connection.Type, Method: "fmeflow"}
+
+	start := time.Now()
+	request, err := buildFmeFlowRequest("/fmeapiv4/connections/"+connection.Name+"/test", "POST", nil)
+	if err != nil {
+		result.Status = connectionTestFailed
+		result.Message = err.Error()
+		return result
+	}
+	response, err := client.Do(&request)
+	result.Latency = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Status = connectionTestFailed
+		result.Message = err.Error()
+		return result
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		result.Status = connectionTestOK
+		responseData, err := io.ReadAll(response.Body)
+		if err == nil && len(responseData) != 0 {
+			var testResponse connectionTestResponse
+			if err := json.Unmarshal(responseData, &testResponse); err == nil {
+				if testResponse.Success != nil && !*testResponse.Success {
+					result.Status = connectionTestFailed
+				}
+				result.Message = testResponse.Message
+			}
+		}
+		return result
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		// the connection exists, so this version of FME Flow can't test connections of this type
+		return testConnectionHost(connection, timeout)
+	default:
+		result.Status = connectionTestFailed
+		result.Message = parseResponseMessage(response).Error()
+		return result
+	}
+}
+
+// check that the host and port of a connection can be reached from this machine
+func testConnectionHost(connection Connection, timeout time.Duration) ConnectionTestResult {
+	result := ConnectionTestResult{Name: connection.Name, Category: connection.Category, Type: connection.Type, Method: "tcp"}
+
+	host := fmt.Sprint(connection.Parameters["HOST"])
+	port := fmt.Sprint(connection.Parameters["PORT"])
+	if connection.Parameters["HOST"] == nil || host == "" || connection.Parameters["PORT"] == nil || port == "" {
+		result.Status = connectionTestUntested
+		result.Method = ""
+		result.Message = "FME Flow can't test this connection and it has no host and port to check"
+		return result
+	}
+
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, port), timeout)
+	result.Latency = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Status = connectionTestFailed
+		result.Message = err.Error()
+		return result
+	}
+	conn.Close()
+	result.Status = connectionTestOK
+	result.Message = "reachable from this machine"
+	return result
+}
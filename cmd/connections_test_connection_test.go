@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionsTest(t *testing.T) {
+	// a port that is listening, for connections that FME Flow can't test
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	openPort := listener.Addr().(*net.TCPAddr).Port
+
+	// a port that is not listening
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedPort := closedListener.Addr().(*net.TCPAddr).Port
+	closedListener.Close()
+
+	responseList := `{"items":[
+		{"name":"warehouse","category":"database","type":"PostgreSQL","parameters":{"HOST":"db.example.com","PORT":"5432"}},
+		{"name":"badwarehouse","category":"database","type":"PostgreSQL","parameters":{"HOST":"db.example.com","PORT":"5433"}},
+		{"name":"legacy","category":"database","type":"MySQL","parameters":{"HOST":"127.0.0.1","PORT":` + strconv.Itoa(openPort) + `}},
+		{"name":"legacyDown","category":"database","type":"MySQL","parameters":{"HOST":"127.0.0.1","PORT":"` + strconv.Itoa(closedPort) + `"}},
+		{"name":"googleDrive","category":"oauthV2","type":"Google Drive","parameters":{}}
+	],"totalCount":5,"limit":100,"offset":0}`
+
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fmeapiv4/connections":
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(responseList))
+			require.NoError(t, err)
+		case "/fmeapiv4/connections/warehouse/test":
+			require.Equal(t, "POST", r.Method)
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"success":true,"message":"Connection successful"}`))
+			require.NoError(t, err)
+		case "/fmeapiv4/connections/badwarehouse/test":
+			w.WriteHeader(http.StatusBadRequest)
+			_, err := w.Write([]byte(`{"message":"password authentication failed for user \"fme\""}`))
+			require.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"connections", "test", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flags",
+			args:        []string{"connections", "test"},
+			wantErrText: "at least one of the flags in the group [name all] is required",
+		},
+		{
+			name:        "name and all",
+			args:        []string{"connections", "test", "--name", "warehouse", "--all"},
+			wantErrText: "if any flags in the group [name all] are set none of the others can be; [all name] were all set",
+		},
+		{
+			name:        "connection does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"connections", "test", "--name", "missing"},
+			wantErrText: "connection missing does not exist",
+		},
+		{
+			name:            "test single connection",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"connections", "test", "--name", "warehouse"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*TYPE[\\s]*STATUS[\\s]*METHOD[\\s]*LATENCY[\\s]*MESSAGE[\\s]*\n[\\s]*warehouse[\\s]*PostgreSQL[\\s]*ok[\\s]*fmeflow[\\s]*[0-9]+ms[\\s]*Connection successful[\\s]*$",
+		},
+		{
+			name:            "test connection with host fallback",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"connections", "test", "--name", "legacy", "--output", "custom-columns=NAME:.name,STATUS:.status,METHOD:.method", "--no-headers"},
+			wantOutputRegex: "^[\\s]*legacy[\\s]*ok[\\s]*tcp[\\s]*$",
+		},
+		{
+			name:            "test all connections",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"connections", "test", "--all", "--output", "custom-columns=NAME:.name,STATUS:.status,METHOD:.method", "--no-headers"},
+			wantOutputRegex: "^[\\s]*badwarehouse[\\s]*failed[\\s]*fmeflow[\\s]*\n[\\s]*googleDrive[\\s]*untested[\\s]*\n[\\s]*legacy[\\s]*ok[\\s]*tcp[\\s]*\n[\\s]*legacyDown[\\s]*failed[\\s]*tcp[\\s]*\n[\\s]*warehouse[\\s]*ok[\\s]*fmeflow[\\s]*$",
+			wantErrText:     "2 of 5 connections failed the test",
+		},
+		{
+			name:            "test failed connection json",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"connections", "test", "--name", "badwarehouse", "--json"},
+			wantErrText:     "1 of 1 connections failed the test",
+			wantOutputRegex: "\"name\": \"badwarehouse\",[\\s]*\"category\": \"database\",[\\s]*\"type\": \"PostgreSQL\",[\\s]*\"status\": \"failed\",[\\s]*\"method\": \"fmeflow\",[\\s]*\"latencyMs\": [0-9]+,[\\s]*\"message\": \"password authentication failed for user \\\\\"fme\\\\\"\"",
+		},
+	}
+
+	runTests(cases, t)
+}
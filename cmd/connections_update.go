@@ -15,8 +15,8 @@ import (
 type UpdateConnection struct {
 	Category             string                 `json:"category"`
 	AuthenticationMethod string                 `json:"authenticationMethod,omitempty"`
-	Username             string                 `json:"username"`
-	Password             string                 `json:"password"`
+	Username             string                 `json:"username,omitempty"`
+	Password             string                 `json:"password,omitempty"`
 	Parameters           map[string]interface{} `json:"parameters,omitempty"`
 }
 
@@ -25,6 +25,9 @@ type ConnectionUpdateFlags struct {
 	authenticationMethod string
 	username             string
 	password             string
+	passwordFile         string
+	passwordStdin        bool
+	passwordEnv          string
 	parameter            []string
 }
 
@@ -37,6 +40,9 @@ func newConnectionUpdateCmd() *cobra.Command {
 		Example: `
   # Update a PostgreSQL connection with the name "myPGSQLConnection" and modify the host to "myDBHost"
   fmeflow connections update --name myPGSQLConnection --parameter HOST=myDBHost
+
+  # Update the password of the connection "myPGSQLConnection" from a file
+  fmeflow connections update --name myPGSQLConnection --password-file ./pg-password.txt
 `,
 
 		Args: NoArgs,
@@ -47,15 +53,24 @@ func newConnectionUpdateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&f.authenticationMethod, "authentication-method", "", "Authentication method of the connection to update.")
 	cmd.Flags().StringVar(&f.username, "username", "", "Username of the connection to update.")
 	cmd.Flags().StringVar(&f.password, "password", "", "Password of the connection to update.")
+	cmd.Flags().StringVar(&f.passwordFile, "password-file", "", "Path to a file containing the password of the connection to update.")
+	cmd.Flags().BoolVar(&f.passwordStdin, "password-stdin", false, "Read the password of the connection to update from stdin.")
+	cmd.Flags().StringVar(&f.passwordEnv, "password-env", "", "Name of an environment variable containing the password of the connection to update.")
 	cmd.Flags().StringArrayVar(&f.parameter, "parameter", []string{}, "Parameters of the connection to update. Must be of the form name=value. Can be specified multiple times.")
 
 	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagsMutuallyExclusive("password", "password-file", "password-stdin", "password-env")
 	return cmd
 }
 
 func connectionUpdateRun(f *ConnectionUpdateFlags) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 
+		password, err := readPasswordFlags(cmd, f.password, f.passwordFile, f.passwordStdin, f.passwordEnv)
+		if err != nil {
+			return err
+		}
+
 		// set up http
 		client := &http.Client{}
 
@@ -117,8 +132,8 @@ func connectionUpdateRun(f *ConnectionUpdateFlags) func(cmd *cobra.Command, args
 		if f.username != "" {
 			updateConnectionStruct.Username = f.username
 		}
-		if f.password != "" {
-			updateConnectionStruct.Password = f.password
+		if password != "" {
+			updateConnectionStruct.Password = password
 		}
 
 		// copy over existing parameters
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -45,6 +46,20 @@ func TestConnectionsUpdate(t *testing.T) {
 
 	}
 
+	passwordHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(responseGet))
+			require.NoError(t, err)
+		}
+		if r.Method == "PUT" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Contains(t, string(body), `"password":"fromstdin"`)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+
 	parameterDoesNotExistBody := `{
 		"message": "Unauthorized request by user admin due to lack of proper permissions or the object does not exist."
 	  }`
@@ -73,6 +88,13 @@ func TestConnectionsUpdate(t *testing.T) {
 			wantOutputRegex: "^Connection successfully updated.[\\s]*$",
 			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
 		},
+		{
+			name:            "update password from stdin",
+			args:            []string{"connections", "update", "--name", "PostGIS 3.3 Testsuite", "--password-stdin"},
+			stdin:           "fromstdin\n",
+			wantOutputRegex: "^Connection successfully updated.[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(passwordHttpServerHandler)),
+		},
 		{
 			name:        "parameter does not exist",
 			statusCode:  http.StatusConflict,
@@ -45,8 +45,8 @@ func newDeploymentParametersCmd() *cobra.Command {
 	f := deploymentparametersFlags{}
 	cmd := &cobra.Command{
 		Use:   "deploymentparameters",
-		Short: "List, Create, Update and Delete Deployment Parameters",
-		Long:  `List Deployment Parameters. Use the subcommands to create, update, or delete a deployment parameter.`,
+		Short: "List, Create, Update, Delete, Export and Import Deployment Parameters",
+		Long:  `List Deployment Parameters. Use the subcommands to create, update, or delete a deployment parameter, export and import deployment parameters in bulk, or find the workspaces that use a deployment parameter.`,
 		Example: `
   # List all deployment parameters
   fmeflow deploymentparameters
@@ -68,6 +68,9 @@ func newDeploymentParametersCmd() *cobra.Command {
 	cmd.AddCommand(newDeploymentParameterCreateCmd())
 	cmd.AddCommand(newDeploymentParameterDeleteCmd())
 	cmd.AddCommand(newDeploymentParameterUpdateCmd())
+	cmd.AddCommand(newDeploymentParameterExportCmd())
+	cmd.AddCommand(newDeploymentParameterImportCmd())
+	cmd.AddCommand(newDeploymentParameterUsagesCmd())
 
 	return cmd
 }
@@ -14,6 +14,7 @@ import (
 type deploymentParameterDeleteFlags struct {
 	name     string
 	noprompt bool
+	force    bool
 }
 
 func newDeploymentParameterDeleteCmd() *cobra.Command {
@@ -21,13 +22,17 @@ func newDeploymentParameterDeleteCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "delete",
 		Short: "Delete a deployment parameter",
-		Long:  `Delete a deployment parameter.`,
+		Long: `Delete a deployment parameter.
+Deleting a deployment parameter that is used by workspaces will break those workspaces, so the command checks every workspace first and refuses to delete a deployment parameter that is in use. Use --force to delete it anyway. Use "fmeflow deploymentparameters usages" to see where a deployment parameter is used.`,
 		Example: `
   # Delete adeployment parameter with the name "myParam"
   fmeflow deploymentparameters delete --name myParam
 	
   # Delete a repository with the name "myRepository" and no confirmation
   fmeflow deploymentparameters delete --name myParam --no-prompt
+
+  # Delete a deployment parameter even if workspaces use it
+  fmeflow deploymentparameters delete --name myParam --force
 `,
 		Args: NoArgs,
 		RunE: deploymentParameterDeleteRun(&f),
@@ -35,6 +40,7 @@ func newDeploymentParameterDeleteCmd() *cobra.Command {
 
 	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
 	cmd.Flags().StringVar(&f.name, "name", "", "Name of the Deployment Parameter to delete.")
+	cmd.Flags().BoolVar(&f.force, "force", false, "Delete the deployment parameter even if it is used by workspaces.")
 	cmd.MarkFlagRequired("name")
 	return cmd
 }
@@ -77,6 +83,19 @@ func deploymentParameterDeleteRun(f *deploymentParameterDeleteFlags) func(cmd *c
 			}
 		}
 
+		// make sure no workspaces use the parameter
+		usages, err := findDeploymentParameterUsages(client, f.name, "")
+		if err != nil {
+			return err
+		}
+		if len(usages) != 0 {
+			message := fmt.Sprintf("deployment parameter %s is used by %s", f.name, formatDeploymentParameterUsages(usages))
+			if !f.force {
+				return errors.New(message + ". Use --force to delete it anyway")
+			}
+			fmt.Fprintln(cmd.ErrOrStderr(), "Warning: "+message)
+		}
+
 		// the parameter exists. Confirm deletion.
 		if !f.noprompt {
 			// prompt to confirm deletion
@@ -9,27 +9,41 @@ import (
 )
 
 func TestDeploymentParametersDelete(t *testing.T) {
-	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+	paramMissingBody := `{
+		"message": "Unauthorized request by user admin due to lack of proper permissions or the object does not exist."
+	  }`
 
-		if r.Method == "GET" {
-			w.WriteHeader(http.StatusOK)
-			_, err := w.Write([]byte(""))
-			require.NoError(t, err)
+	workspacesResponse := `{"items":[{"name":"austinApartments.fmw","repositoryName":"Samples"},{"name":"loader.fmw","repositoryName":"ETL"}],"totalCount":2,"limit":100,"offset":0}`
+	austinApartmentsResponse := `{"name":"austinApartments.fmw","parameters":[{"name":"OUTPUT","type":"text","defaultValue":"out.zip"}],"properties":[]}`
+	loaderResponse := `{"name":"loader.fmw","parameters":[{"name":"DB_CONNECTION","type":"dbConnection","defaultValue":"$(pgsql_param)"}],"properties":[]}`
 
-		}
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond.
+	// Two workspaces are published, one of which uses the deployment parameter pgsql_param
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "DELETE" {
 			w.WriteHeader(http.StatusNoContent)
-			_, err := w.Write([]byte(""))
-			require.NoError(t, err)
-
+			return
 		}
-
+		response := ""
+		if r.URL.Path == "/fmeapiv4/deploymentparameters/myDep" {
+			response = `{"name":"myDep","type":"text","value":""}`
+		} else if r.URL.Path == "/fmeapiv4/deploymentparameters/pgsql_param" {
+			response = `{"name":"pgsql_param","type":"text","value":""}`
+		} else if r.URL.Path == "/fmeapiv4/workspaces" {
+			response = workspacesResponse
+		} else if r.URL.Path == "/fmeapiv4/workspaces/Samples/austinApartments.fmw" {
+			response = austinApartmentsResponse
+		} else if r.URL.Path == "/fmeapiv4/workspaces/ETL/loader.fmw" {
+			response = loaderResponse
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(response))
+		require.NoError(t, err)
 	}
 
-	paramMissingBody := `{
-		"message": "Unauthorized request by user admin due to lack of proper permissions or the object does not exist."
-	  }`
-
 	cases := []testCase{
 		{
 			name:               "unknown flag",
@@ -55,6 +69,19 @@ func TestDeploymentParametersDelete(t *testing.T) {
 			wantOutputRegex: "^Deployment Parameter successfully deleted.[\\s]*$",
 			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
 		},
+		{
+			name:        "delete parameter in use",
+			args:        []string{"deploymentparameters", "delete", "--name", "pgsql_param", "--no-prompt"},
+			wantErrText: "deployment parameter pgsql_param is used by 1 workspace(s): ETL/loader.fmw. Use --force to delete it anyway",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+		},
+		{
+			name:               "force delete parameter in use",
+			args:               []string{"deploymentparameters", "delete", "--name", "pgsql_param", "--no-prompt", "--force"},
+			wantOutputRegex:    "^Deployment Parameter successfully deleted.[\\s]*$",
+			wantErrOutputRegex: "^Warning: deployment parameter pgsql_param is used by 1 workspace\\(s\\): ETL/loader.fmw\n$",
+			httpServer:         httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+		},
 		{
 			name:        "delete parameter not found",
 			statusCode:  http.StatusNotFound,
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/spf13/cobra"
+)
+
+type deploymentParameterExportFlags struct {
+	names      []string
+	outputType string
+}
+
+func newDeploymentParameterExportCmd() *cobra.Command {
+	f := deploymentParameterExportFlags{}
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export deployment parameters to a file",
+		Long: `Export deployment parameters so they can be imported into another FME Flow with "fmeflow deploymentparameters import".
+The output is a manifest, so it can also be used with "fmeflow apply".`,
+		Example: `
+  # Export all deployment parameters to a file
+  fmeflow deploymentparameters export -o yaml > base.yaml
+
+  # Export two deployment parameters as json
+  fmeflow deploymentparameters export --name pgsql_param --name slack_connection --output json`,
+		Args: NoArgs,
+		RunE: deploymentParametersExportRun(&f),
+	}
+
+	cmd.Flags().StringArrayVar(&f.names, "name", []string{}, "Name of a deployment parameter to export. Can be passed in multiple times. Defaults to all deployment parameters.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "yaml", "Specify the output type. Should be one of yaml or json")
+	return cmd
+}
+
+func deploymentParametersExportRun(f *deploymentParameterExportFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+		if f.outputType != "yaml" && f.outputType != "json" {
+			return errors.New("invalid output format specified")
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		parameters, err := exportDeploymentParameters(client)
+		if err != nil {
+			return err
+		}
+
+		if len(f.names) != 0 {
+			for _, name := range f.names {
+				if !slices.ContainsFunc(parameters, func(p ManifestDeploymentParameter) bool { return p.Name == name }) {
+					return fmt.Errorf("deployment parameter %s does not exist", name)
+				}
+			}
+			parameters = slices.DeleteFunc(parameters, func(p ManifestDeploymentParameter) bool { return !slices.Contains(f.names, p.Name) })
+		}
+
+		return writeManifest(cmd, FlowManifest{DeploymentParameters: parameters}, f.outputType)
+	}
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDeploymentParametersExport(t *testing.T) {
+	response := `{
+		"items": [
+		  {"name": "slack_param", "owner": "admin", "type": "dropdown", "updated": "2023-01-11T22:05:23.394Z", "value": "slack_connection", "resourceMissing": false, "choiceSettings": {"choiceSet": "webConnections", "services": ["Slack"]}},
+		  {"name": "pgsql_param", "owner": "admin", "type": "dropdown", "updated": "2023-01-11T22:05:23.394Z", "value": "warehouse", "resourceMissing": false, "choiceSettings": {"choiceSet": "dbConnections", "family": "PostgreSQL"}},
+		  {"name": "text_param", "owner": "admin", "type": "text", "updated": "2023-01-11T22:05:23.394Z", "value": "/data/dev", "resourceMissing": false}
+		],
+		"totalCount": 3,
+		"limit": 100,
+		"offset": 0
+	  }`
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"deploymentparameters", "export", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"deploymentparameters", "export"},
+		},
+		{
+			name:        "invalid output",
+			statusCode:  http.StatusOK,
+			body:        response,
+			wantErrText: "invalid output format specified",
+			args:        []string{"deploymentparameters", "export", "-o", "table"},
+		},
+		{
+			name:            "export deployment parameters",
+			statusCode:      http.StatusOK,
+			body:            response,
+			args:            []string{"deploymentparameters", "export", "-o", "yaml"},
+			wantOutputRegex: "^deploymentParameters:\n  - name: pgsql_param\n    type: database\n    value: warehouse\n    databaseType: PostgreSQL\n  - name: slack_param\n    type: web\n    value: slack_connection\n    includedServices:\n      - Slack\n  - name: text_param\n    type: text\n    value: /data/dev\n$",
+		},
+		{
+			name:           "export single deployment parameter json",
+			statusCode:     http.StatusOK,
+			body:           response,
+			args:           []string{"deploymentparameters", "export", "--name", "text_param", "--json"},
+			wantOutputJson: `{"deploymentParameters":[{"name":"text_param","type":"text","value":"/data/dev"}]}`,
+		},
+		{
+			name:        "export deployment parameter that does not exist",
+			statusCode:  http.StatusOK,
+			body:        response,
+			args:        []string{"deploymentparameters", "export", "--name", "missing"},
+			wantErrText: "deployment parameter missing does not exist",
+		},
+	}
+
+	runTests(cases, t)
+}
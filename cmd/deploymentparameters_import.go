@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type deploymentParameterImportFlags struct {
+	files            []string
+	requireConnected bool
+	dryRun           bool
+	noprompt         bool
+	outputType       string
+	noHeaders        bool
+}
+
+func newDeploymentParameterImportCmd() *cobra.Command {
+	f := deploymentParameterImportFlags{}
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import deployment parameters from a file",
+		Long: `Import deployment parameters from a file created by "fmeflow deploymentparameters export".
+Pass --file more than once to layer files on top of each other, such as a base file followed by a file of overrides for one environment. A parameter in a later file overrides the fields it sets of the parameter with the same name in earlier files, so an override file only needs the name and the fields that are different.
+The changes are shown before anything is changed. Deployment parameters that don't exist are created and ones that are different are updated. Deployment parameters that aren't in the files are left unchanged.
+Values in the files can reference environment variables as ${env:NAME}.
+
+An example base file:
+
+  deploymentParameters:
+    - name: WAREHOUSE_DB
+      type: database
+      databaseType: PostgreSQL
+      value: warehouse_dev
+    - name: OUTPUT_DIR
+      value: /data/dev
+
+And an override file for production:
+
+  deploymentParameters:
+    - name: WAREHOUSE_DB
+      value: warehouse_prod
+    - name: OUTPUT_DIR
+      value: /data/prod`,
+		Example: `
+  # Import the deployment parameters in dev.yaml, prompting before making changes
+  fmeflow deploymentparameters import -f dev.yaml
+
+  # Show the effective changes of a base file with production overrides without making them
+  fmeflow deploymentparameters import -f base.yaml -f prod.yaml --dry-run
+
+  # Import with production overrides, failing if a parameter references a connection that doesn't exist
+  fmeflow deploymentparameters import -f base.yaml -f prod.yaml --require-connections -y`,
+		Args: NoArgs,
+		RunE: deploymentParametersImportRun(&f),
+	}
+
+	cmd.Flags().StringArrayVarP(&f.files, "file", "f", []string{}, "Path to a file of deployment parameters to import. Use - to read from stdin. Can be passed in multiple times, with later files overriding earlier ones.")
+	cmd.Flags().BoolVar(&f.requireConnected, "require-connections", false, "Abort if a database or web deployment parameter references a connection that doesn't exist.")
+	cmd.Flags().BoolVar(&f.dryRun, "dry-run", false, "Show the changes without making them.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation before making the changes.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func deploymentParametersImportRun(f *deploymentParameterImportFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		parameters, err := loadDeploymentParameterLayers(cmd, f.files)
+		if err != nil {
+			return err
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		if f.requireConnected {
+			if err := checkDeploymentParameterConnections(client, parameters); err != nil {
+				return err
+			}
+		}
+
+		plan, err := planDeploymentParameters(client, parameters, false)
+		if err != nil {
+			return err
+		}
+
+		if len(plan) == 0 {
+			if f.outputType == "table" {
+				fmt.Fprintln(cmd.OutOrStdout(), "Deployment parameters already match the file.")
+				return nil
+			}
+			return printManifestPlan(cmd, plan, f.outputType, f.noHeaders)
+		}
+
+		if err := printManifestPlan(cmd, plan, f.outputType, f.noHeaders); err != nil {
+			return err
+		}
+		if f.dryRun {
+			return nil
+		}
+
+		if !f.noprompt {
+			// prompt to confirm the changes
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: "Apply " + strconv.Itoa(len(plan)) + " change(s) to the deployment parameters?",
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		if err := applyManifestPlan(client, plan); err != nil {
+			return err
+		}
+
+		if f.outputType != "json" {
+			fmt.Fprintln(cmd.OutOrStdout(), "Deployment parameters successfully imported.")
+		}
+		return nil
+	}
+}
+
+// read the deployment parameters in each file, with the fields set in later files overriding the
+// same fields of the parameter with the same name in earlier files
+func loadDeploymentParameterLayers(cmd *cobra.Command, paths []string) ([]ManifestDeploymentParameter, error) {
+	merged := &yaml.Node{Kind: yaml.SequenceNode}
+	byName := map[string]*yaml.Node{}
+	for _, path := range paths {
+		document, err := readManifestDocument(cmd, path)
+		if err != nil {
+			return nil, err
+		}
+		sequence := yamlMappingValue(document, "deploymentParameters")
+		if sequence == nil {
+			continue
+		}
+		if sequence.Kind != yaml.SequenceNode {
+			return nil, fmt.Errorf("could not parse manifest %s: deploymentParameters must be a list", path)
+		}
+		for _, parameter := range sequence.Content {
+			nameNode := yamlMappingValue(parameter, "name")
+			if nameNode == nil || nameNode.Value == "" {
+				return nil, fmt.Errorf("every deployment parameter in %s must have a name", path)
+			}
+			existing, found := byName[nameNode.Value]
+			if !found {
+				byName[nameNode.Value] = parameter
+				merged.Content = append(merged.Content, parameter)
+				continue
+			}
+			for i := 0; i+1 < len(parameter.Content); i += 2 {
+				key, value := parameter.Content[i], parameter.Content[i+1]
+				if current := yamlMappingValue(existing, key.Value); current != nil {
+					*current = *value
+				} else {
+					existing.Content = append(existing.Content, key, value)
+				}
+			}
+		}
+	}
+
+	if len(merged.Content) == 0 {
+		return nil, fmt.Errorf("no deployment parameters found in %s", strings.Join(paths, ", "))
+	}
+
+	var manifest FlowManifest
+	if err := merged.Decode(&manifest.DeploymentParameters); err != nil {
+		return nil, fmt.Errorf("could not parse deployment parameters: %w", err)
+	}
+	return manifest.DeploymentParameters, manifest.validate()
+}
+
+// get the value of a key in a yaml mapping, or in the mapping of a yaml document
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) != 0 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// make sure the connection referenced by each database and web deployment parameter exists
+func checkDeploymentParameterConnections(client *http.Client, parameters []ManifestDeploymentParameter) error {
+	connections, err := getAllItemsV4[Connection](client, "/fmeapiv4/connections")
+	if err != nil {
+		return err
+	}
+	existing := map[string]bool{}
+	for _, connection := range connections {
+		existing[connection.Name] = true
+	}
+
+	missing := []string{}
+	for _, parameter := range parameters {
+		if (parameter.Type == "database" || parameter.Type == "web") && parameter.Value != "" && !existing[parameter.Value] {
+			missing = append(missing, fmt.Sprintf("%s references connection %s", parameter.Name, parameter.Value))
+		}
+	}
+	if len(missing) != 0 {
+		return errors.New("connections referenced by deployment parameters do not exist:\n" + strings.Join(missing, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeploymentParametersImport(t *testing.T) {
+	dir := t.TempDir()
+	baseFile := filepath.Join(dir, "base.yaml")
+	require.NoError(t, os.WriteFile(baseFile, []byte(`deploymentParameters:
+  - name: pgsql_param
+    type: database
+    databaseType: PostgreSQL
+    value: warehouse_dev
+  - name: text_param
+    value: /data/dev
+  - name: slack_param
+    type: web
+    includedServices: [Slack]
+    value: slack_connection
+`), 0600))
+	prodFile := filepath.Join(dir, "prod.yaml")
+	require.NoError(t, os.WriteFile(prodFile, []byte(`deploymentParameters:
+  - name: pgsql_param
+    value: warehouse_prod
+  - name: text_param
+    value: ${env:FMEFLOW_TEST_VALUE}
+  - name: new_param
+    value: added
+`), 0600))
+	emptyFile := filepath.Join(dir, "empty.yaml")
+	require.NoError(t, os.WriteFile(emptyFile, []byte("connections: []\n"), 0600))
+	t.Setenv("FMEFLOW_TEST_VALUE", "/data/prod")
+
+	responseList := `{"items":[
+		{"name":"pgsql_param","type":"dropdown","value":"warehouse_dev","choiceSettings":{"choiceSet":"dbConnections","family":"PostgreSQL"}},
+		{"name":"text_param","type":"text","value":"/data/dev"},
+		{"name":"slack_param","type":"dropdown","value":"slack_connection","choiceSettings":{"choiceSet":"webConnections","services":["Slack"]}}
+	],"totalCount":3,"limit":100,"offset":0}`
+	responseConnections := `{"items":[{"name":"warehouse_dev","category":"database","type":"PostgreSQL"},{"name":"slack_connection","category":"oauthV2","type":"Slack"}],"totalCount":2,"limit":100,"offset":0}`
+
+	changes := map[string]string{
+		"PUT /fmeapiv4/deploymentparameters/pgsql_param": `{"type":"dropdown","value":"warehouse_prod","choiceSettings":{"choiceSet":"dbConnections","family":"PostgreSQL"}}`,
+		"PUT /fmeapiv4/deploymentparameters/text_param":  `{"type":"text","value":"/data/prod"}`,
+		"POST /fmeapiv4/deploymentparameters":            `{"name":"new_param","type":"text","value":"added","choiceSettings":{"choiceSet":""}}`,
+	}
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.WriteHeader(http.StatusOK)
+			response := responseList
+			if r.URL.Path == "/fmeapiv4/connections" {
+				response = responseConnections
+			}
+			_, err := w.Write([]byte(response))
+			require.NoError(t, err)
+			return
+		}
+		wantBody, ok := changes[r.Method+" "+r.URL.Path]
+		require.True(t, ok, r.Method+" "+r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, wantBody, string(body))
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusCreated)
+		} else {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"deploymentparameters", "import", "-f", baseFile, "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing file flag",
+			wantErrText: "required flag(s) \"file\" not set",
+			args:        []string{"deploymentparameters", "import"},
+		},
+		{
+			name:        "no deployment parameters",
+			wantErrText: "no deployment parameters found in " + emptyFile,
+			args:        []string{"deploymentparameters", "import", "-f", emptyFile},
+		},
+		{
+			name:            "base file matches",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"deploymentparameters", "import", "-f", baseFile},
+			wantOutputRegex: "^Deployment parameters already match the file.\n$",
+		},
+		{
+			name:            "dry run with overrides",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"deploymentparameters", "import", "-f", baseFile, "-f", prodFile, "--dry-run"},
+			wantOutputRegex: "^[\\s]*KIND[\\s]*NAME[\\s]*ACTION[\\s]*CHANGES[\\s]*\n[\\s]*deploymentparameter[\\s]*new_param[\\s]*create[\\s]*\n[\\s]*deploymentparameter[\\s]*pgsql_param[\\s]*update[\\s]*value[\\s]*\n[\\s]*deploymentparameter[\\s]*text_param[\\s]*update[\\s]*value[\\s]*$",
+		},
+		{
+			name:            "import with overrides",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"deploymentparameters", "import", "-f", baseFile, "-f", prodFile, "-y"},
+			wantOutputRegex: "Deployment parameters successfully imported.\n$",
+		},
+		{
+			name:        "missing connection",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"deploymentparameters", "import", "-f", baseFile, "-f", prodFile, "--require-connections", "-y"},
+			wantErrText: "connections referenced by deployment parameters do not exist:\npgsql_param references connection warehouse_prod",
+		},
+		{
+			name:            "connections exist",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"deploymentparameters", "import", "-f", baseFile, "--require-connections"},
+			wantOutputRegex: "^Deployment parameters already match the file.\n$",
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type DeploymentParameterUsage struct {
+	Repository   string `json:"repository"`
+	Workspace    string `json:"workspace"`
+	ReferencedBy string `json:"referencedBy"`
+	Name         string `json:"name"`
+	Value        string `json:"value"`
+}
+
+type deploymentParameterUsagesFlags struct {
+	name       string
+	repository string
+	outputType string
+	noHeaders  bool
+}
+
+func newDeploymentParameterUsagesCmd() *cobra.Command {
+	f := deploymentParameterUsagesFlags{}
+	cmd := &cobra.Command{
+		Use:   "usages",
+		Short: "List the workspaces that use a deployment parameter",
+		Long: `List the workspaces that reference a deployment parameter. Every workspace is checked for:
+  - published parameters and workspace properties that reference the deployment parameter as $(name)
+  - database and web connection parameters whose value is the name of the deployment parameter
+The datasets and reader and writer connection properties of workspaces are not checked, so a deployment parameter only referenced there is not listed.
+Checking every workspace can take a while on a large FME Flow. Use --repository to only check the workspaces in one repository.`,
+		Example: `
+  # List the workspaces that use the deployment parameter "pgsql_param"
+  fmeflow deploymentparameters usages --name pgsql_param
+
+  # List the workspaces in the Samples repository that use the deployment parameter
+  fmeflow deploymentparameters usages --name pgsql_param --repository Samples
+
+  # Output just the repository and workspace of each usage
+  fmeflow deploymentparameters usages --name pgsql_param --output=custom-columns=REPOSITORY:.repository,WORKSPACE:.workspace --no-headers`,
+		Args: NoArgs,
+		RunE: deploymentParameterUsagesRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the deployment parameter.")
+	cmd.Flags().StringVar(&f.repository, "repository", "", "Only check the workspaces in this repository.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func deploymentParameterUsagesRun(f *deploymentParameterUsagesFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		usages, err := findDeploymentParameterUsages(client, f.name, f.repository)
+		if err != nil {
+			return err
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Repository", "Workspace", "Referenced By", "Name", "Value"})
+
+			for _, element := range usages {
+				t.AppendRow(table.Row{element.Repository, element.Workspace, element.ReferencedBy, element.Name, element.Value})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			jsonData, err := json.Marshal(usages)
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(jsonData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			// we have to marshal the Items array, then create an array of marshalled items
+			// to pass to the creation of the table.
+			marshalledItems := [][]byte{}
+			for _, element := range usages {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// find the published parameters and properties of workspaces that reference a deployment
+// parameter. If repository is set, only the workspaces in that repository are checked
+func findDeploymentParameterUsages(client *http.Client, name string, repository string) ([]DeploymentParameterUsage, error) {
+	endpoint := "/fmeapiv4/workspaces"
+	if repository != "" {
+		endpoint += "?repository=" + url.QueryEscape(repository)
+	}
+	workspaces, err := getAllItemsV4[FMEFlowWorkspaceV4](client, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	reference := "$(" + name + ")"
+	usages := []DeploymentParameterUsage{}
+	for _, workspace := range workspaces {
+		details, err := getWorkspaceDetailed(client, viper.GetViper(), workspace.RepositoryName, workspace.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, parameter := range details.Parameters {
+			value := formatParameterValue(parameter.DefaultValue)
+			// connection parameters reference a deployment parameter by its name
+			if strings.Contains(value, reference) || (isConnectionParameter(parameter) && value == name) {
+				usages = append(usages, DeploymentParameterUsage{Repository: workspace.RepositoryName, Workspace: workspace.Name, ReferencedBy: "parameter", Name: parameter.Name, Value: value})
+			}
+		}
+		for _, property := range details.Properties {
+			if strings.Contains(property.Value, reference) {
+				usages = append(usages, DeploymentParameterUsage{Repository: workspace.RepositoryName, Workspace: workspace.Name, ReferencedBy: "property", Name: property.Category + "." + property.Name, Value: property.Value})
+			}
+		}
+	}
+	return usages, nil
+}
+
+// whether a published parameter takes a database or web connection as its value
+func isConnectionParameter(parameter FMEFlowWorkspaceParameterV4) bool {
+	if parameter.Type == "dbConnection" || parameter.Type == "webConnection" {
+		return true
+	}
+	return parameter.ChoiceSettings != nil && (parameter.ChoiceSettings.ChoiceSet == "dbConnections" || parameter.ChoiceSettings.ChoiceSet == "webConnections")
+}
+
+// describe the workspaces in a list of usages, such as "2 workspace(s): Samples/a.fmw, ETL/b.fmw"
+func formatDeploymentParameterUsages(usages []DeploymentParameterUsage) string {
+	workspaces := []string{}
+	for _, usage := range usages {
+		workspace := usage.Repository + "/" + usage.Workspace
+		if !slices.Contains(workspaces, workspace) {
+			workspaces = append(workspaces, workspace)
+		}
+	}
+	return fmt.Sprintf("%d workspace(s): %s", len(workspaces), strings.Join(workspaces, ", "))
+}
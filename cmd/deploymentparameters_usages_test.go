@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeploymentParametersUsages(t *testing.T) {
+	workspacesResponse := `{"items":[{"name":"austinApartments.fmw","repositoryName":"Samples"},{"name":"loader.fmw","repositoryName":"ETL"}],"totalCount":2,"limit":100,"offset":0}`
+	samplesWorkspacesResponse := `{"items":[{"name":"austinApartments.fmw","repositoryName":"Samples"}],"totalCount":1,"limit":100,"offset":0}`
+	austinApartmentsResponse := `{"name":"austinApartments.fmw","parameters":[{"name":"OUTPUT","type":"text","defaultValue":"pgsql_param"}],"properties":[]}`
+	loaderResponse := `{"name":"loader.fmw","parameters":[{"name":"DB_CONNECTION","type":"dbConnection","defaultValue":"$(pgsql_param)"},{"name":"SLACK","type":"webConnection","defaultValue":"pgsql_param"}],"properties":[{"category":"fmedatadownload","name":"WRITER","value":"prefix_$(pgsql_param)"}]}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond.
+	// Two workspaces are published, one of which uses the deployment parameter pgsql_param
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		response := ""
+		if r.URL.Path == "/fmeapiv4/workspaces" && r.URL.Query().Get("repository") == "Samples" {
+			response = samplesWorkspacesResponse
+		} else if r.URL.Path == "/fmeapiv4/workspaces" {
+			response = workspacesResponse
+		} else if r.URL.Path == "/fmeapiv4/workspaces/Samples/austinApartments.fmw" {
+			response = austinApartmentsResponse
+		} else if r.URL.Path == "/fmeapiv4/workspaces/ETL/loader.fmw" {
+			response = loaderResponse
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(response))
+		require.NoError(t, err)
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"deploymentparameters", "usages", "--name", "pgsql_param", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flag",
+			wantErrText: "required flag(s) \"name\" not set",
+			args:        []string{"deploymentparameters", "usages"},
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"deploymentparameters", "usages", "--name", "pgsql_param"},
+		},
+		{
+			name:            "list usages",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"deploymentparameters", "usages", "--name", "pgsql_param"},
+			wantOutputRegex: "^[\\s]*REPOSITORY[\\s]*WORKSPACE[\\s]*REFERENCED BY[\\s]*NAME[\\s]*VALUE[\\s]*\n[\\s]*ETL[\\s]*loader.fmw[\\s]*parameter[\\s]*DB_CONNECTION[\\s]*\\$\\(pgsql_param\\)[\\s]*\n[\\s]*ETL[\\s]*loader.fmw[\\s]*parameter[\\s]*SLACK[\\s]*pgsql_param[\\s]*\n[\\s]*ETL[\\s]*loader.fmw[\\s]*property[\\s]*fmedatadownload.WRITER[\\s]*prefix_\\$\\(pgsql_param\\)[\\s]*$",
+		},
+		{
+			name:           "list usages json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"deploymentparameters", "usages", "--name", "pgsql_param", "--json"},
+			wantOutputJson: `[{"repository":"ETL","workspace":"loader.fmw","referencedBy":"parameter","name":"DB_CONNECTION","value":"$(pgsql_param)"},{"repository":"ETL","workspace":"loader.fmw","referencedBy":"parameter","name":"SLACK","value":"pgsql_param"},{"repository":"ETL","workspace":"loader.fmw","referencedBy":"property","name":"fmedatadownload.WRITER","value":"prefix_$(pgsql_param)"}]`,
+		},
+		{
+			name:            "no usages in repository",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"deploymentparameters", "usages", "--name", "pgsql_param", "--repository", "Samples", "--no-headers"},
+			wantOutputRegex: "^[\\s]*$",
+		},
+	}
+
+	runTests(cases, t)
+}
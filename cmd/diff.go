@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+type diffFlags struct {
+	file        string
+	prune       bool
+	pruneTokens bool
+	outputType  string
+	noHeaders   bool
+}
+
+func newDiffCmd() *cobra.Command {
+	f := diffFlags{}
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show the differences between a manifest and FME Flow.",
+		Long: `Compare a manifest with FME Flow and show the changes that "fmeflow apply" would make, without changing anything.
+See "fmeflow apply --help" for the format of a manifest.`,
+		Example: `
+  # Show what would change if config.yaml was applied
+  fmeflow diff -f config.yaml
+
+  # Also show what would be deleted when applying with --prune
+  fmeflow diff -f config.yaml --prune
+
+  # Output the differences as json
+  fmeflow diff -f config.yaml --json`,
+		Args: NoArgs,
+		RunE: diffRun(&f),
+	}
+
+	cmd.Flags().StringVarP(&f.file, "file", "f", "", "Path to the manifest to compare. Use - to read from stdin.")
+	cmd.Flags().BoolVar(&f.prune, "prune", false, "Include items of the kinds in the manifest that are not in the manifest and would be deleted.")
+	cmd.Flags().BoolVar(&f.pruneTokens, "prune-tokens", false, "Include tokens that are not in the manifest and would be deleted.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func diffRun(f *diffFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		manifest, err := loadManifest(cmd, f.file)
+		if err != nil {
+			return err
+		}
+
+		plan, err := planManifest(client, manifest, f.prune, f.pruneTokens)
+		if err != nil {
+			return err
+		}
+
+		if len(plan) == 0 && f.outputType == "table" {
+			fmt.Fprintln(cmd.OutOrStdout(), "FME Flow already matches the manifest.")
+			return nil
+		}
+		return printManifestPlan(cmd, plan, f.outputType, f.noHeaders)
+	}
+}
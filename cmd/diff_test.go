@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Setenv("FMEFLOW_TEST_PASSWORD", "secret")
+	t.Setenv("FMEFLOW_TEST_VALUE", "from-env")
+	manifestFile := writeTestManifest(t)
+
+	repositoriesResponse := `{"items":[{"name":"Samples","description":"Sample workspaces","sharable":true},{"name":"Old","description":"","sharable":true}],"totalCount":2,"limit":100,"offset":0}`
+	connectionsResponse := `{"items":[{"name":"warehouse","category":"database","type":"PostgreSQL","username":"fme","parameters":{"HOST":"old.example.com","PORT":5432}}],"totalCount":1,"limit":100,"offset":0}`
+	deploymentParametersResponse := `{"items":[{"name":"WAREHOUSE_DB","type":"dropdown","value":"warehouse","choiceSettings":{"choiceSet":"dbConnections","family":"PostgreSQL"}},{"name":"OLD_PARAM","type":"text","value":"x"}],"totalCount":2,"limit":100,"offset":0}`
+	queuesResponse := `{"items":[{"name":"Default","description":"","priority":5},{"name":"Priority","description":"","priority":2,"rules":[{"repository":"Samples"}]}],"totalCount":2,"limit":100,"offset":0}`
+	workspacesResponse := `{"items":[{"name":"loader.fmw","repositoryName":"ETL"}],"totalCount":1,"limit":100,"offset":0}`
+	loaderResponse := `{"name":"loader.fmw","parameters":[{"name":"DB_CONNECTION","type":"dbConnection","defaultValue":"$(OLD_PARAM)"}],"properties":[]}`
+	tokensResponse := `{"items":[{"name":"ci","description":"CI","enabled":true,"expirationDate":"2030-01-01T00:00:00Z"}],"totalCount":1,"limit":100,"offset":0}`
+
+	// diff only reads from FME Flow, so any other request returns a 404
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		responses := map[string]string{
+			"/fmeapiv4/repositories":              repositoriesResponse,
+			"/fmeapiv4/connections":               connectionsResponse,
+			"/fmeapiv4/deploymentparameters":      deploymentParametersResponse,
+			"/fmeapiv4/queues":                    queuesResponse,
+			"/fmeapiv4/tokens":                    tokensResponse,
+			"/fmeapiv4/workspaces":                workspacesResponse,
+			"/fmeapiv4/workspaces/ETL/loader.fmw": loaderResponse,
+		}
+		response, ok := responses[r.URL.Path]
+		if r.Method != "GET" || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(response))
+		require.NoError(t, err)
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"diff", "-f", manifestFile, "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flag",
+			wantErrText: "required flag(s) \"file\" not set",
+			args:        []string{"diff"},
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"diff", "-f", manifestFile},
+		},
+		{
+			name:            "diff manifest",
+			args:            []string{"diff", "-f", manifestFile},
+			wantOutputRegex: "^[\\s]*KIND[\\s]*NAME[\\s]*ACTION[\\s]*CHANGES[\\s]*repository[\\s]*Production[\\s]*create[\\s]*connection[\\s]*warehouse[\\s]*update[\\s]*parameters.HOST[\\s]*deploymentparameter[\\s]*NEW_PARAM[\\s]*create[\\s]*queue[\\s]*Priority[\\s]*update[\\s]*priority[\\s]*token[\\s]*deploy[\\s]*create[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+		},
+		{
+			name:            "diff manifest with prune in use",
+			args:            []string{"diff", "-f", manifestFile, "--prune", "--no-headers"},
+			wantOutputRegex: "deploymentparameter[\\s]*OLD_PARAM[\\s]*delete[\\s]*used by 1 workspace\\(s\\): ETL/loader.fmw",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+		},
+		{
+			name:            "diff manifest with prune",
+			args:            []string{"diff", "-f", manifestFile, "--prune", "--output", "custom-columns=KIND:.kind,NAME:.name,ACTION:.action", "--no-headers"},
+			wantOutputRegex: "^[\\s]*repository[\\s]*Production[\\s]*create[\\s]*repository[\\s]*Old[\\s]*delete[\\s]*connection[\\s]*warehouse[\\s]*update[\\s]*deploymentparameter[\\s]*NEW_PARAM[\\s]*create[\\s]*deploymentparameter[\\s]*OLD_PARAM[\\s]*delete[\\s]*queue[\\s]*Priority[\\s]*update[\\s]*token[\\s]*deploy[\\s]*create[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+		},
+	}
+
+	runTests(cases, t)
+
+}
@@ -78,7 +78,7 @@ func newEnginesCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "engines",
 		Short: "Get information about the FME Engines",
-		Long:  "Gets information and status about FME Engines currently connected to FME Server",
+		Long:  "Gets information and status about FME Engines currently connected to FME Server. Use the subcommands to start, stop and restart engines, change the queues assigned to them, scale the engines on each host, check CPU-time credit usage and manage remote engine services.",
 		Example: `
   # List all engines
   fmeflow engines
@@ -100,6 +100,15 @@ func newEnginesCmd() *cobra.Command {
 	cmd.Flags().Var(&f.apiVersion, "api-version", "The api version to use when contacting FME Server. Must be one of v3 or v4")
 	cmd.MarkFlagsMutuallyExclusive("output", "count")
 	cmd.MarkFlagsMutuallyExclusive("no-headers", "count")
+	cmd.AddCommand(newEngineStartCmd())
+	cmd.AddCommand(newEngineStopCmd())
+	cmd.AddCommand(newEngineRestartCmd())
+	cmd.AddCommand(newEngineAssignQueueCmd())
+	cmd.AddCommand(newEngineUnassignQueueCmd())
+	cmd.AddCommand(newEngineHostsCmd())
+	cmd.AddCommand(newEngineScaleCmd())
+	cmd.AddCommand(newEngineCreditsCmd())
+	cmd.AddCommand(newEngineRemoteServicesCmd())
 	//enginesCmd.MarkFlagsMutuallyExclusive("json", "count")
 	return cmd
 
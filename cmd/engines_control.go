@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type engineControlFlags struct {
+	names    []string
+	drain    bool
+	wait     bool
+	timeout  time.Duration
+	noprompt bool
+}
+
+const (
+	engineActionStart   = "start"
+	engineActionStop    = "stop"
+	engineActionRestart = "restart"
+)
+
+// the states FME Flow reports for running and stopped engines
+const (
+	engineStateActive  = "active"
+	engineStateStopped = "stopped"
+)
+
+// how often to check on an engine while waiting for it to change. Tests set this lower
+var engineWaitInterval = 1 * time.Second
+
+func newEngineStartCmd() *cobra.Command {
+	f := engineControlFlags{}
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Start stopped engines",
+		Long:  `Start engines that have been stopped. By default, the command waits until the engines are running.`,
+		Example: `
+  # Start the engine "FMESERVER_Engine1"
+  fmeflow engines start --name FMESERVER_Engine1
+
+  # Start two engines without waiting for them to be running
+  fmeflow engines start --name FMESERVER_Engine1 --name FMESERVER_Engine2 --wait=false`,
+		Args:    NoArgs,
+		PreRunE: engineControlPreRun,
+		RunE:    engineControlRun(engineActionStart, &f),
+	}
+	addEngineControlFlags(cmd, &f, "start")
+	return cmd
+}
+
+func newEngineStopCmd() *cobra.Command {
+	f := engineControlFlags{}
+	cmd := &cobra.Command{
+		Use:   "stop",
+		Short: "Stop engines",
+		Long: `Stop engines. Any job running on an engine when it is stopped is aborted. Use --drain to take each engine off its queues so that it isn't given another job, wait for its current job to finish and then stop it. The engine is assigned back to its queues once it has been stopped, so it picks up jobs again when it is started.
+By default, the command waits until the engines have stopped. With --drain it always waits, as an engine isn't assigned back to its queues until it has stopped.`,
+		Example: `
+  # Stop the engine "FMESERVER_Engine1"
+  fmeflow engines stop --name FMESERVER_Engine1
+
+  # Stop the engine once its current job finishes, without giving it another job and without prompting
+  fmeflow engines stop --name FMESERVER_Engine1 --drain --no-prompt`,
+		Args:    NoArgs,
+		PreRunE: engineControlPreRun,
+		RunE:    engineControlRun(engineActionStop, &f),
+	}
+	addEngineControlFlags(cmd, &f, "stop")
+	cmd.Flags().BoolVar(&f.drain, "drain", false, "Take each engine off its queues and wait for its current job to finish before stopping it.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	return cmd
+}
+
+func newEngineRestartCmd() *cobra.Command {
+	f := engineControlFlags{}
+	cmd := &cobra.Command{
+		Use:   "restart",
+		Short: "Restart engines",
+		Long: `Restart engines. Any job running on an engine when it is restarted is aborted. Use --drain to take each engine off its queues so that it isn't given another job, wait for its current job to finish and then restart it. The engine is assigned back to its queues once it has been restarted.
+By default, the command waits until the engines are running again. With --drain it always waits, as an engine isn't assigned back to its queues until it is running again.`,
+		Example: `
+  # Restart the engine "FMESERVER_Engine1"
+  fmeflow engines restart --name FMESERVER_Engine1
+
+  # Restart the engine once its current job finishes, without giving it another job and without prompting
+  fmeflow engines restart --name FMESERVER_Engine1 --drain --no-prompt`,
+		Args:    NoArgs,
+		PreRunE: engineControlPreRun,
+		RunE:    engineControlRun(engineActionRestart, &f),
+	}
+	addEngineControlFlags(cmd, &f, "restart")
+	cmd.Flags().BoolVar(&f.drain, "drain", false, "Take each engine off its queues and wait for its current job to finish before restarting it.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	return cmd
+}
+
+func addEngineControlFlags(cmd *cobra.Command, f *engineControlFlags, action string) {
+	cmd.Flags().StringArrayVar(&f.names, "name", []string{}, "Name of the engine to "+action+". Can be passed in multiple times.")
+	cmd.Flags().BoolVar(&f.wait, "wait", true, "Wait for the engines to "+action+". Set to false to return immediately.")
+	cmd.Flags().DurationVar(&f.timeout, "timeout", 5*time.Minute, "How long to wait for each engine.")
+	cmd.MarkFlagRequired("name")
+}
+
+// controlling engines is only possible with the V4 API
+func engineControlPreRun(cmd *cobra.Command, args []string) error {
+	if viper.GetInt("build") < enginesV4BuildThreshold {
+		return errors.New("managing engines requires the V4 API, which is not available on this version of FME Flow")
+	}
+	return nil
+}
+
+func engineControlRun(action string, f *engineControlFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		// make sure all the engines exist before changing any of them
+		engines := map[string]EngineV4{}
+		for _, name := range f.names {
+			engine, err := getEngineV4(client, name)
+			if err != nil {
+				return err
+			}
+			engines[name] = engine
+		}
+
+		if action != engineActionStart && !f.noprompt {
+			// prompt to confirm
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: "Are you sure you want to " + action + " " + strings.Join(f.names, ", ") + "? Any running jobs will be aborted.",
+			}
+			if f.drain {
+				promptUser.Message = "Are you sure you want to " + action + " " + strings.Join(f.names, ", ") + " once their current jobs finish?"
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		for _, name := range f.names {
+			queues := engines[name].AssignedQueues
+			if f.drain {
+				// an idle engine can be given a new job at any time, so it is taken off its queues before
+				// waiting for its current job to finish and only put back on them once the action is done
+				if err := setEngineQueues(client, name, queues, false); err != nil {
+					return err
+				}
+				if !jsonOutput {
+					fmt.Fprintln(cmd.OutOrStdout(), "Waiting for engine "+name+" to finish its current job...")
+				}
+				if err := waitForEngine(client, name, f.timeout, func(engine EngineV4) bool { return engine.CurrentJobID == 0 }); err != nil {
+					return restoreEngineQueues(client, name, queues, fmt.Errorf("engine %s did not finish its current job: %w", name, err))
+				}
+			}
+
+			if _, err := sendFmeFlowJSON(client, "/fmeapiv4/engines/"+name+"/"+action, "POST", nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent); err != nil {
+				if f.drain {
+					return restoreEngineQueues(client, name, queues, err)
+				}
+				return err
+			}
+
+			// the action may be carried out after the request returns, so a draining engine has to be waited
+			// on before it goes back on its queues, otherwise it could pick up a job that is then aborted
+			if f.wait || f.drain {
+				state := engineStateActive
+				if action == engineActionStop {
+					state = engineStateStopped
+				}
+				if err := waitForEngine(client, name, f.timeout, func(engine EngineV4) bool { return strings.EqualFold(engine.State, state) }); err != nil {
+					err = fmt.Errorf("engine %s did not %s: %w", name, action, err)
+					if f.drain {
+						return restoreEngineQueues(client, name, queues, err)
+					}
+					return err
+				}
+			}
+			if f.drain {
+				if err := setEngineQueues(client, name, queues, true); err != nil {
+					return err
+				}
+			}
+
+			if !jsonOutput {
+				fmt.Fprintln(cmd.OutOrStdout(), engineActionMessage(name, action, f.wait || f.drain))
+			}
+		}
+
+		if jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
+
+func engineActionMessage(name string, action string, waited bool) string {
+	if !waited {
+		return "Engine " + name + " " + action + " request successfully sent."
+	}
+	switch action {
+	case engineActionStart:
+		return "Engine " + name + " successfully started."
+	case engineActionStop:
+		return "Engine " + name + " successfully stopped."
+	}
+	return "Engine " + name + " successfully restarted."
+}
+
+// get a single engine by name
+func getEngineV4(client *http.Client, name string) (EngineV4, error) {
+	var result EngineV4
+
+	request, err := buildFmeFlowRequest("/fmeapiv4/engines/"+name, "GET", nil)
+	if err != nil {
+		return result, err
+	}
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return result, err
+	} else if response.StatusCode != http.StatusOK {
+		if response.StatusCode == http.StatusNotFound {
+			return result, fmt.Errorf("%w: check that the engine %s exists", errors.New(response.Status), name)
+		}
+		return result, parseResponseMessage(response)
+	}
+
+	responseData, err := io.ReadAll(response.Body)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(responseData, &result)
+	return result, err
+}
+
+// assign an engine to queues, or take it off them
+func setEngineQueues(client *http.Client, name string, queues []string, assign bool) error {
+	method := "PUT"
+	if !assign {
+		method = "DELETE"
+	}
+	for _, queue := range queues {
+		if _, err := sendFmeFlowJSON(client, "/fmeapiv4/queues/"+queue+"/engines/"+name, method, nil, http.StatusOK, http.StatusNoContent); err != nil {
+			if assign {
+				return fmt.Errorf("could not assign engine %s back to queue %s: %w", name, queue, err)
+			}
+			return fmt.Errorf("could not take engine %s off queue %s: %w", name, queue, err)
+		}
+	}
+	return nil
+}
+
+// put a drained engine back on its queues after something went wrong, returning the original error
+func restoreEngineQueues(client *http.Client, name string, queues []string, err error) error {
+	if restoreErr := setEngineQueues(client, name, queues, true); restoreErr != nil {
+		return fmt.Errorf("%w. %w", err, restoreErr)
+	}
+	return err
+}
+
+// check on an engine until the condition is met or the timeout is reached
+func waitForEngine(client *http.Client, name string, timeout time.Duration, condition func(EngineV4) bool) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		engine, err := getEngineV4(client, name)
+		if err != nil {
+			return err
+		}
+		if condition(engine) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s", timeout)
+		}
+		time.Sleep(engineWaitInterval)
+	}
+}
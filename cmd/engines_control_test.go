@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnginesControl(t *testing.T) {
+	engineWaitInterval = time.Millisecond
+
+	// write the engine FMESERVER_Engine1 in the given state, running the given job
+	writeEngine := func(w http.ResponseWriter, state string, jobID int) {
+		engine := EngineV4{Name: "FMESERVER_Engine1", State: state, CurrentJobID: jobID, AssignedQueues: []string{"Default"}}
+		response, err := json.Marshal(engine)
+		require.NoError(t, err)
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write(response)
+		require.NoError(t, err)
+	}
+
+	// an idle engine that is running until it is stopped
+	stopState := "ACTIVE"
+	customHttpServerHandlerStop := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/engines/FMESERVER_Engine1" {
+			writeEngine(w, stopState, 0)
+		} else if r.Method == "POST" && r.URL.Path == "/fmeapiv4/engines/FMESERVER_Engine1/stop" {
+			stopState = "STOPPED"
+			w.WriteHeader(http.StatusAccepted)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// a stopped engine that is running once it is started
+	startState := "STOPPED"
+	customHttpServerHandlerStart := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/engines/FMESERVER_Engine1" {
+			writeEngine(w, startState, 0)
+		} else if r.Method == "POST" && r.URL.Path == "/fmeapiv4/engines/FMESERVER_Engine1/start" {
+			startState = "ACTIVE"
+			w.WriteHeader(http.StatusAccepted)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// a running engine that can be restarted
+	customHttpServerHandlerRestart := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/engines/FMESERVER_Engine1" {
+			writeEngine(w, "ACTIVE", 0)
+		} else if r.Method == "POST" && r.URL.Path == "/fmeapiv4/engines/FMESERVER_Engine1/restart" {
+			w.WriteHeader(http.StatusAccepted)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// an engine on the Default queue that finishes its job after it has been checked three times. It must
+	// be taken off its queues before it finishes the job, not be stopped while it is running the job and
+	// be assigned back to its queues once it has stopped, which takes two more checks after the stop request
+	drainChecks := 0
+	drainStopChecks := -1
+	drainState := "ACTIVE"
+	drainQueues := []string{"Default"}
+	customHttpServerHandlerDrain := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/engines/FMESERVER_Engine1" {
+			drainChecks++
+			jobID := 12
+			if drainChecks > 3 {
+				jobID = 0
+			}
+			if drainStopChecks >= 0 {
+				drainStopChecks++
+				if drainStopChecks > 2 {
+					drainState = "STOPPED"
+				}
+			}
+			engine := EngineV4{Name: "FMESERVER_Engine1", State: drainState, CurrentJobID: jobID, AssignedQueues: drainQueues}
+			response, err := json.Marshal(engine)
+			require.NoError(t, err)
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write(response)
+			require.NoError(t, err)
+		} else if r.Method == "DELETE" && r.URL.Path == "/fmeapiv4/queues/Default/engines/FMESERVER_Engine1" {
+			drainQueues = []string{}
+			w.WriteHeader(http.StatusNoContent)
+		} else if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/queues/Default/engines/FMESERVER_Engine1" {
+			require.Equal(t, "STOPPED", drainState)
+			drainQueues = []string{"Default"}
+			w.WriteHeader(http.StatusNoContent)
+		} else if r.Method == "POST" && r.URL.Path == "/fmeapiv4/engines/FMESERVER_Engine1/stop" {
+			require.Greater(t, drainChecks, 3)
+			require.Empty(t, drainQueues)
+			drainStopChecks = 0
+			w.WriteHeader(http.StatusAccepted)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// an engine that never finishes its job. It is assigned back to its queue when the drain times out
+	busyRestored := false
+	customHttpServerHandlerBusy := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/engines/FMESERVER_Engine1" {
+			writeEngine(w, "ACTIVE", 12)
+		} else if r.Method == "DELETE" && r.URL.Path == "/fmeapiv4/queues/Default/engines/FMESERVER_Engine1" {
+			w.WriteHeader(http.StatusNoContent)
+		} else if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/queues/Default/engines/FMESERVER_Engine1" {
+			busyRestored = true
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"engines", "restart", "--name", "FMESERVER_Engine1", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing name",
+			args:        []string{"engines", "stop"},
+			wantErrText: "required flag(s) \"name\" not set",
+		},
+		{
+			name:         "old build",
+			args:         []string{"engines", "start", "--name", "FMESERVER_Engine1"},
+			fmeflowBuild: 22337,
+			wantErrText:  "managing engines requires the V4 API, which is not available on this version of FME Flow",
+		},
+		{
+			name:        "engine does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandlerStop)),
+			args:        []string{"engines", "stop", "--name", "FMESERVER_Engine2", "-y"},
+			wantErrText: "404 Not Found: check that the engine FMESERVER_Engine2 exists",
+		},
+		{
+			name:            "stop engine",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerStop)),
+			args:            []string{"engines", "stop", "--name", "FMESERVER_Engine1", "-y"},
+			wantOutputRegex: "^Engine FMESERVER_Engine1 successfully stopped.\n$",
+		},
+		{
+			name:            "stop engine with drain",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerDrain)),
+			args:            []string{"engines", "stop", "--name", "FMESERVER_Engine1", "--drain", "--wait=false", "-y"},
+			wantOutputRegex: "^Waiting for engine FMESERVER_Engine1 to finish its current job...\nEngine FMESERVER_Engine1 successfully stopped.\n$",
+		},
+		{
+			name:        "drain timeout",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandlerBusy)),
+			args:        []string{"engines", "restart", "--name", "FMESERVER_Engine1", "--drain", "--timeout", "10ms", "-y"},
+			wantErrText: "engine FMESERVER_Engine1 did not finish its current job: timed out after 10ms",
+		},
+		{
+			name:            "start engine",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerStart)),
+			args:            []string{"engines", "start", "--name", "FMESERVER_Engine1"},
+			wantOutputRegex: "^Engine FMESERVER_Engine1 successfully started.\n$",
+		},
+		{
+			name:            "restart engine without waiting",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerRestart)),
+			args:            []string{"engines", "restart", "--name", "FMESERVER_Engine1", "--wait=false", "-y"},
+			wantOutputRegex: "^Engine FMESERVER_Engine1 restart request successfully sent.\n$",
+		},
+		{
+			name:           "restart engine json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandlerRestart)),
+			args:           []string{"engines", "restart", "--name", "FMESERVER_Engine1", "-y", "--json"},
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+	require.Equal(t, []string{"Default"}, drainQueues)
+	require.True(t, busyRestored)
+}
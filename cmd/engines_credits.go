@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// CPU-time credit usage for dynamic engines. Credits are measured in hours of CPU time
+type CPUUsageV4 struct {
+	UsedCredits      float64          `json:"usedCredits"`
+	RemainingCredits float64          `json:"remainingCredits"`
+	ExpiryDate       time.Time        `json:"expiryDate"`
+	Engines          []EngineCPUUsage `json:"engines"`
+}
+
+type EngineCPUUsage struct {
+	Name        string  `json:"name"`
+	Hostname    string  `json:"hostname"`
+	UsedCredits float64 `json:"usedCredits"`
+}
+
+type engineCreditsFlags struct {
+	perEngine  bool
+	outputType string
+	noHeaders  bool
+}
+
+func newEngineCreditsCmd() *cobra.Command {
+	f := engineCreditsFlags{}
+	cmd := &cobra.Command{
+		Use:   "credits",
+		Short: "Show the CPU-time credits used by dynamic engines",
+		Long:  `Shows the CPU-time credits used and remaining for dynamic engines, in hours of CPU time. Use --per-engine to see how many credits each dynamic engine has used.`,
+		Example: `
+  # Show the credits used and remaining
+  fmeflow engines credits
+
+  # Show the credits used by each dynamic engine
+  fmeflow engines credits --per-engine
+
+  # Output just the remaining credits
+  fmeflow engines credits --output=custom-columns=REMAINING:.remainingCredits --no-headers`,
+		Args:    NoArgs,
+		PreRunE: engineControlPreRun,
+		RunE:    engineCreditsRun(&f),
+	}
+	cmd.Flags().BoolVar(&f.perEngine, "per-engine", false, "List the credits used by each dynamic engine.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	return cmd
+}
+
+func engineCreditsRun(f *engineCreditsFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		responseData, err := sendFmeFlowJSON(client, "/fmeapiv4/licensing/cpuusage", "GET", nil, http.StatusOK)
+		if err != nil {
+			return err
+		}
+
+		var result CPUUsageV4
+		if err := json.Unmarshal(responseData, &result); err != nil {
+			return err
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			if f.perEngine {
+				t.AppendHeader(table.Row{"Engine", "Hostname", "Used Credits"})
+				for _, element := range result.Engines {
+					t.AppendRow(table.Row{element.Name, element.Hostname, fmt.Sprintf("%.2f", element.UsedCredits)})
+				}
+			} else {
+				expiry := ""
+				if !result.ExpiryDate.IsZero() {
+					expiry = result.ExpiryDate.Format(time.DateOnly)
+				}
+				t.AppendHeader(table.Row{"Used Credits", "Remaining Credits", "Expiry Date"})
+				t.AppendRow(table.Row{fmt.Sprintf("%.2f", result.UsedCredits), fmt.Sprintf("%.2f", result.RemainingCredits), expiry})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			marshalledItems := [][]byte{}
+			if f.perEngine {
+				for _, element := range result.Engines {
+					mJson, err := json.Marshal(element)
+					if err != nil {
+						return err
+					}
+					marshalledItems = append(marshalledItems, mJson)
+				}
+			} else {
+				marshalledItems = append(marshalledItems, responseData)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
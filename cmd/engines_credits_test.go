@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestEnginesCredits(t *testing.T) {
+	responseV4 := `{
+		"usedCredits": 12.5,
+		"remainingCredits": 87.25,
+		"expiryDate": "2027-03-01T00:00:00Z",
+		"engines": [
+		  {
+			"name": "FMESERVER_Dynamic1",
+			"hostname": "engines1.example.com",
+			"usedCredits": 10
+		  },
+		  {
+			"name": "FMESERVER_Dynamic2",
+			"hostname": "engines1.example.com",
+			"usedCredits": 2.5
+		  }
+		]
+	  }`
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"engines", "credits", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			args:        []string{"engines", "credits"},
+			wantErrText: "500 Internal Server Error",
+		},
+		{
+			name:            "credits summary",
+			statusCode:      http.StatusOK,
+			body:            responseV4,
+			args:            []string{"engines", "credits"},
+			wantOutputRegex: "^[\\s]*USED CREDITS[\\s]*REMAINING CREDITS[\\s]*EXPIRY DATE[\\s]*12.50[\\s]*87.25[\\s]*2027-03-01[\\s]*$",
+			wantURLContains: "/fmeapiv4/licensing/cpuusage",
+		},
+		{
+			name:            "credits per engine",
+			statusCode:      http.StatusOK,
+			body:            responseV4,
+			args:            []string{"engines", "credits", "--per-engine", "--no-headers"},
+			wantOutputRegex: "^[\\s]*FMESERVER_Dynamic1[\\s]*engines1.example.com[\\s]*10.00[\\s]*FMESERVER_Dynamic2[\\s]*engines1.example.com[\\s]*2.50[\\s]*$",
+		},
+		{
+			name:            "credits custom columns",
+			statusCode:      http.StatusOK,
+			body:            responseV4,
+			args:            []string{"engines", "credits", "--output=custom-columns=REMAINING:.remainingCredits"},
+			wantOutputRegex: "^[\\s]*REMAINING[\\s]*87.25[\\s]*$",
+		},
+		{
+			name:           "credits json",
+			statusCode:     http.StatusOK,
+			body:           responseV4,
+			args:           []string{"engines", "credits", "--json"},
+			wantOutputJson: responseV4,
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type EngineHostV4 struct {
+	Hostname        string `json:"hostname"`
+	Platform        string `json:"platform"`
+	StandardEngines int    `json:"standardEngines"`
+	DynamicEngines  int    `json:"dynamicEngines"`
+	HostProperties  struct {
+		PhysicalMemory int `json:"physicalMemory"`
+		ProcessorCount int `json:"processorCount"`
+	} `json:"hostProperties"`
+}
+
+type EngineHostsV4 struct {
+	Offset     int            `json:"offset"`
+	Limit      int            `json:"limit"`
+	TotalCount int            `json:"totalCount"`
+	Items      []EngineHostV4 `json:"items"`
+}
+
+// the number of each type of engine to run on a host
+type EngineHostScaleV4 struct {
+	StandardEngines int `json:"standardEngines"`
+	DynamicEngines  int `json:"dynamicEngines"`
+}
+
+type engineHostsFlags struct {
+	outputType string
+	noHeaders  bool
+}
+
+type engineScaleFlags struct {
+	host     string
+	standard int
+	dynamic  int
+}
+
+func newEngineHostsCmd() *cobra.Command {
+	f := engineHostsFlags{}
+	cmd := &cobra.Command{
+		Use:   "hosts",
+		Short: "List the hosts that run FME Engines",
+		Long:  `Lists the hosts that run FME Engines with their processors, memory and the number of standard and dynamic engines configured on each. Use "fmeflow engines scale" to change the number of engines on a host.`,
+		Example: `
+  # List all engine hosts
+  fmeflow engines hosts
+
+  # Output the hostname and processor count of each host
+  fmeflow engines hosts --output=custom-columns=HOST:.hostname,CPUS:.hostProperties.processorCount`,
+		Args:    NoArgs,
+		PreRunE: engineControlPreRun,
+		RunE:    engineHostsRun(&f),
+	}
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	return cmd
+}
+
+func newEngineScaleCmd() *cobra.Command {
+	f := engineScaleFlags{}
+	cmd := &cobra.Command{
+		Use:   "scale",
+		Short: "Change the number of engines on a host",
+		Long: `Change the number of standard or dynamic engines that run on an engine host. Dynamic engines consume CPU-time credits while they run jobs, so lowering the number of dynamic engines is a way to limit costs.
+Any count that isn't specified is left unchanged.`,
+		Example: `
+  # Run 4 dynamic engines on the host "engines1.example.com"
+  fmeflow engines scale --host engines1.example.com --dynamic 4
+
+  # Stop all dynamic engines and run 2 standard engines on a host
+  fmeflow engines scale --host engines1.example.com --dynamic 0 --standard 2`,
+		Args:    NoArgs,
+		PreRunE: engineControlPreRun,
+		RunE:    engineScaleRun(&f),
+	}
+	cmd.Flags().StringVar(&f.host, "host", "", "Hostname of the engine host to scale.")
+	cmd.Flags().IntVar(&f.standard, "standard", 0, "Number of standard engines to run on the host.")
+	cmd.Flags().IntVar(&f.dynamic, "dynamic", 0, "Number of dynamic engines to run on the host.")
+	cmd.MarkFlagRequired("host")
+	cmd.MarkFlagsOneRequired("standard", "dynamic")
+	return cmd
+}
+
+func engineHostsRun(f *engineHostsFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		hosts, err := getAllItemsV4[EngineHostV4](client, "/fmeapiv4/enginehosts")
+		if err != nil {
+			return err
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Hostname", "Platform", "Processors", "Memory", "Standard Engines", "Dynamic Engines"})
+
+			for _, element := range hosts {
+				t.AppendRow(table.Row{element.Hostname, element.Platform, element.HostProperties.ProcessorCount, element.HostProperties.PhysicalMemory, element.StandardEngines, element.DynamicEngines})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			responseData, err := json.Marshal(EngineHostsV4{Items: hosts, TotalCount: len(hosts), Limit: len(hosts)})
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			marshalledItems := [][]byte{}
+			for _, element := range hosts {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+func engineScaleRun(f *engineScaleFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if f.standard < 0 || f.dynamic < 0 {
+			return errors.New("the number of engines can't be negative")
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		// start from the current counts so that only the specified ones change
+		hosts, err := getAllItemsV4[EngineHostV4](client, "/fmeapiv4/enginehosts")
+		if err != nil {
+			return err
+		}
+		var scale *EngineHostScaleV4
+		for _, host := range hosts {
+			if strings.EqualFold(host.Hostname, f.host) {
+				scale = &EngineHostScaleV4{StandardEngines: host.StandardEngines, DynamicEngines: host.DynamicEngines}
+				f.host = host.Hostname
+				break
+			}
+		}
+		if scale == nil {
+			return fmt.Errorf("engine host %s does not exist", f.host)
+		}
+
+		if cmd.Flags().Changed("standard") {
+			scale.StandardEngines = f.standard
+		}
+		if cmd.Flags().Changed("dynamic") {
+			scale.DynamicEngines = f.dynamic
+		}
+
+		if _, err := sendFmeFlowJSON(client, "/fmeapiv4/enginehosts/"+f.host, "PUT", scale, http.StatusOK, http.StatusNoContent); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintf(cmd.OutOrStdout(), "Engine host %s successfully scaled to %d standard and %d dynamic engines.\n", f.host, scale.StandardEngines, scale.DynamicEngines)
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
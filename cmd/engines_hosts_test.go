@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnginesHosts(t *testing.T) {
+	engineHostsBody := `{
+		"items": [
+		  {
+			"hostname": "engines1.example.com",
+			"platform": "WIN64",
+			"standardEngines": 2,
+			"dynamicEngines": 4,
+			"hostProperties": {
+			  "physicalMemory": 17179869184,
+			  "processorCount": 8
+			}
+		  }
+		],
+		"limit": 1,
+		"offset": 0,
+		"totalCount": 1
+	  }`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/enginehosts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(engineHostsBody))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// scales the dynamic engines and keeps the standard engines
+	customHttpServerHandlerDynamic := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/enginehosts/engines1.example.com" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"standardEngines":2,"dynamicEngines":0}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// scales both kinds of engines
+	customHttpServerHandlerBoth := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/enginehosts/engines1.example.com" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"standardEngines":1,"dynamicEngines":6}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"engines", "hosts", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:         "old build",
+			statusCode:   http.StatusOK,
+			fmeflowBuild: 23166,
+			args:         []string{"engines", "hosts"},
+			wantErrText:  "managing engines requires the V4 API, which is not available on this version of FME Flow",
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			args:        []string{"engines", "hosts"},
+			wantErrText: "500 Internal Server Error",
+		},
+		{
+			name:            "list hosts",
+			statusCode:      http.StatusOK,
+			body:            engineHostsBody,
+			args:            []string{"engines", "hosts"},
+			wantOutputRegex: "^[\\s]*HOSTNAME[\\s]*PLATFORM[\\s]*PROCESSORS[\\s]*MEMORY[\\s]*STANDARD ENGINES[\\s]*DYNAMIC ENGINES[\\s]*engines1.example.com[\\s]*WIN64[\\s]*8[\\s]*17179869184[\\s]*2[\\s]*4[\\s]*$",
+		},
+		{
+			name:            "list hosts custom columns",
+			statusCode:      http.StatusOK,
+			body:            engineHostsBody,
+			args:            []string{"engines", "hosts", "--output=custom-columns=HOST:.hostname,CPUS:.hostProperties.processorCount", "--no-headers"},
+			wantOutputRegex: "^[\\s]*engines1.example.com[\\s]*8[\\s]*$",
+		},
+		{
+			name:           "list hosts json",
+			statusCode:     http.StatusOK,
+			body:           engineHostsBody,
+			args:           []string{"engines", "hosts", "--json"},
+			wantOutputJson: engineHostsBody,
+		},
+		{
+			name:        "scale missing counts",
+			args:        []string{"engines", "scale", "--host", "engines1.example.com"},
+			wantErrText: "at least one of the flags in the group [standard dynamic] is required",
+		},
+		{
+			name:        "scale negative",
+			args:        []string{"engines", "scale", "--host", "engines1.example.com", "--dynamic", "-1"},
+			wantErrText: "the number of engines can't be negative",
+		},
+		{
+			name:        "scale missing host",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"engines", "scale", "--host", "engines2.example.com", "--dynamic", "1"},
+			wantErrText: "engine host engines2.example.com does not exist",
+		},
+		{
+			name:            "scale dynamic engines",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerDynamic)),
+			args:            []string{"engines", "scale", "--host", "engines1.example.com", "--dynamic", "0"},
+			wantOutputRegex: "^Engine host engines1.example.com successfully scaled to 2 standard and 0 dynamic engines.\n$",
+		},
+		{
+			name:           "scale both json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandlerBoth)),
+			args:           []string{"engines", "scale", "--host", "ENGINES1.example.com", "--dynamic", "6", "--standard", "1", "--json"},
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+type engineQueueFlags struct {
+	name     string
+	queues   []string
+	wait     bool
+	timeout  time.Duration
+	noprompt bool
+}
+
+func newEngineAssignQueueCmd() *cobra.Command {
+	f := engineQueueFlags{}
+	cmd := &cobra.Command{
+		Use:   "assign-queue",
+		Short: "Assign queues to an engine",
+		Long:  `Assign queues to an engine so that it runs the jobs submitted to those queues. By default, the command waits until the engine reports the new queues.`,
+		Example: `
+  # Assign the queue "Priority" to the engine "FMESERVER_Engine1"
+  fmeflow engines assign-queue --name FMESERVER_Engine1 --queue Priority`,
+		Args:    NoArgs,
+		PreRunE: engineControlPreRun,
+		RunE:    engineQueueRun(true, &f),
+	}
+	addEngineQueueFlags(cmd, &f, "assign to")
+	return cmd
+}
+
+func newEngineUnassignQueueCmd() *cobra.Command {
+	f := engineQueueFlags{}
+	cmd := &cobra.Command{
+		Use:   "unassign-queue",
+		Short: "Unassign queues from an engine",
+		Long:  `Unassign queues from an engine so that it no longer runs the jobs submitted to those queues. By default, the command waits until the engine no longer reports the queues.`,
+		Example: `
+  # Unassign the queue "Priority" from the engine "FMESERVER_Engine1"
+  fmeflow engines unassign-queue --name FMESERVER_Engine1 --queue Priority
+
+  # Unassign two queues without prompting
+  fmeflow engines unassign-queue --name FMESERVER_Engine1 --queue Priority --queue Default -y`,
+		Args:    NoArgs,
+		PreRunE: engineControlPreRun,
+		RunE:    engineQueueRun(false, &f),
+	}
+	addEngineQueueFlags(cmd, &f, "unassign from")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	return cmd
+}
+
+func addEngineQueueFlags(cmd *cobra.Command, f *engineQueueFlags, action string) {
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the engine.")
+	cmd.Flags().StringArrayVar(&f.queues, "queue", []string{}, "Name of the queue to "+action+" the engine. Can be passed in multiple times.")
+	cmd.Flags().BoolVar(&f.wait, "wait", true, "Wait for the engine to report the change. Set to false to return immediately.")
+	cmd.Flags().DurationVar(&f.timeout, "timeout", 5*time.Minute, "How long to wait for the engine.")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("queue")
+}
+
+func engineQueueRun(assign bool, f *engineQueueFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		engine, err := getEngineV4(client, f.name)
+		if err != nil {
+			return err
+		}
+
+		if !assign {
+			for _, queue := range f.queues {
+				if !slices.Contains(engine.AssignedQueues, queue) {
+					return fmt.Errorf("queue %s is not assigned to engine %s", queue, f.name)
+				}
+			}
+
+			if !f.noprompt {
+				// prompt to confirm
+				confirm := false
+				promptUser := &survey.Confirm{
+					Message: "Are you sure you want to unassign " + strings.Join(f.queues, ", ") + " from " + f.name + "?",
+				}
+				survey.AskOne(promptUser, &confirm)
+				if !confirm {
+					return nil
+				}
+			}
+		}
+
+		if err := setEngineQueues(client, f.name, f.queues, assign); err != nil {
+			return err
+		}
+
+		if f.wait {
+			err := waitForEngine(client, f.name, f.timeout, func(engine EngineV4) bool {
+				for _, queue := range f.queues {
+					if slices.Contains(engine.AssignedQueues, queue) != assign {
+						return false
+					}
+				}
+				return true
+			})
+			if err != nil {
+				return fmt.Errorf("engine %s did not report the change to its queues: %w", f.name, err)
+			}
+		}
+
+		if !jsonOutput {
+			if assign {
+				fmt.Fprintln(cmd.OutOrStdout(), "Queues successfully assigned.")
+			} else {
+				fmt.Fprintln(cmd.OutOrStdout(), "Queues successfully unassigned.")
+			}
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
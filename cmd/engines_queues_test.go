@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnginesQueues(t *testing.T) {
+	engineWaitInterval = time.Millisecond
+
+	// write the engine FMESERVER_Engine1 assigned to the given queues
+	writeEngine := func(w http.ResponseWriter, queues []string) {
+		engine := EngineV4{Name: "FMESERVER_Engine1", State: "ACTIVE", AssignedQueues: queues}
+		response, err := json.Marshal(engine)
+		require.NoError(t, err)
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write(response)
+		require.NoError(t, err)
+	}
+
+	// an engine on the Default queue that can be assigned to more queues
+	assignedQueues := []string{"Default"}
+	customHttpServerHandlerAssign := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/engines/FMESERVER_Engine1" {
+			writeEngine(w, assignedQueues)
+		} else if r.Method == "PUT" && strings.HasPrefix(r.URL.Path, "/fmeapiv4/queues/") && strings.HasSuffix(r.URL.Path, "/engines/FMESERVER_Engine1") {
+			queue := strings.Split(r.URL.Path, "/")[3]
+			if !slices.Contains(assignedQueues, queue) {
+				assignedQueues = append(assignedQueues, queue)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// an engine on the Default queue that can be taken off it
+	unassignedQueues := []string{"Default"}
+	customHttpServerHandlerUnassign := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/engines/FMESERVER_Engine1" {
+			writeEngine(w, unassignedQueues)
+		} else if r.Method == "DELETE" && r.URL.Path == "/fmeapiv4/queues/Default/engines/FMESERVER_Engine1" {
+			unassignedQueues = []string{}
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"engines", "assign-queue", "--name", "FMESERVER_Engine1", "--queue", "Priority", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing queue",
+			args:        []string{"engines", "assign-queue", "--name", "FMESERVER_Engine1"},
+			wantErrText: "required flag(s) \"queue\" not set",
+		},
+		{
+			name:            "assign queue",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerAssign)),
+			args:            []string{"engines", "assign-queue", "--name", "FMESERVER_Engine1", "--queue", "Priority", "--queue", "Slow"},
+			wantOutputRegex: "^Queues successfully assigned.\n$",
+		},
+		{
+			name:            "unassign queue",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerUnassign)),
+			args:            []string{"engines", "unassign-queue", "--name", "FMESERVER_Engine1", "--queue", "Default", "-y"},
+			wantOutputRegex: "^Queues successfully unassigned.\n$",
+		},
+		{
+			name:        "unassign queue not assigned",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandlerUnassign)),
+			args:        []string{"engines", "unassign-queue", "--name", "FMESERVER_Engine1", "--queue", "Priority", "-y"},
+			wantErrText: "queue Priority is not assigned to engine FMESERVER_Engine1",
+		},
+		{
+			name:           "assign queue json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandlerAssign)),
+			args:           []string{"engines", "assign-queue", "--name", "FMESERVER_Engine1", "--queue", "Priority", "--json"},
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type RemoteEngineServiceV4 struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	EngineCount int    `json:"engineCount"`
+}
+
+type RemoteEngineServicesV4 struct {
+	Offset     int                     `json:"offset"`
+	Limit      int                     `json:"limit"`
+	TotalCount int                     `json:"totalCount"`
+	Items      []RemoteEngineServiceV4 `json:"items"`
+}
+
+type NewRemoteEngineServiceV4 struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+	Token       string `json:"token,omitempty"`
+}
+
+type remoteServicesFlags struct {
+	outputType string
+	noHeaders  bool
+}
+
+type remoteServiceRegisterFlags struct {
+	name        string
+	url         string
+	description string
+	token       string
+	tokenFile   string
+	tokenStdin  bool
+	tokenEnv    string
+}
+
+type remoteServiceDeregisterFlags struct {
+	name     string
+	noprompt bool
+}
+
+func newEngineRemoteServicesCmd() *cobra.Command {
+	f := remoteServicesFlags{}
+	cmd := &cobra.Command{
+		Use:   "remote-services",
+		Short: "List, register and deregister remote engine services",
+		Long:  `Lists the remote engine services registered with FME Flow. Remote engine services run FME Engines outside of the FME Flow installation, such as in another data center or cloud. Use the subcommands to register and deregister them.`,
+		Example: `
+  # List all remote engine services
+  fmeflow engines remote-services
+
+  # Register a remote engine service
+  fmeflow engines remote-services register --name east --url https://engines-east.example.com --token-env EAST_TOKEN`,
+		Args:    NoArgs,
+		PreRunE: engineControlPreRun,
+		RunE:    remoteServicesRun(&f),
+	}
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.AddCommand(newRemoteServiceRegisterCmd())
+	cmd.AddCommand(newRemoteServiceDeregisterCmd())
+	return cmd
+}
+
+func newRemoteServiceRegisterCmd() *cobra.Command {
+	f := remoteServiceRegisterFlags{}
+	cmd := &cobra.Command{
+		Use:   "register",
+		Short: "Register a remote engine service",
+		Long:  `Register a remote engine service with FME Flow so that the engines it runs can process jobs. The token that the remote engine service uses to connect can be read from a file, stdin or an environment variable to keep it out of the shell history.`,
+		Example: `
+  # Register a remote engine service
+  fmeflow engines remote-services register --name east --url https://engines-east.example.com --token-file east-token.txt
+
+  # Register a remote engine service, reading the token from stdin
+  cat east-token.txt | fmeflow engines remote-services register --name east --url https://engines-east.example.com --token-stdin`,
+		Args:    NoArgs,
+		PreRunE: engineControlPreRun,
+		RunE:    remoteServiceRegisterRun(&f),
+	}
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the remote engine service.")
+	cmd.Flags().StringVar(&f.url, "url", "", "URL of the remote engine service.")
+	cmd.Flags().StringVar(&f.description, "description", "", "Description of the remote engine service.")
+	cmd.Flags().StringVar(&f.token, "token", "", "Token the remote engine service uses to connect.")
+	cmd.Flags().StringVar(&f.tokenFile, "token-file", "", "Read the token from a file.")
+	cmd.Flags().BoolVar(&f.tokenStdin, "token-stdin", false, "Read the token from stdin.")
+	cmd.Flags().StringVar(&f.tokenEnv, "token-env", "", "Read the token from an environment variable.")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("url")
+	cmd.MarkFlagsMutuallyExclusive("token", "token-file", "token-stdin", "token-env")
+	return cmd
+}
+
+func newRemoteServiceDeregisterCmd() *cobra.Command {
+	f := remoteServiceDeregisterFlags{}
+	cmd := &cobra.Command{
+		Use:   "deregister",
+		Short: "Deregister a remote engine service",
+		Long:  `Deregister a remote engine service. Its engines stop processing jobs for FME Flow.`,
+		Example: `
+  # Deregister the remote engine service "east"
+  fmeflow engines remote-services deregister --name east
+
+  # Deregister the remote engine service "east" with no confirmation
+  fmeflow engines remote-services deregister --name east -y`,
+		Args:    NoArgs,
+		PreRunE: engineControlPreRun,
+		RunE:    remoteServiceDeregisterRun(&f),
+	}
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the remote engine service to deregister.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func remoteServicesRun(f *remoteServicesFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		services, err := getAllItemsV4[RemoteEngineServiceV4](client, "/fmeapiv4/remoteengineservices")
+		if err != nil {
+			return err
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Name", "URL", "Status", "Engines", "Description"})
+
+			for _, element := range services {
+				t.AppendRow(table.Row{element.Name, element.URL, element.Status, element.EngineCount, element.Description})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			responseData, err := json.Marshal(RemoteEngineServicesV4{Items: services, TotalCount: len(services), Limit: len(services)})
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			marshalledItems := [][]byte{}
+			for _, element := range services {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+func remoteServiceRegisterRun(f *remoteServiceRegisterFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if u, err := url.ParseRequestURI(f.url); err != nil || u.Host == "" {
+			return fmt.Errorf("invalid url %s", f.url)
+		}
+
+		token, err := readPasswordFlags(cmd, f.token, f.tokenFile, f.tokenStdin, f.tokenEnv)
+		if err != nil {
+			return err
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		service := NewRemoteEngineServiceV4{Name: f.name, URL: f.url, Description: f.description, Token: token}
+		if _, err := sendFmeFlowJSON(client, "/fmeapiv4/remoteengineservices", "POST", service, http.StatusCreated); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Remote engine service successfully registered.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
+
+func remoteServiceDeregisterRun(f *remoteServiceDeregisterFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if !f.noprompt {
+			// prompt to confirm
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: "Are you sure you want to deregister the remote engine service " + f.name + "?",
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		if _, err := sendFmeFlowJSON(client, "/fmeapiv4/remoteengineservices/"+f.name, "DELETE", nil, http.StatusNoContent, http.StatusOK); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Remote engine service successfully deregistered.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
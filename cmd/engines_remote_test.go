@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestEnginesRemoteServices(t *testing.T) {
+	responseV4 := `{
+		"items": [
+		  {
+			"name": "east",
+			"url": "https://engines-east.example.com",
+			"description": "East data center",
+			"status": "connected",
+			"engineCount": 3
+		  }
+		],
+		"limit": 1,
+		"offset": 0,
+		"totalCount": 1
+	  }`
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"engines", "remote-services", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:            "list remote services",
+			statusCode:      http.StatusOK,
+			body:            responseV4,
+			args:            []string{"engines", "remote-services"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*URL[\\s]*STATUS[\\s]*ENGINES[\\s]*DESCRIPTION[\\s]*east[\\s]*https://engines-east.example.com[\\s]*connected[\\s]*3[\\s]*East data center[\\s]*$",
+		},
+		{
+			name:           "list remote services json",
+			statusCode:     http.StatusOK,
+			body:           responseV4,
+			args:           []string{"engines", "remote-services", "--json"},
+			wantOutputJson: responseV4,
+		},
+		{
+			name:        "register missing url",
+			args:        []string{"engines", "remote-services", "register", "--name", "east"},
+			wantErrText: "required flag(s) \"url\" not set",
+		},
+		{
+			name:        "register invalid url",
+			args:        []string{"engines", "remote-services", "register", "--name", "east", "--url", "engines-east"},
+			wantErrText: "invalid url engines-east",
+		},
+		{
+			name:            "register",
+			statusCode:      http.StatusCreated,
+			args:            []string{"engines", "remote-services", "register", "--name", "east", "--url", "https://engines-east.example.com", "--token-stdin"},
+			stdin:           "abc123\n",
+			wantOutputRegex: "^Remote engine service successfully registered.\n$",
+			wantBodyJson:    `{"name":"east","url":"https://engines-east.example.com","token":"abc123"}`,
+		},
+		{
+			name:        "register already exists",
+			statusCode:  http.StatusConflict,
+			body:        `{"message": "A remote engine service with the name east already exists."}`,
+			args:        []string{"engines", "remote-services", "register", "--name", "east", "--url", "https://engines-east.example.com"},
+			wantErrText: "A remote engine service with the name east already exists.",
+		},
+		{
+			name:            "deregister",
+			statusCode:      http.StatusNoContent,
+			args:            []string{"engines", "remote-services", "deregister", "--name", "east", "-y"},
+			wantOutputRegex: "^Remote engine service successfully deregistered.\n$",
+			wantURLContains: "/fmeapiv4/remoteengineservices/east",
+		},
+		{
+			name:        "deregister not found",
+			statusCode:  http.StatusNotFound,
+			args:        []string{"engines", "remote-services", "deregister", "--name", "west", "-y"},
+			wantErrText: "404 Not Found",
+		},
+	}
+
+	runTests(cases, t)
+}
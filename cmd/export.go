@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type exportFlags struct {
+	kinds      []string
+	outputType string
+}
+
+// the kinds of items that can be exported to a manifest
+var manifestKinds = []string{"repositories", "connections", "deploymentparameters", "queues", "tokens"}
+
+// connection parameters with names containing any of these are treated as secrets
+var secretParameterNames = []string{"PASSWORD", "SECRET", "TOKEN", "API_KEY", "APIKEY"}
+
+// connection categories that have a password
+var passwordConnectionCategories = []string{"basic", "database"}
+
+var envNameRegexp = regexp.MustCompile(`[^A-Z0-9]+`)
+
+func newExportCmd() *cobra.Command {
+	f := exportFlags{}
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the configuration of FME Flow as a manifest.",
+		Long: `Export the repositories, connections, deployment parameters, queues and tokens on FME Flow as a manifest that can be used with "fmeflow apply" and "fmeflow diff".
+Fields generated by FME Flow such as owners, IDs and timestamps are left out. Secrets can't be exported, so connection passwords and connection parameters that look like secrets are replaced with references to environment variables such as ${env:WAREHOUSE_PASSWORD}. Set these environment variables before applying the manifest.`,
+		Example: `
+  # Export everything to a file
+  fmeflow export > state.yaml
+
+  # Export only the repositories, connections and deployment parameters
+  fmeflow export --kinds repositories,connections,deploymentparameters -o yaml > state.yaml
+
+  # Copy the repositories and connections from one FME Flow to another
+  fmeflow export --kinds repositories,connections | fmeflow apply -f - --config prod-config.yaml -y`,
+		Args: NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			for _, kind := range f.kinds {
+				if !slices.Contains(manifestKinds, kind) {
+					return fmt.Errorf("invalid kind %q. Must be one of %s", kind, strings.Join(manifestKinds, ", "))
+				}
+			}
+			return nil
+		},
+		RunE: exportRun(&f),
+	}
+
+	cmd.Flags().StringSliceVar(&f.kinds, "kinds", manifestKinds, "The kinds of items to export. Should be a comma separated list of repositories, connections, deploymentparameters, queues or tokens.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "yaml", "Specify the output type. Should be one of yaml or json")
+	cmd.RegisterFlagCompletionFunc("kinds", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return manifestKinds, cobra.ShellCompDirectiveDefault
+	})
+	return cmd
+}
+
+func exportRun(f *exportFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+		if f.outputType != "yaml" && f.outputType != "json" {
+			return errors.New("invalid output format specified")
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		manifest, err := exportManifest(client, f.kinds)
+		if err != nil {
+			return err
+		}
+
+		return writeManifest(cmd, manifest, f.outputType)
+	}
+}
+
+// write a manifest, or another document such as a schedules file, to stdout as yaml or json
+func writeManifest(cmd *cobra.Command, manifest interface{}, outputType string) error {
+	if outputType == "json" {
+		jsonData, err := json.Marshal(manifest)
+		if err != nil {
+			return err
+		}
+		prettyJSON, err := prettyPrintJSON(jsonData)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		return nil
+	}
+
+	encoder := yaml.NewEncoder(cmd.OutOrStdout())
+	encoder.SetIndent(2)
+	if err := encoder.Encode(manifest); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// build a manifest from the current state of FME Flow
+func exportManifest(client *http.Client, kinds []string) (FlowManifest, error) {
+	var manifest FlowManifest
+
+	if slices.Contains(kinds, "repositories") {
+		items, err := getAllItemsV4[FMEFlowRepositoryV4](client, "/fmeapiv4/repositories")
+		if err != nil {
+			return manifest, err
+		}
+		manifest.Repositories = []ManifestRepository{}
+		for _, item := range sortedByName(items, func(r FMEFlowRepositoryV4) string { return r.Name }) {
+			sharable := item.Sharable
+			manifest.Repositories = append(manifest.Repositories, ManifestRepository{Name: item.Name, Description: manifestOptionalString(item.Description), Sharable: &sharable})
+		}
+	}
+
+	if slices.Contains(kinds, "connections") {
+		connections, err := exportConnections(client, true)
+		if err != nil {
+			return manifest, err
+		}
+		manifest.Connections = connections
+	}
+
+	if slices.Contains(kinds, "deploymentparameters") {
+		parameters, err := exportDeploymentParameters(client)
+		if err != nil {
+			return manifest, err
+		}
+		manifest.DeploymentParameters = parameters
+	}
+
+	if slices.Contains(kinds, "queues") {
+		items, err := getAllItemsV4[FMEFlowQueueV4](client, "/fmeapiv4/queues")
+		if err != nil {
+			return manifest, err
+		}
+		manifest.Queues = []ManifestQueue{}
+		for _, item := range sortedByName(items, func(q FMEFlowQueueV4) string { return q.Name }) {
+			priority := item.Priority
+			manifest.Queues = append(manifest.Queues, ManifestQueue{Name: item.Name, Description: manifestOptionalString(item.Description), Priority: &priority, Rules: manifestQueueRules(item.Rules)})
+		}
+	}
+
+	if slices.Contains(kinds, "tokens") {
+		items, err := getAllItemsV4[FMEFlowTokenV4](client, "/fmeapiv4/tokens")
+		if err != nil {
+			return manifest, err
+		}
+		manifest.Tokens = []ManifestToken{}
+		for _, item := range sortedByName(items, func(t FMEFlowTokenV4) string { return t.Name }) {
+			enabled := item.Enabled
+			token := ManifestToken{Name: item.Name, Description: manifestOptionalString(item.Description), Enabled: &enabled}
+			if !item.ExpirationDate.IsZero() {
+				expirationDate := item.ExpirationDate.UTC()
+				token.ExpirationDate = &expirationDate
+			}
+			manifest.Tokens = append(manifest.Tokens, token)
+		}
+	}
+
+	return manifest, nil
+}
+
+// get the connections on FME Flow for a manifest. Secrets can't be read back, so if
+// secretReferences is set, passwords and secret parameters are replaced with references to
+// environment variables. Otherwise they are left out.
+func exportConnections(client *http.Client, secretReferences bool) ([]ManifestConnection, error) {
+	items, err := getAllItemsV4[Connection](client, "/fmeapiv4/connections")
+	if err != nil {
+		return nil, err
+	}
+	connections := []ManifestConnection{}
+	for _, item := range sortedByName(items, func(c Connection) string { return c.Name }) {
+		connection := ManifestConnection{Name: item.Name, Category: item.Category, Type: item.Type, Username: item.Username}
+		if secretReferences && slices.Contains(passwordConnectionCategories, item.Category) {
+			connection.Password = manifestEnvReference(item.Name, "PASSWORD")
+		}
+		if len(item.Parameters) != 0 {
+			connection.Parameters = map[string]interface{}{}
+			for name, value := range item.Parameters {
+				if isSecretParameterName(name) {
+					if !secretReferences {
+						continue
+					}
+					value = manifestEnvReference(item.Name, name)
+				}
+				connection.Parameters[name] = value
+			}
+		}
+		connections = append(connections, connection)
+	}
+	return connections, nil
+}
+
+// get the deployment parameters on FME Flow for a manifest
+func exportDeploymentParameters(client *http.Client) ([]ManifestDeploymentParameter, error) {
+	items, err := getAllItemsV4[DeploymentParameter](client, "/fmeapiv4/deploymentparameters")
+	if err != nil {
+		return nil, err
+	}
+	parameters := []ManifestDeploymentParameter{}
+	for _, item := range sortedByName(items, func(p DeploymentParameter) string { return p.Name }) {
+		parameter := ManifestDeploymentParameter{Name: item.Name, Type: deploymentParameterManifestType(item), Value: item.Value}
+		switch parameter.Type {
+		case "database":
+			parameter.DatabaseType = item.ChoiceSettings.Family
+		case "web":
+			parameter.IncludedServices = item.ChoiceSettings.Services
+			parameter.ExcludedServices = item.ChoiceSettings.ExcludedServices
+		}
+		parameters = append(parameters, parameter)
+	}
+	return parameters, nil
+}
+
+func isSecretParameterName(name string) bool {
+	name = strings.ToUpper(name)
+	for _, secret := range secretParameterNames {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// create a reference to an environment variable named after the parts, such as ${env:WAREHOUSE_PASSWORD}
+func manifestEnvReference(parts ...string) string {
+	name := envNameRegexp.ReplaceAllString(strings.ToUpper(strings.Join(parts, "_")), "_")
+	return "${env:" + strings.Trim(name, "_") + "}"
+}
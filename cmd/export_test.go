@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	repositoriesResponse := `{"items":[{"name":"Samples","description":"Sample workspaces","sharable":true},{"name":"Old","description":"","sharable":true}],"totalCount":2,"limit":100,"offset":0}`
+	connectionsResponse := `{"items":[{"name":"warehouse","category":"database","type":"PostgreSQL","username":"fme","parameters":{"HOST":"old.example.com","PORT":5432}}],"totalCount":1,"limit":100,"offset":0}`
+	deploymentParametersResponse := `{"items":[{"name":"WAREHOUSE_DB","type":"dropdown","value":"warehouse","choiceSettings":{"choiceSet":"dbConnections","family":"PostgreSQL"}},{"name":"OLD_PARAM","type":"text","value":"x"}],"totalCount":2,"limit":100,"offset":0}`
+	queuesResponse := `{"items":[{"name":"Default","description":"","priority":5},{"name":"Priority","description":"","priority":2,"rules":[{"repository":"Samples"}]}],"totalCount":2,"limit":100,"offset":0}`
+	tokensResponse := `{"items":[{"name":"ci","description":"CI","enabled":true,"expirationDate":"2030-01-01T00:00:00Z"}],"totalCount":1,"limit":100,"offset":0}`
+
+	// export only reads from FME Flow, so any other request returns a 404
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		responses := map[string]string{
+			"/fmeapiv4/repositories":         repositoriesResponse,
+			"/fmeapiv4/connections":          connectionsResponse,
+			"/fmeapiv4/deploymentparameters": deploymentParametersResponse,
+			"/fmeapiv4/queues":               queuesResponse,
+			"/fmeapiv4/tokens":               tokensResponse,
+		}
+		response, ok := responses[r.URL.Path]
+		if r.Method != "GET" || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(response))
+		require.NoError(t, err)
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"export", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "invalid kind",
+			args:        []string{"export", "--kinds", "repositories,workspaces"},
+			wantErrText: "invalid kind \"workspaces\". Must be one of repositories, connections, deploymentparameters, queues, tokens",
+		},
+		{
+			name:        "invalid output",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"export", "--output", "table"},
+			wantErrText: "invalid output format specified",
+		},
+		{
+			name:            "export repositories and connections",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"export", "--kinds", "repositories,connections"},
+			wantOutputRegex: "^repositories:\n  - name: Old\n    sharable: true\n  - name: Samples\n    description: Sample workspaces\n    sharable: true\nconnections:\n  - name: warehouse\n    category: database\n    type: PostgreSQL\n    username: fme\n    password: \\$\\{env:WAREHOUSE_PASSWORD\\}\n    parameters:\n      HOST: old.example.com\n      PORT: 5432\n$",
+		},
+		{
+			name:            "export deployment parameters",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"export", "--kinds", "deploymentparameters"},
+			wantOutputRegex: "^deploymentParameters:\n  - name: OLD_PARAM\n    type: text\n    value: x\n  - name: WAREHOUSE_DB\n    type: database\n    value: warehouse\n    databaseType: PostgreSQL\n$",
+		},
+		{
+			name:           "export queues and tokens json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"export", "--kinds", "queues,tokens", "--json"},
+			wantOutputJson: `{"queues":[{"name":"Default","priority":5},{"name":"Priority","priority":2,"rules":["Samples"]}],"tokens":[{"name":"ci","description":"CI","enabled":true,"expirationDate":"2030-01-01T00:00:00Z"}]}`,
+		},
+	}
+
+	runTests(cases, t)
+}
+
+func TestManifestEnvReference(t *testing.T) {
+	require.Equal(t, "${env:WAREHOUSE_PASSWORD}", manifestEnvReference("warehouse", "PASSWORD"))
+	require.Equal(t, "${env:MY_API_CONN_API_KEY}", manifestEnvReference("my-api conn", "api_key"))
+	require.True(t, isSecretParameterName("clientSecret"))
+	require.False(t, isSecretParameterName("HOST"))
+}
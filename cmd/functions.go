@@ -3,12 +3,15 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -36,9 +39,15 @@ var defaultStyle = table.Style{
 }
 
 func buildFmeFlowRequest(endpoint string, method string, body io.Reader) (http.Request, error) {
+	return buildFmeFlowRequestForConfig(viper.GetViper(), endpoint, method, body)
+}
+
+// build a request using the url and token from the given config instead of the global one.
+// This is used by commands that talk to more than one FME Flow.
+func buildFmeFlowRequestForConfig(config *viper.Viper, endpoint string, method string, body io.Reader) (http.Request, error) {
 	// retrieve url and token
-	fmeflowUrl := viper.GetString("url")
-	fmeflowToken := viper.GetString("token")
+	fmeflowUrl := config.GetString("url")
+	fmeflowToken := config.GetString("token")
 
 	req, err := http.NewRequest(method, fmeflowUrl+endpoint, body)
 	if fmeflowToken != "" {
@@ -205,3 +214,126 @@ func checkConfigFile(requireToken bool) error {
 	return nil
 
 }
+
+// attempt to parse the body of a failed response into JSON as there could be a valuable message in there.
+// if that fails, just return the status
+func parseResponseMessage(response *http.Response) error {
+	responseData, err := io.ReadAll(response.Body)
+	if err != nil {
+		return errors.New(response.Status)
+	}
+	var responseMessage Message
+	if err := json.Unmarshal(responseData, &responseMessage); err != nil || responseMessage.Message == "" {
+		return errors.New(response.Status)
+	}
+	return errors.New(responseMessage.Message)
+}
+
+// load a config file for a second FME Flow, such as one created with "fmeflow login --config other.yaml"
+func loadFmeFlowConfig(path string) (*viper.Viper, error) {
+	config := viper.New()
+	config.SetConfigFile(path)
+	if err := config.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("%w: could not read the config file %s. Have you called the login command? ", err, path)
+	}
+	if _, err := url.ParseRequestURI(config.GetString("url")); err != nil {
+		return nil, fmt.Errorf("invalid FME Flow url in config file %s. Have you called the login command? ", path)
+	}
+	if config.GetString("token") == "" {
+		return nil, fmt.Errorf("no token found in config file %s. Have you called the login command? ", path)
+	}
+	return config, nil
+}
+
+// get every item from a paged v4 list endpoint
+func getAllItemsV4[T any](client *http.Client, endpoint string) ([]T, error) {
+	items := []T{}
+	limit := 100
+	offset := 0
+
+	for {
+		request, err := buildFmeFlowRequest(endpoint, "GET", nil)
+		if err != nil {
+			return nil, err
+		}
+
+		q := request.URL.Query()
+		q.Add("limit", strconv.Itoa(limit))
+		q.Add("offset", strconv.Itoa(offset))
+		request.URL.RawQuery = q.Encode()
+
+		response, err := client.Do(&request)
+		if err != nil {
+			return nil, err
+		} else if response.StatusCode != http.StatusOK {
+			return nil, parseResponseMessage(response)
+		}
+
+		responseData, err := io.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Items      []T `json:"items"`
+			TotalCount int `json:"totalCount"`
+		}
+		if err := json.Unmarshal(responseData, &result); err != nil {
+			return nil, err
+		}
+		items = append(items, result.Items...)
+
+		if len(result.Items) < limit || offset+limit >= result.TotalCount {
+			break
+		}
+		offset += limit
+	}
+
+	return items, nil
+}
+
+// send a JSON body to FME Flow and check that the response has one of the expected status codes.
+// The response body is returned so that callers can read anything the server sends back
+func sendFmeFlowJSON(client *http.Client, endpoint string, method string, body interface{}, expectedStatus ...int) ([]byte, error) {
+	var requestBody io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		requestBody = bytes.NewBuffer(jsonData)
+	}
+
+	request, err := buildFmeFlowRequest(endpoint, method, requestBody)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		request.Header.Add("Content-Type", "application/json")
+	}
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if !slices.Contains(expectedStatus, response.StatusCode) {
+		return nil, parseResponseMessage(response)
+	}
+	return io.ReadAll(response.Body)
+}
+
+// check the output type before making any changes, for commands that only output their results once
+// the changes are done. The errors match the ones given when the output is printed
+func checkOutputType(outputType string) error {
+	if outputType == "table" || outputType == "json" {
+		return nil
+	} else if strings.HasPrefix(outputType, "custom-columns") {
+		if !strings.HasPrefix(outputType, "custom-columns=") || len(outputType) == len("custom-columns=") {
+			return errors.New("custom-columns format specified but no custom columns given")
+		}
+		return nil
+	}
+	return errors.New("invalid output format specified")
+}
@@ -112,7 +112,7 @@ type account struct {
 	Enabled        bool    `json:"enabled"`
 	SharingEnabled bool    `json:"sharingEnabled"`
 	Type           string  `json:"type"`
-	Password       *string `json:"password"`
+	Password       *string `json:"password,omitempty"`
 }
 
 type accountsResponse struct {
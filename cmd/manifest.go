@@ -0,0 +1,834 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// A manifest describes the desired configuration of an FME Flow. It is read by
+// apply and diff and written by export. Only the kinds present in a manifest are managed,
+// and properties left out of the manifest keep their current value on FME Flow.
+type FlowManifest struct {
+	Repositories         []ManifestRepository          `yaml:"repositories,omitempty" json:"repositories,omitempty"`
+	Connections          []ManifestConnection          `yaml:"connections,omitempty" json:"connections,omitempty"`
+	DeploymentParameters []ManifestDeploymentParameter `yaml:"deploymentParameters,omitempty" json:"deploymentParameters,omitempty"`
+	Queues               []ManifestQueue               `yaml:"queues,omitempty" json:"queues,omitempty"`
+	Tokens               []ManifestToken               `yaml:"tokens,omitempty" json:"tokens,omitempty"`
+}
+
+type ManifestRepository struct {
+	Name        string  `yaml:"name" json:"name"`
+	Description *string `yaml:"description,omitempty" json:"description,omitempty"`
+	Sharable    *bool   `yaml:"sharable,omitempty" json:"sharable,omitempty"`
+}
+
+type ManifestConnection struct {
+	Name                 string                 `yaml:"name" json:"name"`
+	Category             string                 `yaml:"category" json:"category"`
+	Type                 string                 `yaml:"type,omitempty" json:"type,omitempty"`
+	AuthenticationMethod string                 `yaml:"authenticationMethod,omitempty" json:"authenticationMethod,omitempty"`
+	Username             string                 `yaml:"username,omitempty" json:"username,omitempty"`
+	Password             string                 `yaml:"password,omitempty" json:"password,omitempty"`
+	Parameters           map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+}
+
+type ManifestDeploymentParameter struct {
+	Name             string   `yaml:"name" json:"name"`
+	Type             string   `yaml:"type,omitempty" json:"type,omitempty"`
+	Value            string   `yaml:"value" json:"value"`
+	DatabaseType     string   `yaml:"databaseType,omitempty" json:"databaseType,omitempty"`
+	IncludedServices []string `yaml:"includedServices,omitempty" json:"includedServices,omitempty"`
+	ExcludedServices []string `yaml:"excludedServices,omitempty" json:"excludedServices,omitempty"`
+}
+
+// Rules are given as REPOSITORY or REPOSITORY/WORKSPACE. If they are left out, the rules
+// of an existing queue are kept
+type ManifestQueue struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description *string  `yaml:"description,omitempty" json:"description,omitempty"`
+	Priority    *int     `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Rules       []string `yaml:"rules,omitempty" json:"rules,omitempty"`
+}
+
+type ManifestToken struct {
+	Name           string     `yaml:"name" json:"name"`
+	Description    *string    `yaml:"description,omitempty" json:"description,omitempty"`
+	Enabled        *bool      `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	ExpirationDate *time.Time `yaml:"expirationDate,omitempty" json:"expirationDate,omitempty"`
+}
+
+type FMEFlowTokenV4 struct {
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	Enabled        bool      `json:"enabled"`
+	ExpirationDate time.Time `json:"expirationDate"`
+}
+
+type NewTokenV4 struct {
+	Name           string     `json:"name,omitempty"`
+	Description    string     `json:"description"`
+	Enabled        bool       `json:"enabled"`
+	ExpirationDate *time.Time `json:"expirationDate,omitempty"`
+}
+
+// a single change that needs to be made to bring FME Flow in line with a manifest
+type ManifestChange struct {
+	Kind    string   `json:"kind"`
+	Name    string   `json:"name"`
+	Action  string   `json:"action"`
+	Changes []string `json:"changes"`
+	Token   string   `json:"token,omitempty"`
+	apply   func(client *http.Client, change *ManifestChange) error
+	// why the change is unsafe, such as a deleted deployment parameter that workspaces still use
+	unsafe string
+}
+
+const manifestActionReplace = "replace"
+
+// the queue every FME Flow has, which can't be removed
+const defaultQueueName = "Default"
+
+// environment variable references that are substituted when a manifest is loaded
+var manifestEnvRegexp = regexp.MustCompile(`\$\{env:([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// read a manifest from a file, or from stdin if the path is "-"
+func loadManifest(cmd *cobra.Command, path string) (FlowManifest, error) {
+	var manifest FlowManifest
+
+	document, err := readManifestDocument(cmd, path)
+	if err != nil {
+		return manifest, err
+	}
+	if err := document.Decode(&manifest); err != nil {
+		return manifest, fmt.Errorf("could not parse manifest %s: %w", path, err)
+	}
+
+	return manifest, manifest.validate()
+}
+
+// read a manifest from a file, or from stdin if the path is "-", and substitute any environment
+// variable references without decoding it
+func readManifestDocument(cmd *cobra.Command, path string) (*yaml.Node, error) {
+	var contents []byte
+	var err error
+	if path == "-" {
+		contents, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		contents, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(contents, &document); err != nil {
+		return nil, fmt.Errorf("could not parse manifest %s: %w", path, err)
+	}
+	if err := expandManifestEnv(&document); err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+// replace ${env:NAME} references in every string value with the value of the environment variable
+func expandManifestEnv(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var missing []string
+		node.Value = manifestEnvRegexp.ReplaceAllStringFunc(node.Value, func(reference string) string {
+			name := manifestEnvRegexp.FindStringSubmatch(reference)[1]
+			value, found := os.LookupEnv(name)
+			if !found {
+				missing = append(missing, name)
+			}
+			return value
+		})
+		if len(missing) != 0 {
+			return fmt.Errorf("environment variable %s referenced in the manifest is not set", missing[0])
+		}
+	}
+	for _, child := range node.Content {
+		if err := expandManifestEnv(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m FlowManifest) validate() error {
+	names := map[string]map[string]bool{}
+	checkName := func(kind string, name string) error {
+		if name == "" {
+			return fmt.Errorf("every %s in the manifest must have a name", kind)
+		}
+		if names[kind] == nil {
+			names[kind] = map[string]bool{}
+		}
+		if names[kind][name] {
+			return fmt.Errorf("%s %s is specified more than once in the manifest", kind, name)
+		}
+		names[kind][name] = true
+		return nil
+	}
+
+	for _, repository := range m.Repositories {
+		if err := checkName("repository", repository.Name); err != nil {
+			return err
+		}
+	}
+	for _, connection := range m.Connections {
+		if err := checkName("connection", connection.Name); err != nil {
+			return err
+		}
+		if connection.Category == "" {
+			return fmt.Errorf("connection %s in the manifest must have a category", connection.Name)
+		}
+	}
+	for _, parameter := range m.DeploymentParameters {
+		if err := checkName("deployment parameter", parameter.Name); err != nil {
+			return err
+		}
+		if parameter.Type != "" && parameter.Type != "text" && parameter.Type != "database" && parameter.Type != "web" {
+			return fmt.Errorf("invalid type %q for deployment parameter %s. Must be one of text, database or web", parameter.Type, parameter.Name)
+		}
+	}
+	for _, queue := range m.Queues {
+		if err := checkName("queue", queue.Name); err != nil {
+			return err
+		}
+		if _, err := parseManifestQueueRules(queue); err != nil {
+			return err
+		}
+	}
+	for _, token := range m.Tokens {
+		if err := checkName("token", token.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compare the manifest against FME Flow and work out what needs to change. If prune is set,
+// items of a kind in the manifest that exist on FME Flow but not in the manifest are deleted.
+// Tokens are only deleted if pruneTokens is set, as FME Flow doesn't say which token fmeflow is using.
+func planManifest(client *http.Client, manifest FlowManifest, prune bool, pruneTokens bool) ([]ManifestChange, error) {
+	plan := []ManifestChange{}
+
+	if manifest.Repositories != nil {
+		changes, err := planRepositories(client, manifest.Repositories, prune)
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, changes...)
+	}
+	if manifest.Connections != nil {
+		changes, err := planConnections(client, manifest.Connections, prune)
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, changes...)
+	}
+	if manifest.DeploymentParameters != nil {
+		changes, err := planDeploymentParameters(client, manifest.DeploymentParameters, prune)
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, changes...)
+	}
+	if manifest.Queues != nil {
+		changes, err := planQueues(client, manifest.Queues, prune)
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, changes...)
+	}
+	if manifest.Tokens != nil {
+		changes, err := planTokens(client, manifest.Tokens, pruneTokens)
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, changes...)
+	}
+
+	return plan, nil
+}
+
+// make the changes in a plan. Items are created and updated in the order of the plan so that
+// connections exist before the deployment parameters that use them. Deletes are done last, in reverse.
+func applyManifestPlan(client *http.Client, plan []ManifestChange) error {
+	for i := range plan {
+		if plan[i].Action != syncActionDelete {
+			if err := plan[i].apply(client, &plan[i]); err != nil {
+				return fmt.Errorf("failed to %s %s %s: %w", plan[i].Action, plan[i].Kind, plan[i].Name, err)
+			}
+		}
+	}
+	for i := len(plan) - 1; i >= 0; i-- {
+		if plan[i].Action == syncActionDelete {
+			if err := plan[i].apply(client, &plan[i]); err != nil {
+				return fmt.Errorf("failed to %s %s %s: %w", plan[i].Action, plan[i].Kind, plan[i].Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func printManifestPlan(cmd *cobra.Command, plan []ManifestChange, outputType string, noHeaders bool) error {
+	if outputType == "table" {
+
+		t := table.NewWriter()
+		t.SetStyle(defaultStyle)
+
+		t.AppendHeader(table.Row{"Kind", "Name", "Action", "Changes"})
+
+		for _, element := range plan {
+			t.AppendRow(table.Row{element.Kind, element.Name, element.Action, strings.Join(element.Changes, ", ")})
+		}
+		if noHeaders {
+			t.ResetHeaders()
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+	} else if outputType == "json" {
+		jsonData, err := json.Marshal(plan)
+		if err != nil {
+			return err
+		}
+		prettyJSON, err := prettyPrintJSON(jsonData)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+	} else if strings.HasPrefix(outputType, "custom-columns") {
+		// parse the columns and json queries
+		columnsString := ""
+		if strings.HasPrefix(outputType, "custom-columns=") {
+			columnsString = outputType[len("custom-columns="):]
+		}
+		if len(columnsString) == 0 {
+			return errors.New("custom-columns format specified but no custom columns given")
+		}
+
+		// we have to marshal the Items array, then create an array of marshalled items
+		// to pass to the creation of the table.
+		marshalledItems := [][]byte{}
+		for _, element := range plan {
+			mJson, err := json.Marshal(element)
+			if err != nil {
+				return err
+			}
+			marshalledItems = append(marshalledItems, mJson)
+		}
+
+		columnsInput := strings.Split(columnsString, ",")
+		t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+		if err != nil {
+			return err
+		}
+		if noHeaders {
+			t.ResetHeaders()
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+	} else {
+		return errors.New("invalid output format specified")
+	}
+	return nil
+}
+
+// returns a delete change for each existing item that isn't wanted
+func planPrune(kind string, endpoint string, existing []string, wanted map[string]bool) []ManifestChange {
+	plan := []ManifestChange{}
+	sort.Strings(existing)
+	for _, name := range existing {
+		if wanted[name] {
+			continue
+		}
+		name := name
+		plan = append(plan, ManifestChange{Kind: kind, Name: name, Action: syncActionDelete, Changes: []string{}, apply: func(client *http.Client, change *ManifestChange) error {
+			_, err := sendFmeFlowJSON(client, endpoint+"/"+name, "DELETE", nil, http.StatusNoContent)
+			return err
+		}})
+	}
+	return plan
+}
+
+func planRepositories(client *http.Client, repositories []ManifestRepository, prune bool) ([]ManifestChange, error) {
+	items, err := getAllItemsV4[FMEFlowRepositoryV4](client, "/fmeapiv4/repositories")
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]FMEFlowRepositoryV4{}
+	for _, item := range items {
+		existing[item.Name] = item
+	}
+
+	plan := []ManifestChange{}
+	wanted := map[string]bool{}
+	for _, repository := range sortedByName(repositories, func(r ManifestRepository) string { return r.Name }) {
+		repository := repository
+		wanted[repository.Name] = true
+		current, exists := existing[repository.Name]
+		if !exists {
+			description := manifestStringValue(repository.Description, "")
+			plan = append(plan, ManifestChange{Kind: "repository", Name: repository.Name, Action: syncActionCreate, Changes: []string{}, apply: func(client *http.Client, change *ManifestChange) error {
+				if _, err := sendFmeFlowJSON(client, "/fmeapiv4/repositories", "POST", NewRepository{Name: repository.Name, Description: description}, http.StatusCreated); err != nil {
+					return err
+				}
+				if repository.Sharable != nil {
+					_, err := sendFmeFlowJSON(client, "/fmeapiv4/repositories/"+repository.Name, "PUT", UpdateRepository{Description: description, Sharable: *repository.Sharable}, http.StatusNoContent)
+					return err
+				}
+				return nil
+			}})
+			continue
+		}
+
+		changes := []string{}
+		description := manifestStringValue(repository.Description, current.Description)
+		if current.Description != description {
+			changes = append(changes, "description")
+		}
+		sharable := current.Sharable
+		if repository.Sharable != nil && *repository.Sharable != current.Sharable {
+			changes = append(changes, "sharable")
+			sharable = *repository.Sharable
+		}
+		if len(changes) != 0 {
+			plan = append(plan, ManifestChange{Kind: "repository", Name: repository.Name, Action: syncActionUpdate, Changes: changes, apply: func(client *http.Client, change *ManifestChange) error {
+				_, err := sendFmeFlowJSON(client, "/fmeapiv4/repositories/"+repository.Name, "PUT", UpdateRepository{Description: description, Sharable: sharable}, http.StatusNoContent)
+				return err
+			}})
+		}
+	}
+
+	if prune {
+		plan = append(plan, planPrune("repository", "/fmeapiv4/repositories", mapKeys(existing), wanted)...)
+	}
+	return plan, nil
+}
+
+func planConnections(client *http.Client, connections []ManifestConnection, prune bool) ([]ManifestChange, error) {
+	items, err := getAllItemsV4[Connection](client, "/fmeapiv4/connections")
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]Connection{}
+	for _, item := range items {
+		existing[item.Name] = item
+	}
+
+	plan := []ManifestChange{}
+	wanted := map[string]bool{}
+	for _, connection := range sortedByName(connections, func(c ManifestConnection) string { return c.Name }) {
+		connection := connection
+		wanted[connection.Name] = true
+		newConnection := NewConnection{
+			Category:             connection.Category,
+			Name:                 connection.Name,
+			Type:                 connection.Type,
+			AuthenticationMethod: connection.AuthenticationMethod,
+			Username:             connection.Username,
+			Password:             connection.Password,
+			Parameters:           connection.Parameters,
+		}
+		create := func(client *http.Client, change *ManifestChange) error {
+			_, err := sendFmeFlowJSON(client, "/fmeapiv4/connections", "POST", newConnection, http.StatusCreated)
+			return err
+		}
+
+		current, exists := existing[connection.Name]
+		if !exists {
+			plan = append(plan, ManifestChange{Kind: "connection", Name: connection.Name, Action: syncActionCreate, Changes: []string{}, apply: create})
+			continue
+		}
+
+		// the category and type can't be updated, so the connection has to be recreated
+		changes := []string{}
+		if current.Category != connection.Category {
+			changes = append(changes, "category")
+		}
+		if connection.Type != "" && current.Type != connection.Type {
+			changes = append(changes, "type")
+		}
+		if len(changes) != 0 {
+			plan = append(plan, ManifestChange{Kind: "connection", Name: connection.Name, Action: manifestActionReplace, Changes: changes, apply: func(client *http.Client, change *ManifestChange) error {
+				if _, err := sendFmeFlowJSON(client, "/fmeapiv4/connections/"+connection.Name, "DELETE", nil, http.StatusNoContent); err != nil {
+					return err
+				}
+				return create(client, change)
+			}})
+			continue
+		}
+
+		// passwords can't be read back from FME Flow so only the username and parameters are compared.
+		// Credentials left out of the manifest aren't sent, so the ones on FME Flow are kept
+		if connection.Username != "" && current.Username != connection.Username {
+			changes = append(changes, "username")
+		}
+		parameters := map[string]interface{}{}
+		for name, value := range current.Parameters {
+			parameters[name] = value
+		}
+		for _, name := range sortedKeys(connection.Parameters) {
+			currentValue, found := current.Parameters[name]
+			if !found || fmt.Sprint(currentValue) != fmt.Sprint(connection.Parameters[name]) {
+				changes = append(changes, "parameters."+name)
+			}
+			parameters[name] = connection.Parameters[name]
+		}
+		if len(changes) != 0 {
+			update := UpdateConnection{
+				Category:             current.Category,
+				AuthenticationMethod: connection.AuthenticationMethod,
+				Username:             connection.Username,
+				Password:             connection.Password,
+				Parameters:           parameters,
+			}
+			plan = append(plan, ManifestChange{Kind: "connection", Name: connection.Name, Action: syncActionUpdate, Changes: changes, apply: func(client *http.Client, change *ManifestChange) error {
+				_, err := sendFmeFlowJSON(client, "/fmeapiv4/connections/"+connection.Name, "PUT", update, http.StatusNoContent)
+				return err
+			}})
+		}
+	}
+
+	if prune {
+		plan = append(plan, planPrune("connection", "/fmeapiv4/connections", mapKeys(existing), wanted)...)
+	}
+	return plan, nil
+}
+
+func planDeploymentParameters(client *http.Client, parameters []ManifestDeploymentParameter, prune bool) ([]ManifestChange, error) {
+	items, err := getAllItemsV4[DeploymentParameter](client, "/fmeapiv4/deploymentparameters")
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]DeploymentParameter{}
+	for _, item := range items {
+		existing[item.Name] = item
+	}
+
+	plan := []ManifestChange{}
+	wanted := map[string]bool{}
+	for _, parameter := range sortedByName(parameters, func(p ManifestDeploymentParameter) string { return p.Name }) {
+		parameter := parameter
+		wanted[parameter.Name] = true
+		if parameter.Type == "" {
+			parameter.Type = "text"
+		}
+
+		// build the settings for the type of parameter the same way the create and update commands do
+		update := UpdateDeploymentParameter{Value: parameter.Value, ChoiceSettings: new(ChoiceSettings)}
+		switch parameter.Type {
+		case "database":
+			update.Type = "dropdown"
+			update.ChoiceSettings.ChoiceSet = "dbConnections"
+			update.ChoiceSettings.Family = parameter.DatabaseType
+		case "web":
+			update.Type = "dropdown"
+			update.ChoiceSettings.ChoiceSet = "webConnections"
+			update.ChoiceSettings.Services = parameter.IncludedServices
+			update.ChoiceSettings.ExcludedServices = parameter.ExcludedServices
+		default:
+			update.Type = parameter.Type
+			update.ChoiceSettings = nil
+		}
+
+		current, exists := existing[parameter.Name]
+		if !exists {
+			var newParameter NewDeploymentParameter
+			newParameter.Name = parameter.Name
+			newParameter.Type = update.Type
+			newParameter.Value = parameter.Value
+			if update.ChoiceSettings != nil {
+				newParameter.ChoiceSettings.ChoiceSet = update.ChoiceSettings.ChoiceSet
+				newParameter.ChoiceSettings.Family = update.ChoiceSettings.Family
+				newParameter.ChoiceSettings.Services = update.ChoiceSettings.Services
+				newParameter.ChoiceSettings.ExcludedServices = update.ChoiceSettings.ExcludedServices
+			}
+			plan = append(plan, ManifestChange{Kind: "deploymentparameter", Name: parameter.Name, Action: syncActionCreate, Changes: []string{}, apply: func(client *http.Client, change *ManifestChange) error {
+				_, err := sendFmeFlowJSON(client, "/fmeapiv4/deploymentparameters", "POST", newParameter, http.StatusCreated)
+				return err
+			}})
+			continue
+		}
+
+		changes := []string{}
+		if deploymentParameterManifestType(current) != parameter.Type {
+			changes = append(changes, "type")
+		}
+		if current.Value != parameter.Value {
+			changes = append(changes, "value")
+		}
+		if parameter.Type == "database" && current.ChoiceSettings.Family != parameter.DatabaseType {
+			changes = append(changes, "databaseType")
+		}
+		if parameter.Type == "web" && !slices.Equal(current.ChoiceSettings.Services, parameter.IncludedServices) {
+			changes = append(changes, "includedServices")
+		}
+		if parameter.Type == "web" && !slices.Equal(current.ChoiceSettings.ExcludedServices, parameter.ExcludedServices) {
+			changes = append(changes, "excludedServices")
+		}
+		if len(changes) != 0 {
+			plan = append(plan, ManifestChange{Kind: "deploymentparameter", Name: parameter.Name, Action: syncActionUpdate, Changes: changes, apply: func(client *http.Client, change *ManifestChange) error {
+				_, err := sendFmeFlowJSON(client, "/fmeapiv4/deploymentparameters/"+parameter.Name, "PUT", update, http.StatusNoContent)
+				return err
+			}})
+		}
+	}
+
+	if prune {
+		// deleting a deployment parameter breaks the workspaces that use it, the same as the delete command
+		for _, change := range planPrune("deploymentparameter", "/fmeapiv4/deploymentparameters", mapKeys(existing), wanted) {
+			usages, err := findDeploymentParameterUsages(client, change.Name, "")
+			if err != nil {
+				return nil, err
+			}
+			if len(usages) != 0 {
+				change.Changes = append(change.Changes, "used by "+formatDeploymentParameterUsages(usages))
+				change.unsafe = fmt.Sprintf("deployment parameter %s is used by %s", change.Name, formatDeploymentParameterUsages(usages))
+			}
+			plan = append(plan, change)
+		}
+	}
+	return plan, nil
+}
+
+// deployment parameters are stored as text or dropdowns. Convert back to the type used by the create command
+func deploymentParameterManifestType(parameter DeploymentParameter) string {
+	if parameter.Type == "dropdown" {
+		switch parameter.ChoiceSettings.ChoiceSet {
+		case "dbConnections":
+			return "database"
+		case "webConnections":
+			return "web"
+		}
+	}
+	return parameter.Type
+}
+
+func planQueues(client *http.Client, queues []ManifestQueue, prune bool) ([]ManifestChange, error) {
+	items, err := getAllItemsV4[FMEFlowQueueV4](client, "/fmeapiv4/queues")
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]FMEFlowQueueV4{}
+	for _, item := range items {
+		existing[item.Name] = item
+	}
+
+	plan := []ManifestChange{}
+	// the default queue can never be pruned
+	wanted := map[string]bool{defaultQueueName: true}
+	for _, queue := range sortedByName(queues, func(q ManifestQueue) string { return q.Name }) {
+		queue := queue
+		wanted[queue.Name] = true
+		rules, err := parseManifestQueueRules(queue)
+		if err != nil {
+			return nil, err
+		}
+
+		current, exists := existing[queue.Name]
+		if !exists {
+			newQueue := NewQueueV4{Name: queue.Name, Description: manifestStringValue(queue.Description, ""), Rules: rules}
+			if queue.Priority != nil {
+				newQueue.Priority = *queue.Priority
+			}
+			plan = append(plan, ManifestChange{Kind: "queue", Name: queue.Name, Action: syncActionCreate, Changes: []string{}, apply: func(client *http.Client, change *ManifestChange) error {
+				_, err := sendFmeFlowJSON(client, "/fmeapiv4/queues", "POST", newQueue, http.StatusCreated)
+				return err
+			}})
+			continue
+		}
+
+		// the update replaces every property of the queue, so anything not in the manifest is carried over
+		update := UpdateQueueV4{Description: manifestStringValue(queue.Description, current.Description), Priority: current.Priority, Rules: current.Rules}
+		if update.Rules == nil {
+			update.Rules = []QueueRuleV4{}
+		}
+		changes := []string{}
+		if current.Description != update.Description {
+			changes = append(changes, "description")
+		}
+		if queue.Priority != nil && current.Priority != *queue.Priority {
+			changes = append(changes, "priority")
+			update.Priority = *queue.Priority
+		}
+		if rules != nil {
+			if formatQueueRules(sortedQueueRules(current.Rules)) != formatQueueRules(sortedQueueRules(rules)) {
+				changes = append(changes, "rules")
+			}
+			update.Rules = rules
+		}
+		if len(changes) != 0 {
+			plan = append(plan, ManifestChange{Kind: "queue", Name: queue.Name, Action: syncActionUpdate, Changes: changes, apply: func(client *http.Client, change *ManifestChange) error {
+				_, err := sendFmeFlowJSON(client, "/fmeapiv4/queues/"+queue.Name, "PUT", update, http.StatusOK, http.StatusNoContent)
+				return err
+			}})
+		}
+	}
+
+	if prune {
+		plan = append(plan, planPrune("queue", "/fmeapiv4/queues", mapKeys(existing), wanted)...)
+	}
+	return plan, nil
+}
+
+// parse the routing rules of a queue in the manifest. Nil is returned if the manifest doesn't list any
+func parseManifestQueueRules(queue ManifestQueue) ([]QueueRuleV4, error) {
+	if queue.Rules == nil {
+		return nil, nil
+	}
+	rules := []QueueRuleV4{}
+	for _, rule := range queue.Rules {
+		parsed, err := parseQueueRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("queue %s in the manifest has an %w", queue.Name, err)
+		}
+		rules = append(rules, parsed)
+	}
+	return rules, nil
+}
+
+// the rules of a queue in the form they are written in a manifest
+func manifestQueueRules(rules []QueueRuleV4) []string {
+	formatted := []string{}
+	for _, rule := range sortedQueueRules(rules) {
+		if rule.Workspace == "" {
+			formatted = append(formatted, rule.Repository)
+		} else {
+			formatted = append(formatted, rule.Repository+"/"+rule.Workspace)
+		}
+	}
+	return formatted
+}
+
+// sort rules by repository, then by workspace, so that they can be compared
+func sortedQueueRules(rules []QueueRuleV4) []QueueRuleV4 {
+	sorted := slices.Clone(rules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Repository != sorted[j].Repository {
+			return sorted[i].Repository < sorted[j].Repository
+		}
+		return sorted[i].Workspace < sorted[j].Workspace
+	})
+	return sorted
+}
+
+func planTokens(client *http.Client, tokens []ManifestToken, prune bool) ([]ManifestChange, error) {
+	items, err := getAllItemsV4[FMEFlowTokenV4](client, "/fmeapiv4/tokens")
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]FMEFlowTokenV4{}
+	for _, item := range items {
+		existing[item.Name] = item
+	}
+
+	plan := []ManifestChange{}
+	wanted := map[string]bool{}
+	for _, token := range sortedByName(tokens, func(t ManifestToken) string { return t.Name }) {
+		token := token
+		wanted[token.Name] = true
+		newToken := NewTokenV4{Name: token.Name, Description: manifestStringValue(token.Description, ""), Enabled: true, ExpirationDate: token.ExpirationDate}
+		if token.Enabled != nil {
+			newToken.Enabled = *token.Enabled
+		}
+
+		current, exists := existing[token.Name]
+		if !exists {
+			plan = append(plan, ManifestChange{Kind: "token", Name: token.Name, Action: syncActionCreate, Changes: []string{}, apply: func(client *http.Client, change *ManifestChange) error {
+				responseData, err := sendFmeFlowJSON(client, "/fmeapiv4/tokens", "POST", newToken, http.StatusCreated)
+				if err != nil {
+					return err
+				}
+				// this is the only time the token is available, so keep it to show to the user
+				var result struct {
+					Token string `json:"token"`
+				}
+				if err := json.Unmarshal(responseData, &result); err == nil {
+					change.Token = result.Token
+				}
+				return nil
+			}})
+			continue
+		}
+
+		changes := []string{}
+		newToken.Description = manifestStringValue(token.Description, current.Description)
+		if current.Description != newToken.Description {
+			changes = append(changes, "description")
+		}
+		if token.Enabled != nil && current.Enabled != *token.Enabled {
+			changes = append(changes, "enabled")
+		} else {
+			newToken.Enabled = current.Enabled
+		}
+		if token.ExpirationDate != nil && !current.ExpirationDate.Equal(*token.ExpirationDate) {
+			changes = append(changes, "expirationDate")
+		}
+		if len(changes) != 0 {
+			newToken.Name = ""
+			plan = append(plan, ManifestChange{Kind: "token", Name: token.Name, Action: syncActionUpdate, Changes: changes, apply: func(client *http.Client, change *ManifestChange) error {
+				_, err := sendFmeFlowJSON(client, "/fmeapiv4/tokens/"+token.Name, "PUT", newToken, http.StatusNoContent)
+				return err
+			}})
+		}
+	}
+
+	if prune {
+		plan = append(plan, planPrune("token", "/fmeapiv4/tokens", mapKeys(existing), wanted)...)
+	}
+	return plan, nil
+}
+
+// the value of an optional string in the manifest, or the current value if the manifest leaves it out
+func manifestStringValue(value *string, current string) string {
+	if value == nil {
+		return current
+	}
+	return *value
+}
+
+// a pointer to the value for an optional string in a manifest, or nil if it is empty so that it is left out
+func manifestOptionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// return a copy of the items sorted by name so that plans are always in the same order
+func sortedByName[T any](items []T, name func(T) string) []T {
+	sorted := slices.Clone(items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return name(sorted[i]) < name(sorted[j])
+	})
+	return sorted
+}
+
+func mapKeys[T any](m map[string]T) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := mapKeys(m)
+	sort.Strings(keys)
+	return keys
+}
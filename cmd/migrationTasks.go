@@ -490,3 +490,67 @@ func migrationTasksRun(f *migrationTasksFlags) func(cmd *cobra.Command, args []s
 		return nil
 	}
 }
+
+// how often to check on a migration task while waiting for it to finish. Tests set this lower
+var migrationTaskWaitInterval = 2 * time.Second
+
+// the status and result of a migration task, which are the same in v3 and v4
+type migrationTaskStatus struct {
+	Status string `json:"status"`
+	Result string `json:"result"`
+}
+
+// check on a migration task until it finishes or the timeout is reached, reporting each change in
+// its status. An error is returned if the task doesn't succeed
+func waitForMigrationTask(client *http.Client, apiVersion apiVersionFlag, id int, timeout time.Duration, out io.Writer) error {
+	endpoint := "/fmeapiv4/migrations/tasks/" + strconv.Itoa(id)
+	if apiVersion == apiVersionFlagV3 {
+		endpoint = "/fmerest/v3/migration/tasks/id/" + strconv.Itoa(id)
+	}
+
+	deadline := time.Now().Add(timeout)
+	lastStatus := ""
+	for {
+		request, err := buildFmeFlowRequest(endpoint, "GET", nil)
+		if err != nil {
+			return err
+		}
+		response, err := client.Do(&request)
+		if err != nil {
+			return err
+		} else if response.StatusCode != http.StatusOK {
+			return parseResponseMessage(response)
+		}
+		responseData, err := io.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			return err
+		}
+		var task migrationTaskStatus
+		if err := json.Unmarshal(responseData, &task); err != nil {
+			return err
+		}
+
+		status := strings.ToUpper(task.Status)
+		if status != lastStatus {
+			fmt.Fprintf(out, "Migration task %d is %s\n", id, status)
+			lastStatus = status
+		}
+		if status == "SUCCESS" {
+			return nil
+		}
+		for _, failed := range []string{"FAIL", "ERROR", "ABORT", "CANCEL"} {
+			if strings.Contains(status, failed) {
+				message := fmt.Sprintf("migration task %d finished with status %s", id, status)
+				if task.Result != "" {
+					message += ": " + task.Result
+				}
+				return fmt.Errorf("%s. Use \"fmeflow migration tasks --id %d --log\" to see the log", message, id)
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for migration task %d. Use \"fmeflow migration tasks --id %d\" to check on it", timeout, id, id)
+		}
+		time.Sleep(migrationTaskWaitInterval)
+	}
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+type notificationsPublishFlags struct {
+	topic   string
+	message string
+}
+
+func newNotificationsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notifications",
+		Short: "Manage the notification service",
+		Long: `Manage the topics, subscriptions and publications of the FME Flow notification service.
+Topics carry messages from publishers, such as jobs with --success-topic or --failure-topic, to subscribers. Subscriptions send the messages on topics to a service such as email, and publications receive messages from a service and send them to topics.`,
+		Example: `
+  # List all topics
+  fmeflow notifications topics
+
+  # Email the ops team when a job fails
+  fmeflow notifications topics create --name JOB_FAILED
+  fmeflow notifications subscriptions create --name ops-email --service Email --topic JOB_FAILED --property email_to=ops@example.com
+  fmeflow run --repository Samples --workspace austinApartments.fmw --failure-topic JOB_FAILED
+
+  # Send a test message to a topic
+  fmeflow notifications publish --topic JOB_FAILED --message @message.json`,
+		Args: NoArgs,
+	}
+	cmd.AddCommand(newTopicsCmd())
+	cmd.AddCommand(newNotificationServicesCmd(subscriptionKind))
+	cmd.AddCommand(newNotificationServicesCmd(publicationKind))
+	cmd.AddCommand(newNotificationsPublishCmd())
+	return cmd
+}
+
+func newNotificationsPublishCmd() *cobra.Command {
+	f := notificationsPublishFlags{}
+	cmd := &cobra.Command{
+		Use:   "publish",
+		Short: "Publish a message to a topic",
+		Long:  `Publish a message to a topic so that it is sent to every subscription on the topic. This is useful for testing subscriptions. The message can be given directly or read from a file by prefixing the path with @.`,
+		Example: `
+  # Publish the json message in message.json to the topic JOB_FAILED
+  fmeflow notifications publish --topic JOB_FAILED --message @message.json
+
+  # Publish a text message
+  fmeflow notifications publish --topic JOB_FAILED --message "Test message"`,
+		Args: NoArgs,
+		RunE: notificationsPublishRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.topic, "topic", "", "Name of the topic to publish to.")
+	cmd.Flags().StringVar(&f.message, "message", "", "The message to publish. Prefix with @ to read the message from a file, or use @- to read it from stdin.")
+	cmd.MarkFlagRequired("topic")
+	cmd.MarkFlagRequired("message")
+	return cmd
+}
+
+func notificationsPublishRun(f *notificationsPublishFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		message := []byte(f.message)
+		if path, ok := strings.CutPrefix(f.message, "@"); ok {
+			var err error
+			if path == "-" {
+				message, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				message, err = os.ReadFile(path)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		// json messages are sent as they are, anything else is sent as a json string
+		var body interface{} = json.RawMessage(message)
+		if !json.Valid(message) {
+			body = string(message)
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		if err := validateTopics(client, []string{f.topic}); err != nil {
+			return err
+		}
+
+		if _, err := sendFmeFlowJSON(client, "/fmeapiv4/topics/"+url.PathEscape(f.topic)+"/message", "POST", body, http.StatusOK, http.StatusAccepted, http.StatusNoContent); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Message successfully published to "+f.topic+".")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,437 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// subscriptions and publications are managed the same way, they just differ in
+// the direction messages flow between the topics and the service
+type notificationKind struct {
+	singular string
+	plural   string
+	endpoint string
+	example  string
+}
+
+var subscriptionKind = notificationKind{
+	singular: "subscription",
+	plural:   "subscriptions",
+	endpoint: "/fmeapiv4/subscriptions",
+	example:  "--name ops-email --service Email --topic JOB_FAILED --property email_to=ops@example.com",
+}
+
+var publicationKind = notificationKind{
+	singular: "publication",
+	plural:   "publications",
+	endpoint: "/fmeapiv4/publications",
+	example:  "--name incoming-email --service Email --topic EMAIL_RECEIVED --property email_publisher_address=flow@example.com",
+}
+
+type NotificationServiceV4 struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Enabled     bool                   `json:"enabled"`
+	Owner       string                 `json:"owner,omitempty"`
+	Service     string                 `json:"service"`
+	Topics      []string               `json:"topics"`
+	Properties  map[string]interface{} `json:"properties"`
+}
+
+type NotificationServicesV4 struct {
+	Offset     int                     `json:"offset"`
+	Limit      int                     `json:"limit"`
+	TotalCount int                     `json:"totalCount"`
+	Items      []NotificationServiceV4 `json:"items"`
+}
+
+type notificationServicesFlags struct {
+	outputType string
+	noHeaders  bool
+}
+
+type notificationServiceFlags struct {
+	name             string
+	description      string
+	service          string
+	topics           []string
+	addTopics        []string
+	removeTopics     []string
+	properties       []string
+	removeProperties []string
+	disabled         bool
+	enabled          bool
+	noprompt         bool
+}
+
+func newNotificationServicesCmd(kind notificationKind) *cobra.Command {
+	f := notificationServicesFlags{}
+	cmd := &cobra.Command{
+		Use:   kind.plural,
+		Short: "List, create, update and delete " + kind.plural,
+		Long:  "Lists the notification " + kind.plural + " on FME Flow. Use the subcommands to create, update and delete " + kind.plural + ".",
+		Example: `
+  # List all ` + kind.plural + `
+  fmeflow notifications ` + kind.plural + `
+
+  # List the ` + kind.plural + ` in json
+  fmeflow notifications ` + kind.plural + ` --json`,
+		Args: NoArgs,
+		RunE: notificationServicesRun(kind, &f),
+	}
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.AddCommand(newNotificationServiceCreateCmd(kind))
+	cmd.AddCommand(newNotificationServiceUpdateCmd(kind))
+	cmd.AddCommand(newNotificationServiceDeleteCmd(kind))
+	return cmd
+}
+
+func newNotificationServiceCreateCmd(kind notificationKind) *cobra.Command {
+	f := notificationServiceFlags{}
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a " + kind.singular,
+		Long:  "Create a notification " + kind.singular + ". The properties that can be set depend on the service. All of the topics must already exist.",
+		Example: `
+  # Create a ` + kind.singular + `
+  fmeflow notifications ` + kind.plural + ` create ` + kind.example,
+		Args: NoArgs,
+		RunE: notificationServiceCreateRun(kind, &f),
+	}
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the "+kind.singular+" to create.")
+	cmd.Flags().StringVar(&f.description, "description", "", "Description of the "+kind.singular+".")
+	cmd.Flags().StringVar(&f.service, "service", "", "The service the "+kind.singular+" uses, such as Email.")
+	cmd.Flags().StringArrayVar(&f.topics, "topic", []string{}, "Topic of the "+kind.singular+". Can be passed in multiple times.")
+	cmd.Flags().StringArrayVar(&f.properties, "property", []string{}, "A property of the service in the form KEY=VALUE. Can be passed in multiple times.")
+	cmd.Flags().BoolVar(&f.disabled, "disabled", false, "Create the "+kind.singular+" disabled.")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("service")
+	cmd.MarkFlagRequired("topic")
+	return cmd
+}
+
+func newNotificationServiceUpdateCmd(kind notificationKind) *cobra.Command {
+	f := notificationServiceFlags{}
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update a " + kind.singular,
+		Long:  "Update the description, topics, properties or state of a notification " + kind.singular + ". Properties that aren't specified are left unchanged.",
+		Example: `
+  # Add a topic to a ` + kind.singular + `
+  fmeflow notifications ` + kind.plural + ` update --name my-` + kind.singular + ` --add-topic JOB_SUCCEEDED
+
+  # Disable a ` + kind.singular + `
+  fmeflow notifications ` + kind.plural + ` update --name my-` + kind.singular + ` --enabled=false`,
+		Args: NoArgs,
+		RunE: notificationServiceUpdateRun(kind, &f),
+	}
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the "+kind.singular+" to update.")
+	cmd.Flags().StringVar(&f.description, "description", "", "Description of the "+kind.singular+".")
+	cmd.Flags().StringArrayVar(&f.addTopics, "add-topic", []string{}, "Topic to add to the "+kind.singular+". Can be passed in multiple times.")
+	cmd.Flags().StringArrayVar(&f.removeTopics, "remove-topic", []string{}, "Topic to remove from the "+kind.singular+". Can be passed in multiple times.")
+	cmd.Flags().StringArrayVar(&f.properties, "property", []string{}, "A property of the service to set in the form KEY=VALUE. Can be passed in multiple times.")
+	cmd.Flags().StringArrayVar(&f.removeProperties, "remove-property", []string{}, "A property of the service to remove. Can be passed in multiple times.")
+	cmd.Flags().BoolVar(&f.enabled, "enabled", true, "Whether the "+kind.singular+" is enabled.")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagsOneRequired("description", "add-topic", "remove-topic", "property", "remove-property", "enabled")
+	return cmd
+}
+
+func newNotificationServiceDeleteCmd(kind notificationKind) *cobra.Command {
+	f := notificationServiceFlags{}
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a " + kind.singular,
+		Long:  "Delete a notification " + kind.singular + ".",
+		Example: `
+  # Delete a ` + kind.singular + `
+  fmeflow notifications ` + kind.plural + ` delete --name my-` + kind.singular + `
+
+  # Delete a ` + kind.singular + ` with no confirmation
+  fmeflow notifications ` + kind.plural + ` delete --name my-` + kind.singular + ` --no-prompt`,
+		Args: NoArgs,
+		RunE: notificationServiceDeleteRun(kind, &f),
+	}
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the "+kind.singular+" to delete.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func notificationServicesRun(kind notificationKind, f *notificationServicesFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		items, err := getAllItemsV4[NotificationServiceV4](client, kind.endpoint)
+		if err != nil {
+			return err
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Name", "Service", "Topics", "Enabled", "Description"})
+
+			for _, element := range items {
+				t.AppendRow(table.Row{element.Name, element.Service, strings.Join(element.Topics, ", "), element.Enabled, element.Description})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			responseData, err := json.Marshal(NotificationServicesV4{Items: items, TotalCount: len(items), Limit: len(items)})
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			marshalledItems := [][]byte{}
+			for _, element := range items {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+func notificationServiceCreateRun(kind notificationKind, f *notificationServiceFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		properties, err := parseNotificationProperties(f.properties)
+		if err != nil {
+			return err
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		if err := validateTopics(client, f.topics); err != nil {
+			return err
+		}
+
+		item := NotificationServiceV4{
+			Name:        f.name,
+			Description: f.description,
+			Enabled:     !f.disabled,
+			Service:     f.service,
+			Topics:      f.topics,
+			Properties:  properties,
+		}
+		if _, err := sendFmeFlowJSON(client, kind.endpoint, "POST", item, http.StatusCreated); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), notificationKindTitle(kind)+" successfully created.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
+
+func notificationServiceUpdateRun(kind notificationKind, f *notificationServiceFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		properties, err := parseNotificationProperties(f.properties)
+		if err != nil {
+			return err
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		item, err := getNotificationServiceV4(client, kind, f.name)
+		if err != nil {
+			return err
+		}
+
+		if err := validateTopics(client, f.addTopics); err != nil {
+			return err
+		}
+
+		if cmd.Flags().Changed("description") {
+			item.Description = f.description
+		}
+		if cmd.Flags().Changed("enabled") {
+			item.Enabled = f.enabled
+		}
+		for _, topic := range f.removeTopics {
+			index := -1
+			for i, existing := range item.Topics {
+				if existing == topic {
+					index = i
+				}
+			}
+			if index == -1 {
+				return fmt.Errorf("%s %s does not have the topic %s", kind.singular, f.name, topic)
+			}
+			item.Topics = append(item.Topics[:index], item.Topics[index+1:]...)
+		}
+		for _, topic := range f.addTopics {
+			exists := false
+			for _, existing := range item.Topics {
+				exists = exists || existing == topic
+			}
+			if !exists {
+				item.Topics = append(item.Topics, topic)
+			}
+		}
+		if len(item.Topics) == 0 {
+			return fmt.Errorf("a %s must have at least one topic", kind.singular)
+		}
+		if item.Properties == nil {
+			item.Properties = map[string]interface{}{}
+		}
+		for _, name := range f.removeProperties {
+			if _, ok := item.Properties[name]; !ok {
+				return fmt.Errorf("%s %s does not have the property %s", kind.singular, f.name, name)
+			}
+			delete(item.Properties, name)
+		}
+		for name, value := range properties {
+			item.Properties[name] = value
+		}
+
+		// the owner is set by FME Flow and can't be updated
+		item.Owner = ""
+		if _, err := sendFmeFlowJSON(client, kind.endpoint+"/"+url.PathEscape(f.name), "PUT", item, http.StatusOK, http.StatusNoContent); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), notificationKindTitle(kind)+" successfully updated.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
+
+func notificationServiceDeleteRun(kind notificationKind, f *notificationServiceFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		if _, err := getNotificationServiceV4(client, kind, f.name); err != nil {
+			return err
+		}
+
+		if !f.noprompt {
+			// prompt to confirm deletion
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: "Are you sure you want to delete the " + kind.singular + " " + f.name + "?",
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		if _, err := sendFmeFlowJSON(client, kind.endpoint+"/"+url.PathEscape(f.name), "DELETE", nil, http.StatusNoContent, http.StatusOK); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), notificationKindTitle(kind)+" successfully deleted.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
+
+// get a single subscription or publication by name
+func getNotificationServiceV4(client *http.Client, kind notificationKind, name string) (NotificationServiceV4, error) {
+	var result NotificationServiceV4
+
+	request, err := buildFmeFlowRequest(kind.endpoint+"/"+url.PathEscape(name), "GET", nil)
+	if err != nil {
+		return result, err
+	}
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return result, err
+	} else if response.StatusCode != http.StatusOK {
+		if response.StatusCode == http.StatusNotFound {
+			return result, fmt.Errorf("%w: check that the %s %s exists", errors.New(response.Status), kind.singular, name)
+		}
+		return result, parseResponseMessage(response)
+	}
+
+	responseData, err := io.ReadAll(response.Body)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(responseData, &result)
+	return result, err
+}
+
+// parse KEY=VALUE properties into a map
+func parseNotificationProperties(properties []string) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	for _, property := range properties {
+		name, value, found := strings.Cut(property, "=")
+		if !found || name == "" {
+			return result, fmt.Errorf("invalid property %q. Must be in the form KEY=VALUE", property)
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+func notificationKindTitle(kind notificationKind) string {
+	return strings.ToUpper(kind.singular[:1]) + kind.singular[1:]
+}
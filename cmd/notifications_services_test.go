@@ -0,0 +1,328 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationServices(t *testing.T) {
+	subscriptionsListBody := `{
+	  "items": [
+	    {
+	      "name": "ops-email",
+	      "description": "Email the ops team",
+	      "enabled": true,
+	      "owner": "admin",
+	      "service": "Email",
+	      "topics": [
+	        "JOB_FAILED"
+	      ],
+	      "properties": {
+	        "email_to": "ops@example.com"
+	      }
+	    }
+	  ],
+	  "totalCount": 1,
+	  "limit": 1,
+	  "offset": 0
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && (r.URL.Path == "/fmeapiv4/subscriptions" || r.URL.Path == "/fmeapiv4/publications") {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(subscriptionsListBody))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"notifications", "subscriptions", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"notifications", "publications"},
+		},
+		{
+			name:            "list subscriptions",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"notifications", "subscriptions"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*SERVICE[\\s]*TOPICS[\\s]*ENABLED[\\s]*DESCRIPTION[\\s]*ops-email[\\s]*Email[\\s]*JOB_FAILED[\\s]*true[\\s]*Email the ops team[\\s]*$",
+		},
+		{
+			name:           "list publications json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"notifications", "publications", "--json"},
+			wantOutputJson: subscriptionsListBody,
+		},
+		{
+			name:            "list subscriptions custom columns",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"notifications", "subscriptions", "--output", "custom-columns=NAME:.name,EMAIL:.properties.email_to", "--no-headers"},
+			wantOutputRegex: "^[\\s]*ops-email[\\s]*ops@example.com[\\s]*$",
+		},
+	}
+
+	runTests(cases, t)
+}
+
+func TestNotificationServicesCreate(t *testing.T) {
+	topicsListBody := `{
+	  "items": [
+	    {
+	      "name": "JOB_FAILED",
+	      "description": "Jobs that failed"
+	    },
+	    {
+	      "name": "JOB_SUCCEEDED",
+	      "description": ""
+	    }
+	  ],
+	  "totalCount": 2,
+	  "limit": 2,
+	  "offset": 0
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/topics" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(topicsListBody))
+			require.NoError(t, err)
+		} else if r.Method == "POST" && (r.URL.Path == "/fmeapiv4/subscriptions" || r.URL.Path == "/fmeapiv4/publications") {
+			w.WriteHeader(http.StatusCreated)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// creates an enabled subscription
+	customHttpServerHandlerSubscription := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/subscriptions" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"ops-email","description":"","enabled":true,"service":"Email","topics":["JOB_FAILED"],"properties":{"email_to":"ops@example.com"}}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// creates a disabled publication
+	customHttpServerHandlerPublication := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/publications" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"incoming","description":"Incoming email","enabled":false,"service":"Email","topics":["JOB_FAILED","JOB_SUCCEEDED"],"properties":{}}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	cases := []testCase{
+		{
+			name:        "missing flags",
+			args:        []string{"notifications", "subscriptions", "create", "--name", "ops-email"},
+			wantErrText: "required flag(s) \"service\", \"topic\" not set",
+		},
+		{
+			name:        "invalid property",
+			args:        []string{"notifications", "subscriptions", "create", "--name", "ops-email", "--service", "Email", "--topic", "JOB_FAILED", "--property", "email_to"},
+			wantErrText: "invalid property \"email_to\". Must be in the form KEY=VALUE",
+		},
+		{
+			name:        "topic does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"notifications", "subscriptions", "create", "--name", "ops-email", "--service", "Email", "--topic", "JOB_FAILED", "--topic", "JOB_FALED"},
+			wantErrText: "topic JOB_FALED does not exist. Use \"fmeflow notifications topics create\" to create it",
+		},
+		{
+			name:            "create subscription",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerSubscription)),
+			args:            []string{"notifications", "subscriptions", "create", "--name", "ops-email", "--service", "Email", "--topic", "JOB_FAILED", "--property", "email_to=ops@example.com"},
+			wantOutputRegex: "^Subscription successfully created.\n$",
+		},
+		{
+			name:           "create disabled publication",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandlerPublication)),
+			args:           []string{"notifications", "publications", "create", "--name", "incoming", "--description", "Incoming email", "--service", "Email", "--topic", "JOB_FAILED", "--topic", "JOB_SUCCEEDED", "--disabled", "--json"},
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+}
+
+func TestNotificationServicesUpdate(t *testing.T) {
+	topicsListBody := `{
+	  "items": [
+	    {
+	      "name": "JOB_FAILED",
+	      "description": "Jobs that failed"
+	    },
+	    {
+	      "name": "JOB_SUCCEEDED",
+	      "description": ""
+	    }
+	  ],
+	  "totalCount": 2,
+	  "limit": 2,
+	  "offset": 0
+	}`
+
+	subscriptionBody := `{
+	  "name": "ops-email",
+	  "description": "Email the ops team",
+	  "enabled": true,
+	  "owner": "admin",
+	  "service": "Email",
+	  "topics": [
+	    "JOB_FAILED"
+	  ],
+	  "properties": {
+	    "email_to": "ops@example.com"
+	  }
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/topics" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(topicsListBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && (r.URL.Path == "/fmeapiv4/subscriptions/ops-email" || r.URL.Path == "/fmeapiv4/publications/ops-email") {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(subscriptionBody))
+			require.NoError(t, err)
+		} else if r.Method == "PUT" && (r.URL.Path == "/fmeapiv4/subscriptions/ops-email" || r.URL.Path == "/fmeapiv4/publications/ops-email") {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// adds a topic and sets a property on the subscription
+	customHttpServerHandlerSubscription := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/subscriptions/ops-email" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"ops-email","description":"Email the ops team","enabled":true,"service":"Email","topics":["JOB_FAILED","JOB_SUCCEEDED"],"properties":{"email_to":"team@example.com"}}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// disables the publication and removes its property
+	customHttpServerHandlerPublication := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/publications/ops-email" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"ops-email","description":"Email the ops team","enabled":false,"service":"Email","topics":["JOB_FAILED"],"properties":{}}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	cases := []testCase{
+		{
+			name:        "missing name",
+			args:        []string{"notifications", "subscriptions", "update", "--description", "test"},
+			wantErrText: "required flag(s) \"name\" not set",
+		},
+		{
+			name:        "nothing to update",
+			args:        []string{"notifications", "subscriptions", "update", "--name", "ops-email"},
+			wantErrText: "at least one of the flags in the group [description add-topic remove-topic property remove-property enabled] is required",
+		},
+		{
+			name:        "subscription does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"notifications", "subscriptions", "update", "--name", "missing", "--enabled=false"},
+			wantErrText: "404 Not Found: check that the subscription missing exists",
+		},
+		{
+			name:            "add topic and set property",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerSubscription)),
+			args:            []string{"notifications", "subscriptions", "update", "--name", "ops-email", "--add-topic", "JOB_SUCCEEDED", "--property", "email_to=team@example.com"},
+			wantOutputRegex: "^Subscription successfully updated.\n$",
+		},
+		{
+			name:        "remove the only topic",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"notifications", "subscriptions", "update", "--name", "ops-email", "--remove-topic", "JOB_FAILED"},
+			wantErrText: "a subscription must have at least one topic",
+		},
+		{
+			name:        "remove topic not on subscription",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"notifications", "subscriptions", "update", "--name", "ops-email", "--remove-topic", "JOB_SUCCEEDED"},
+			wantErrText: "subscription ops-email does not have the topic JOB_SUCCEEDED",
+		},
+		{
+			name:           "disable publication and remove property",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandlerPublication)),
+			args:           []string{"notifications", "publications", "update", "--name", "ops-email", "--enabled=false", "--remove-property", "email_to", "--json"},
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+}
+
+func TestNotificationServicesDelete(t *testing.T) {
+	subscriptionBody := `{
+	  "name": "ops-email",
+	  "description": "Email the ops team",
+	  "enabled": true,
+	  "owner": "admin",
+	  "service": "Email",
+	  "topics": [
+	    "JOB_FAILED"
+	  ],
+	  "properties": {
+	    "email_to": "ops@example.com"
+	  }
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && (r.URL.Path == "/fmeapiv4/subscriptions/ops-email" || r.URL.Path == "/fmeapiv4/publications/ops-email") {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(subscriptionBody))
+			require.NoError(t, err)
+		} else if r.Method == "DELETE" && (r.URL.Path == "/fmeapiv4/subscriptions/ops-email" || r.URL.Path == "/fmeapiv4/publications/ops-email") {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:        "missing flag",
+			args:        []string{"notifications", "publications", "delete"},
+			wantErrText: "required flag(s) \"name\" not set",
+		},
+		{
+			name:        "publication does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"notifications", "publications", "delete", "--name", "missing", "-y"},
+			wantErrText: "404 Not Found: check that the publication missing exists",
+		},
+		{
+			name:            "delete subscription",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"notifications", "subscriptions", "delete", "--name", "ops-email", "-y"},
+			wantOutputRegex: "^Subscription successfully deleted.\n$",
+		},
+	}
+
+	runTests(cases, t)
+}
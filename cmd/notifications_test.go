@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationsPublish(t *testing.T) {
+	dir := t.TempDir()
+	messageFile := filepath.Join(dir, "message.json")
+	require.NoError(t, os.WriteFile(messageFile, []byte(`{"subject": "Test", "body": "Test message"}`), 0644))
+	missingFile := filepath.Join(dir, "missing.json")
+
+	topicsListBody := `{
+	  "items": [
+	    {
+	      "name": "JOB_FAILED",
+	      "description": "Jobs that failed"
+	    },
+	    {
+	      "name": "JOB_SUCCEEDED",
+	      "description": ""
+	    }
+	  ],
+	  "totalCount": 2,
+	  "limit": 2,
+	  "offset": 0
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/topics" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(topicsListBody))
+			require.NoError(t, err)
+		} else if r.Method == "POST" && r.URL.Path == "/fmeapiv4/topics/JOB_FAILED/message" {
+			w.WriteHeader(http.StatusAccepted)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// the message is read from a json file
+	customHttpServerHandlerFile := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/topics/JOB_FAILED/message" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"subject": "Test", "body": "Test message"}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// a plain text message is sent as a json string
+	customHttpServerHandlerText := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/topics/JOB_FAILED/message" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `"Test message"`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"notifications", "publish", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flags",
+			args:        []string{"notifications", "publish"},
+			wantErrText: "required flag(s) \"message\", \"topic\" not set",
+		},
+		{
+			name:        "topic does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"notifications", "publish", "--topic", "MISSING", "--message", "hello"},
+			wantErrText: "topic MISSING does not exist. Use \"fmeflow notifications topics create\" to create it",
+		},
+		{
+			name:            "publish message from file",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerFile)),
+			args:            []string{"notifications", "publish", "--topic", "JOB_FAILED", "--message", "@" + messageFile},
+			wantOutputRegex: "^Message successfully published to JOB_FAILED.\n$",
+		},
+		{
+			name:           "publish text message from stdin",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandlerText)),
+			args:           []string{"notifications", "publish", "--topic", "JOB_FAILED", "--message", "@-", "--json"},
+			stdin:          "Test message",
+			wantOutputJson: "{}",
+		},
+		{
+			name:        "message file does not exist",
+			args:        []string{"notifications", "publish", "--topic", "JOB_FAILED", "--message", "@" + missingFile},
+			wantErrText: "open " + missingFile + ": no such file or directory",
+		},
+	}
+
+	runTests(cases, t)
+}
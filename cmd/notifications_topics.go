@@ -0,0 +1,301 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type TopicV4 struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type TopicsV4 struct {
+	Offset     int       `json:"offset"`
+	Limit      int       `json:"limit"`
+	TotalCount int       `json:"totalCount"`
+	Items      []TopicV4 `json:"items"`
+}
+
+type topicsFlags struct {
+	outputType string
+	noHeaders  bool
+}
+
+type topicFlags struct {
+	name        string
+	description string
+	noprompt    bool
+}
+
+func newTopicsCmd() *cobra.Command {
+	f := topicsFlags{}
+	cmd := &cobra.Command{
+		Use:   "topics",
+		Short: "List, create, update and delete topics",
+		Long:  `Lists the notification topics on FME Flow. Use the subcommands to create, update and delete topics.`,
+		Example: `
+  # List all topics
+  fmeflow notifications topics
+
+  # Output just the names of the topics
+  fmeflow notifications topics --output=custom-columns=NAME:.name --no-headers`,
+		Args: NoArgs,
+		RunE: topicsRun(&f),
+	}
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.AddCommand(newTopicCreateCmd())
+	cmd.AddCommand(newTopicUpdateCmd())
+	cmd.AddCommand(newTopicDeleteCmd())
+	return cmd
+}
+
+func newTopicCreateCmd() *cobra.Command {
+	f := topicFlags{}
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a topic",
+		Long:  `Create a notification topic.`,
+		Example: `
+  # Create a topic named JOB_FAILED
+  fmeflow notifications topics create --name JOB_FAILED --description "Jobs that failed"`,
+		Args: NoArgs,
+		RunE: topicSaveRun(true, &f),
+	}
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the topic to create.")
+	cmd.Flags().StringVar(&f.description, "description", "", "Description of the topic.")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func newTopicUpdateCmd() *cobra.Command {
+	f := topicFlags{}
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update a topic",
+		Long:  `Update the description of a notification topic.`,
+		Example: `
+  # Update the description of the topic JOB_FAILED
+  fmeflow notifications topics update --name JOB_FAILED --description "Failed jobs"`,
+		Args: NoArgs,
+		RunE: topicSaveRun(false, &f),
+	}
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the topic to update.")
+	cmd.Flags().StringVar(&f.description, "description", "", "Description of the topic.")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("description")
+	return cmd
+}
+
+func newTopicDeleteCmd() *cobra.Command {
+	f := topicFlags{}
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a topic",
+		Long:  `Delete a notification topic. Subscriptions and publications that use the topic no longer send or receive messages on it.`,
+		Example: `
+  # Delete the topic JOB_FAILED
+  fmeflow notifications topics delete --name JOB_FAILED
+
+  # Delete the topic JOB_FAILED with no confirmation
+  fmeflow notifications topics delete --name JOB_FAILED --no-prompt`,
+		Args: NoArgs,
+		RunE: topicDeleteRun(&f),
+	}
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the topic to delete.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func topicsRun(f *topicsFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		topics, err := getAllItemsV4[TopicV4](client, "/fmeapiv4/topics")
+		if err != nil {
+			return err
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Name", "Description"})
+
+			for _, element := range topics {
+				t.AppendRow(table.Row{element.Name, element.Description})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			responseData, err := json.Marshal(TopicsV4{Items: topics, TotalCount: len(topics), Limit: len(topics)})
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			marshalledItems := [][]byte{}
+			for _, element := range topics {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+func topicSaveRun(create bool, f *topicFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		var err error
+		if create {
+			_, err = sendFmeFlowJSON(client, "/fmeapiv4/topics", "POST", TopicV4{Name: f.name, Description: f.description}, http.StatusCreated)
+		} else {
+			_, err = sendFmeFlowJSON(client, "/fmeapiv4/topics/"+url.PathEscape(f.name), "PUT", TopicV4{Name: f.name, Description: f.description}, http.StatusOK, http.StatusNoContent)
+		}
+		if err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			if create {
+				fmt.Fprintln(cmd.OutOrStdout(), "Topic successfully created.")
+			} else {
+				fmt.Fprintln(cmd.OutOrStdout(), "Topic successfully updated.")
+			}
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
+
+func topicDeleteRun(f *topicFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		if err := validateTopics(client, []string{f.name}); err != nil {
+			return err
+		}
+
+		if !f.noprompt {
+			// prompt to confirm deletion
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: "Are you sure you want to delete the topic " + f.name + "?",
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		if _, err := sendFmeFlowJSON(client, "/fmeapiv4/topics/"+url.PathEscape(f.name), "DELETE", nil, http.StatusNoContent, http.StatusOK); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Topic successfully deleted.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
+
+// check that every topic exists on FME Flow
+func validateTopics(client *http.Client, topics []string) error {
+	if len(topics) == 0 {
+		return nil
+	}
+	items, err := getAllItemsV4[TopicV4](client, "/fmeapiv4/topics")
+	if err != nil {
+		return fmt.Errorf("could not check the topics: %w", err)
+	}
+	return checkTopicsExist(items, topics)
+}
+
+// check that every topic exists on a version of FME Flow that only has the v3 API
+func validateTopicsV3(client *http.Client, topics []string) error {
+	if len(topics) == 0 {
+		return nil
+	}
+	responseData, err := sendFmeFlowJSON(client, "/fmerest/v3/notifications/topics", "GET", nil, http.StatusOK)
+	if err != nil {
+		return fmt.Errorf("could not check the topics: %w", err)
+	}
+	var items []TopicV4
+	if err := json.Unmarshal(responseData, &items); err != nil {
+		return fmt.Errorf("could not check the topics: %w", err)
+	}
+	return checkTopicsExist(items, topics)
+}
+
+func checkTopicsExist(items []TopicV4, topics []string) error {
+	missing := []string{}
+	for _, topic := range topics {
+		if !slices.ContainsFunc(items, func(t TopicV4) bool { return t.Name == topic }) && !slices.Contains(missing, topic) {
+			missing = append(missing, topic)
+		}
+	}
+	if len(missing) == 1 {
+		return fmt.Errorf("topic %s does not exist. Use \"fmeflow notifications topics create\" to create it", missing[0])
+	} else if len(missing) > 1 {
+		return fmt.Errorf("topics %s do not exist. Use \"fmeflow notifications topics create\" to create them", strings.Join(missing, ", "))
+	}
+	return nil
+}
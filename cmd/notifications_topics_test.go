@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopics(t *testing.T) {
+	topicsListBody := `{
+	  "items": [
+	    {
+	      "name": "JOB_FAILED",
+	      "description": "Jobs that failed"
+	    },
+	    {
+	      "name": "JOB_SUCCEEDED",
+	      "description": ""
+	    }
+	  ],
+	  "totalCount": 2,
+	  "limit": 2,
+	  "offset": 0
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/topics" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(topicsListBody))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"notifications", "topics", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"notifications", "topics"},
+		},
+		{
+			name:            "list topics",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"notifications", "topics"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*DESCRIPTION[\\s]*JOB_FAILED[\\s]*Jobs that failed[\\s]*JOB_SUCCEEDED[\\s]*$",
+		},
+		{
+			name:           "list topics json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"notifications", "topics", "--json"},
+			wantOutputJson: topicsListBody,
+		},
+		{
+			name:            "list topics custom columns",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"notifications", "topics", "--output", "custom-columns=NAME:.name", "--no-headers"},
+			wantOutputRegex: "^[\\s]*JOB_FAILED[\\s]*JOB_SUCCEEDED[\\s]*$",
+		},
+		{
+			name:        "invalid output",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"notifications", "topics", "--output", "xml"},
+			wantErrText: "invalid output format specified",
+		},
+	}
+
+	runTests(cases, t)
+}
+
+func TestTopicsCreateUpdateDelete(t *testing.T) {
+	topicsListBody := `{
+	  "items": [
+	    {
+	      "name": "JOB_FAILED",
+	      "description": "Jobs that failed"
+	    },
+	    {
+	      "name": "JOB_SUCCEEDED",
+	      "description": ""
+	    }
+	  ],
+	  "totalCount": 2,
+	  "limit": 2,
+	  "offset": 0
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/topics" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(topicsListBody))
+			require.NoError(t, err)
+		} else if r.Method == "POST" && r.URL.Path == "/fmeapiv4/topics" {
+			w.WriteHeader(http.StatusCreated)
+		} else if r.URL.Path == "/fmeapiv4/topics/JOB_FAILED" && (r.Method == "PUT" || r.Method == "DELETE") {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// creates a new topic
+	customHttpServerHandlerCreate := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/topics" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"JOB_STARTED","description":"Jobs that started"}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// updates the description of an existing topic
+	customHttpServerHandlerUpdate := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/topics/JOB_FAILED" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"JOB_FAILED","description":"Failed jobs"}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	cases := []testCase{
+		{
+			name:        "create missing flag",
+			args:        []string{"notifications", "topics", "create"},
+			wantErrText: "required flag(s) \"name\" not set",
+		},
+		{
+			name:            "create topic",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerCreate)),
+			args:            []string{"notifications", "topics", "create", "--name", "JOB_STARTED", "--description", "Jobs that started"},
+			wantOutputRegex: "^Topic successfully created.\n$",
+		},
+		{
+			name:        "create topic that already exists",
+			statusCode:  http.StatusConflict,
+			body:        `{"message":"A topic with the name JOB_FAILED already exists."}`,
+			args:        []string{"notifications", "topics", "create", "--name", "JOB_FAILED"},
+			wantErrText: "A topic with the name JOB_FAILED already exists.",
+		},
+		{
+			name:        "update missing flag",
+			args:        []string{"notifications", "topics", "update", "--name", "JOB_FAILED"},
+			wantErrText: "required flag(s) \"description\" not set",
+		},
+		{
+			name:           "update topic",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandlerUpdate)),
+			args:           []string{"notifications", "topics", "update", "--name", "JOB_FAILED", "--description", "Failed jobs", "--json"},
+			wantOutputJson: "{}",
+		},
+		{
+			name:        "delete topic that does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"notifications", "topics", "delete", "--name", "MISSING", "-y"},
+			wantErrText: "topic MISSING does not exist. Use \"fmeflow notifications topics create\" to create it",
+		},
+		{
+			name:            "delete topic",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"notifications", "topics", "delete", "--name", "JOB_FAILED", "-y"},
+			wantOutputRegex: "^Topic successfully deleted.\n$",
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,439 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// an item on FME Flow that belongs to a user
+type OwnedItem struct {
+	Kind  string `json:"kind"`
+	Name  string `json:"name"`
+	Owner string `json:"owner"`
+
+	endpoint string
+}
+
+type OwnershipChangeV4 struct {
+	OwnerID string `json:"ownerID"`
+}
+
+type ownershipListFlags struct {
+	user       string
+	orphaned   bool
+	kinds      []string
+	outputType string
+	noHeaders  bool
+}
+
+// the kinds of item whose ownership can be listed and transferred
+var ownershipKinds = []string{"repositories", "schedules", "automations", "connections"}
+
+func newOwnershipCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ownership",
+		Short: "List and transfer the items users own",
+		Long: `List the repositories, schedules, automations and connections that a user owns, and transfer them to another user.
+This is useful when someone leaves: transfer everything they own to another user before disabling or deleting their account. Items left behind by accounts that have already been deleted or disabled can be found with "fmeflow ownership list --orphaned".`,
+		Example: `
+  # List everything the user "jsmith" owns
+  fmeflow ownership list --user jsmith
+
+  # Show what would be transferred from "jsmith" to "bjones" without changing anything
+  fmeflow ownership transfer --from jsmith --to bjones --dry-run
+
+  # Transfer the repositories and schedules of "jsmith" to "bjones"
+  fmeflow ownership transfer --from jsmith --to bjones --kinds repositories,schedules`,
+		Args: NoArgs,
+	}
+	cmd.AddCommand(newOwnershipListCmd())
+	cmd.AddCommand(newOwnershipTransferCmd())
+	return cmd
+}
+
+func newOwnershipListCmd() *cobra.Command {
+	f := ownershipListFlags{}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the items a user owns",
+		Long:  `List the repositories, schedules, automations and connections a user owns. Use --orphaned instead to list the items whose owner has been deleted or disabled.`,
+		Example: `
+  # List everything the user "jsmith" owns
+  fmeflow ownership list --user jsmith
+
+  # List the repositories and connections the user "jsmith" owns
+  fmeflow ownership list --user jsmith --kinds repositories,connections
+
+  # List the items owned by deleted or disabled accounts
+  fmeflow ownership list --orphaned`,
+		Args: NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateOwnershipKinds(f.kinds)
+		},
+		RunE: ownershipListRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.user, "user", "", "Name of the user whose items to list.")
+	cmd.Flags().BoolVar(&f.orphaned, "orphaned", false, "List the items whose owner has been deleted or disabled.")
+	addOwnershipKindsFlag(cmd, &f.kinds)
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.MarkFlagsOneRequired("user", "orphaned")
+	cmd.MarkFlagsMutuallyExclusive("user", "orphaned")
+	return cmd
+}
+
+func addOwnershipKindsFlag(cmd *cobra.Command, kinds *[]string) {
+	cmd.Flags().StringSliceVar(kinds, "kinds", ownershipKinds, "The kinds of item to include. Should be a comma separated list of "+strings.Join(ownershipKinds, ", ")+".")
+	cmd.RegisterFlagCompletionFunc("kinds", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return ownershipKinds, cobra.ShellCompDirectiveDefault
+	})
+}
+
+func validateOwnershipKinds(kinds []string) error {
+	for _, kind := range kinds {
+		if !slices.Contains(ownershipKinds, kind) {
+			return fmt.Errorf("invalid kind %q. Must be one of %s", kind, strings.Join(ownershipKinds, ", "))
+		}
+	}
+	return nil
+}
+
+func ownershipListRun(f *ownershipListFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		var items []OwnedItem
+		if f.orphaned {
+			accounts, err := getAllItemsV4[account](client, "/fmeapiv4/accounts")
+			if err != nil {
+				return err
+			}
+			active := map[string]bool{}
+			for _, element := range accounts {
+				active[strings.ToLower(element.Name)] = element.Enabled
+			}
+			items, err = getOwnedItems(client, f.kinds, func(owner string) bool { return owner != "" && !active[strings.ToLower(owner)] })
+			if err != nil {
+				return err
+			}
+		} else {
+			if _, err := GetAccountIDByName(f.user); err != nil {
+				return err
+			}
+			var err error
+			items, err = getOwnedItems(client, f.kinds, func(owner string) bool { return strings.EqualFold(owner, f.user) })
+			if err != nil {
+				return err
+			}
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Kind", "Name", "Owner"})
+
+			for _, element := range items {
+				t.AppendRow(table.Row{element.Kind, element.Name, element.Owner})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			jsonData, err := json.Marshal(items)
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(jsonData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			marshalledItems := [][]byte{}
+			for _, element := range items {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// get the items of the given kinds whose owner matches, in the order the kinds are listed in ownershipKinds
+func getOwnedItems(client *http.Client, kinds []string, matches func(owner string) bool) ([]OwnedItem, error) {
+	items := []OwnedItem{}
+	if slices.Contains(kinds, "repositories") {
+		repositories, err := getAllItemsV4[FMEFlowRepositoryV4](client, "/fmeapiv4/repositories")
+		if err != nil {
+			return nil, err
+		}
+		for _, element := range sortedByName(repositories, func(r FMEFlowRepositoryV4) string { return r.Name }) {
+			if matches(element.Owner) {
+				items = append(items, OwnedItem{Kind: "repository", Name: element.Name, Owner: element.Owner, endpoint: "/fmeapiv4/repositories/" + url.PathEscape(element.Name)})
+			}
+		}
+	}
+	if slices.Contains(kinds, "schedules") {
+		schedules, err := getSchedulesV4(client, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, element := range sortedByName(schedules, func(s ScheduleV4) string { return s.Category + "/" + s.Name }) {
+			if matches(element.Owner) {
+				items = append(items, OwnedItem{Kind: "schedule", Name: element.Category + "/" + element.Name, Owner: element.Owner, endpoint: scheduleEndpoint(element.Category, element.Name)})
+			}
+		}
+	}
+	if slices.Contains(kinds, "automations") {
+		automations, err := getAllItemsV4[AutomationV4](client, "/fmeapiv4/automations")
+		if err != nil {
+			return nil, err
+		}
+		for _, element := range sortedByName(automations, func(a AutomationV4) string { return a.Name }) {
+			if matches(element.Owner) {
+				items = append(items, OwnedItem{Kind: "automation", Name: element.Name, Owner: element.Owner, endpoint: automationEndpoint(element.ID)})
+			}
+		}
+	}
+	if slices.Contains(kinds, "connections") {
+		connections, err := getAllItemsV4[Connection](client, "/fmeapiv4/connections")
+		if err != nil {
+			return nil, err
+		}
+		for _, element := range sortedByName(connections, func(c Connection) string { return c.Name }) {
+			if matches(element.Owner) {
+				items = append(items, OwnedItem{Kind: "connection", Name: element.Name, Owner: element.Owner, endpoint: "/fmeapiv4/connections/" + url.PathEscape(element.Name)})
+			}
+		}
+	}
+	return items, nil
+}
+
+type OwnershipTransferResult struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Status string `json:"status"`
+}
+
+type ownershipTransferFlags struct {
+	from       string
+	to         string
+	kinds      []string
+	dryRun     bool
+	noprompt   bool
+	outputType string
+	noHeaders  bool
+}
+
+func newOwnershipTransferCmd() *cobra.Command {
+	f := ownershipTransferFlags{}
+	cmd := &cobra.Command{
+		Use:   "transfer",
+		Short: "Transfer everything a user owns to another user",
+		Long: `Transfer the repositories, schedules, automations and connections a user owns to another user in one operation.
+Use --dry-run to see the plan without changing anything. The user being transferred from doesn't need to exist any more, so items left behind by deleted accounts can be given a new owner.
+If an item can't be transferred, the rest are still transferred and the item is reported as failed. Run the command again to retry the items that failed.`,
+		Example: `
+  # Show what would be transferred from "jsmith" to "bjones"
+  fmeflow ownership transfer --from jsmith --to bjones --dry-run
+
+  # Transfer everything "jsmith" owns to "bjones" with no confirmation
+  fmeflow ownership transfer --from jsmith --to bjones -y
+
+  # Transfer only the schedules and automations "jsmith" owns
+  fmeflow ownership transfer --from jsmith --to bjones --kinds schedules,automations`,
+		Args: NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateOwnershipKinds(f.kinds)
+		},
+		RunE: ownershipTransferRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.from, "from", "", "Name of the user to transfer the items from.")
+	cmd.Flags().StringVar(&f.to, "to", "", "Name of the user to transfer the items to.")
+	addOwnershipKindsFlag(cmd, &f.kinds)
+	cmd.Flags().BoolVar(&f.dryRun, "dry-run", false, "Show the items that would be transferred without transferring them.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+func ownershipTransferRun(f *ownershipTransferFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if strings.EqualFold(f.from, f.to) {
+			return errors.New("--from and --to must be different users")
+		}
+
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+		if err := checkOutputType(f.outputType); err != nil {
+			return err
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		toID, err := GetAccountIDByName(f.to)
+		if err != nil {
+			return err
+		}
+
+		items, err := getOwnedItems(client, f.kinds, func(owner string) bool { return strings.EqualFold(owner, f.from) })
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			if !jsonOutput {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s doesn't own any %s.\n", f.from, strings.Join(f.kinds, ", "))
+			} else {
+				fmt.Fprintln(cmd.OutOrStdout(), "[]")
+			}
+			return nil
+		}
+
+		if !f.dryRun && !f.noprompt {
+			// prompt to confirm
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: fmt.Sprintf("Are you sure you want to transfer %d items from %s to %s?", len(items), f.from, f.to),
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		// keep going when an item fails so that one bad item doesn't leave the rest behind
+		results := []OwnershipTransferResult{}
+		failed := 0
+		for _, item := range items {
+			result := OwnershipTransferResult{Kind: item.Kind, Name: item.Name, From: item.Owner, To: f.to, Status: "planned"}
+			if !f.dryRun {
+				if _, err := sendFmeFlowJSON(client, item.endpoint+"/owner", "PUT", OwnershipChangeV4{OwnerID: toID}, http.StatusNoContent, http.StatusOK); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Warning: could not transfer %s %s: %s\n", item.Kind, item.Name, err)
+					result.Status = "failed"
+					failed++
+				} else {
+					result.Status = "transferred"
+				}
+			}
+			results = append(results, result)
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Kind", "Name", "From", "To", "Status"})
+
+			for _, element := range results {
+				t.AppendRow(table.Row{element.Kind, element.Name, element.From, element.To, element.Status})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			jsonData, err := json.Marshal(results)
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(jsonData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			marshalledItems := [][]byte{}
+			for _, element := range results {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		if failed != 0 {
+			return fmt.Errorf("%d of %d items could not be transferred", failed, len(items))
+		}
+		return nil
+	}
+}
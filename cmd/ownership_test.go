@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwnershipList(t *testing.T) {
+	usersListBody := `{
+	  "items": [
+	    {
+	      "id": "7d3b9d43-3b8a-4b52-9a1f-0c4a1d2f6e01",
+	      "name": "admin",
+	      "fullName": "Administrator",
+	      "email": "admin@example.com",
+	      "isSuperUser": true,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    },
+	    {
+	      "id": "c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02",
+	      "name": "jsmith",
+	      "fullName": "Jane Smith",
+	      "email": "jsmith@example.com",
+	      "isSuperUser": false,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    }
+	  ],
+	  "totalCount": 2,
+	  "limit": 2,
+	  "offset": 0
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond.
+	// jsmith owns one item of each kind, and a deleted user "rjones" still owns a repository
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(usersListBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/repositories" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items": [{"name": "Samples", "owner": "jsmith"}, {"name": "Legacy", "owner": "rjones"}, {"name": "Admin", "owner": "admin"}], "totalCount": 3, "limit": 3, "offset": 0}`))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/schedules" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items": [{"name": "Refresh", "category": "Nightly", "owner": "jsmith"}], "totalCount": 1, "limit": 1, "offset": 0}`))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/automations" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items": [{"name": "Watcher", "id": "a1", "owner": "jsmith"}], "totalCount": 1, "limit": 1, "offset": 0}`))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/connections" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items": [{"name": "warehouse", "category": "database", "owner": "jsmith"}], "totalCount": 1, "limit": 1, "offset": 0}`))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"ownership", "list", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing user",
+			statusCode:  http.StatusOK,
+			args:        []string{"ownership", "list"},
+			wantErrText: "at least one of the flags in the group [user orphaned] is required",
+		},
+		{
+			name:        "user and orphaned",
+			statusCode:  http.StatusOK,
+			args:        []string{"ownership", "list", "--user", "jsmith", "--orphaned"},
+			wantErrText: "if any flags in the group [user orphaned] are set none of the others can be; [orphaned user] were all set",
+		},
+		{
+			name:        "invalid kind",
+			statusCode:  http.StatusOK,
+			args:        []string{"ownership", "list", "--user", "jsmith", "--kinds", "projects"},
+			wantErrText: "invalid kind \"projects\". Must be one of repositories, schedules, automations, connections",
+		},
+		{
+			name:        "user not found",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"ownership", "list", "--user", "nobody"},
+			wantErrText: "account name 'nobody' not found",
+		},
+		{
+			name:            "list items a user owns",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"ownership", "list", "--user", "jsmith"},
+			wantOutputRegex: "^[\\s]*KIND[\\s]*NAME[\\s]*OWNER[\\s]*repository[\\s]*Samples[\\s]*jsmith[\\s]*schedule[\\s]*Nightly/Refresh[\\s]*jsmith[\\s]*automation[\\s]*Watcher[\\s]*jsmith[\\s]*connection[\\s]*warehouse[\\s]*jsmith[\\s]*$",
+		},
+		{
+			name:            "list some kinds",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"ownership", "list", "--user", "jsmith", "--kinds", "connections,repositories", "--no-headers"},
+			wantOutputRegex: "^[\\s]*repository[\\s]*Samples[\\s]*jsmith[\\s]*connection[\\s]*warehouse[\\s]*jsmith[\\s]*$",
+		},
+		{
+			name:            "list orphaned items",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"ownership", "list", "--orphaned", "--no-headers"},
+			wantOutputRegex: "^[\\s]*repository[\\s]*Legacy[\\s]*rjones[\\s]*$",
+		},
+		{
+			name:           "list json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"ownership", "list", "--user", "jsmith", "--kinds", "schedules", "--json"},
+			wantOutputJson: `[{"kind": "schedule", "name": "Nightly/Refresh", "owner": "jsmith"}]`,
+		},
+		{
+			name:            "list custom columns",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"ownership", "list", "--user", "jsmith", "--kinds", "automations", "--output", "custom-columns=NAME:.name", "--no-headers"},
+			wantOutputRegex: "^[\\s]*Watcher[\\s]*$",
+		},
+		{
+			name:        "invalid output",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"ownership", "list", "--user", "jsmith", "--output", "xml"},
+			wantErrText: "invalid output format specified",
+		},
+	}
+
+	runTests(cases, t)
+}
+
+func TestOwnershipTransfer(t *testing.T) {
+	usersListBody := `{
+	  "items": [
+	    {
+	      "id": "7d3b9d43-3b8a-4b52-9a1f-0c4a1d2f6e01",
+	      "name": "admin",
+	      "fullName": "Administrator",
+	      "email": "admin@example.com",
+	      "isSuperUser": true,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    },
+	    {
+	      "id": "c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02",
+	      "name": "jsmith",
+	      "fullName": "Jane Smith",
+	      "email": "jsmith@example.com",
+	      "isSuperUser": false,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    }
+	  ],
+	  "totalCount": 2,
+	  "limit": 2,
+	  "offset": 0
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond.
+	// jsmith owns one item of each kind, and a deleted user "rjones" still owns a repository
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(usersListBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/repositories" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items": [{"name": "Samples", "owner": "jsmith"}, {"name": "Legacy", "owner": "rjones"}, {"name": "Admin", "owner": "admin"}], "totalCount": 3, "limit": 3, "offset": 0}`))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/schedules" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items": [{"name": "Refresh", "category": "Nightly", "owner": "jsmith"}], "totalCount": 1, "limit": 1, "offset": 0}`))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/automations" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items": [{"name": "Watcher", "id": "a1", "owner": "jsmith"}], "totalCount": 1, "limit": 1, "offset": 0}`))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/connections" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items": [{"name": "warehouse", "category": "database", "owner": "jsmith"}], "totalCount": 1, "limit": 1, "offset": 0}`))
+			require.NoError(t, err)
+		} else if r.Method == "PUT" && (r.URL.Path == "/fmeapiv4/repositories/Samples/owner" || r.URL.Path == "/fmeapiv4/repositories/Legacy/owner" || r.URL.Path == "/fmeapiv4/schedules/Nightly/Refresh/owner" || r.URL.Path == "/fmeapiv4/automations/a1/owner" || r.URL.Path == "/fmeapiv4/connections/warehouse/owner") {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// nothing is changed in a dry run
+	customHttpServerHandlerDryRun := func(w http.ResponseWriter, r *http.Request) {
+		require.NotEqual(t, "PUT", r.Method, "nothing should be transferred in a dry run")
+		customHttpServerHandler(w, r)
+	}
+
+	// the schedule can't be transferred, but the items after it still are
+	customHttpServerHandlerScheduleFails := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/schedules/Nightly/Refresh/owner" {
+			w.WriteHeader(http.StatusForbidden)
+			_, err := w.Write([]byte(`{"message": "You do not have permission to change the owner of this schedule."}`))
+			require.NoError(t, err)
+			return
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// every item is given to admin
+	customHttpServerHandlerTransfer := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"ownerID":"7d3b9d43-3b8a-4b52-9a1f-0c4a1d2f6e01"}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	cases := []testCase{
+		{
+			name:        "missing flags",
+			statusCode:  http.StatusOK,
+			args:        []string{"ownership", "transfer"},
+			wantErrText: "required flag(s) \"from\", \"to\" not set",
+		},
+		{
+			name:        "same user",
+			statusCode:  http.StatusOK,
+			args:        []string{"ownership", "transfer", "--from", "jsmith", "--to", "JSmith"},
+			wantErrText: "--from and --to must be different users",
+		},
+		{
+			name:        "target user not found",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"ownership", "transfer", "--from", "jsmith", "--to", "nobody", "-y"},
+			wantErrText: "account name 'nobody' not found",
+		},
+		{
+			name:            "nothing to transfer",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"ownership", "transfer", "--from", "admin", "--to", "jsmith", "--kinds", "schedules", "-y"},
+			wantOutputRegex: "^admin doesn't own any schedules.\n$",
+		},
+		{
+			name:        "invalid output is caught before transferring",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandlerDryRun)),
+			args:        []string{"ownership", "transfer", "--from", "jsmith", "--to", "admin", "-y", "--output", "bogus"},
+			wantErrText: "invalid output format specified",
+		},
+		{
+			name:            "dry run",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerDryRun)),
+			args:            []string{"ownership", "transfer", "--from", "jsmith", "--to", "admin", "--dry-run"},
+			wantOutputRegex: "^[\\s]*KIND[\\s]*NAME[\\s]*FROM[\\s]*TO[\\s]*STATUS[\\s]*repository[\\s]*Samples[\\s]*jsmith[\\s]*admin[\\s]*planned[\\s]*schedule[\\s]*Nightly/Refresh[\\s]*jsmith[\\s]*admin[\\s]*planned[\\s]*automation[\\s]*Watcher[\\s]*jsmith[\\s]*admin[\\s]*planned[\\s]*connection[\\s]*warehouse[\\s]*jsmith[\\s]*admin[\\s]*planned[\\s]*$",
+		},
+		{
+			name:            "transfer everything",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerTransfer)),
+			args:            []string{"ownership", "transfer", "--from", "jsmith", "--to", "admin", "-y", "--no-headers"},
+			wantOutputRegex: "^[\\s]*repository[\\s]*Samples[\\s]*jsmith[\\s]*admin[\\s]*transferred[\\s]*schedule[\\s]*Nightly/Refresh[\\s]*jsmith[\\s]*admin[\\s]*transferred[\\s]*automation[\\s]*Watcher[\\s]*jsmith[\\s]*admin[\\s]*transferred[\\s]*connection[\\s]*warehouse[\\s]*jsmith[\\s]*admin[\\s]*transferred[\\s]*$",
+		},
+		{
+			name:               "transfer continues after a failure",
+			httpServer:         httptest.NewServer(http.HandlerFunc(customHttpServerHandlerScheduleFails)),
+			args:               []string{"ownership", "transfer", "--from", "jsmith", "--to", "admin", "-y", "--no-headers"},
+			wantOutputRegex:    "^[\\s]*repository[\\s]*Samples[\\s]*jsmith[\\s]*admin[\\s]*transferred[\\s]*schedule[\\s]*Nightly/Refresh[\\s]*jsmith[\\s]*admin[\\s]*failed[\\s]*automation[\\s]*Watcher[\\s]*jsmith[\\s]*admin[\\s]*transferred[\\s]*connection[\\s]*warehouse[\\s]*jsmith[\\s]*admin[\\s]*transferred[\\s]*$",
+			wantErrOutputRegex: "^Warning: could not transfer schedule Nightly/Refresh: You do not have permission to change the owner of this schedule.\n",
+			wantErrText:        "1 of 4 items could not be transferred",
+		},
+		{
+			name:           "transfer from a deleted user json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandlerTransfer)),
+			args:           []string{"ownership", "transfer", "--from", "rjones", "--to", "admin", "--kinds", "repositories", "-y", "--json"},
+			wantOutputJson: `[{"kind": "repository", "name": "Legacy", "from": "rjones", "to": "admin", "status": "transferred"}]`,
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type PackageInspectResult struct {
+	FMEServer PackageServerInfo `json:"fmeserver" yaml:"fmeserver"`
+	Counts    map[string]int    `json:"counts" yaml:"counts"`
+	Items     []PackageItem     `json:"items" yaml:"items"`
+}
+
+type packageInspectFlags struct {
+	outputType string
+	noHeaders  bool
+}
+
+func newPackageCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "package",
+		Short: "Inspect and compare backup and project packages",
+		Long: `Inspect and compare .fsconfig backup packages and .fsproject project packages without restoring or uploading them.
+These commands work on local files and don't connect to FME Flow.`,
+		Example: `
+  # List what is in a backup
+  fmeflow package inspect ServerConfigPackage.fsconfig
+
+  # Show what changed between two versions of a project
+  fmeflow package diff v1.fsproject v2.fsproject`,
+		// no connection to FME Flow is needed, so don't check the config file
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+		Args: NoArgs,
+	}
+	cmd.AddCommand(newPackageInspectCmd())
+	cmd.AddCommand(newPackageDiffCmd())
+	return cmd
+}
+
+func newPackageInspectCmd() *cobra.Command {
+	f := packageInspectFlags{}
+	cmd := &cobra.Command{
+		Use:   "inspect FILE",
+		Short: "List the contents of a backup or project package",
+		Long:  `List the repositories, workspaces, connections, schedules, automations, users and other items in a .fsconfig or .fsproject package, along with the version of FME Flow that created it.`,
+		Example: `
+  # List what is in a backup
+  fmeflow package inspect ServerConfigPackage.fsconfig
+
+  # Output the contents of a project as yaml
+  fmeflow package inspect MyProject.fsproject --output yaml
+
+  # List just the names of the workspaces in a project
+  fmeflow package inspect MyProject.fsproject --output=custom-columns=TYPE:.type,NAME:.name --no-headers | grep ^workspace`,
+		// the file is the only argument
+		Args: cobra.ExactArgs(1),
+		RunE: packageInspectRun(&f),
+	}
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, yaml or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	return cmd
+}
+
+func packageInspectRun(f *packageInspectFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		p, err := openPackage(args[0])
+		if err != nil {
+			return err
+		}
+		defer p.Close()
+
+		items := sortedPackageItems(p.manifest.Items)
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Type", "Name"})
+
+			for _, element := range items {
+				t.AppendRow(table.Row{element.Type, element.Name})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			} else {
+				fmt.Fprintln(cmd.OutOrStdout(), args[0]+": "+p.summary())
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" || f.outputType == "yaml" {
+			result := PackageInspectResult{FMEServer: p.manifest.FMEServer, Counts: map[string]int{}, Items: items}
+			for _, element := range items {
+				result.Counts[packageItemTypePlural(element.Type)]++
+			}
+			return printPackageResult(cmd, result, f.outputType)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			marshalledItems := [][]byte{}
+			for _, element := range items {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// output the result of a package command as json or yaml
+func printPackageResult(cmd *cobra.Command, result interface{}, outputType string) error {
+	if outputType == "yaml" {
+		encoder := yaml.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent(2)
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	prettyJSON, err := prettyPrintJSON(jsonData)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+	return nil
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// the file at the root of a .fsconfig or .fsproject package that describes what it contains
+const packageManifestName = "manifest.json"
+
+type PackageManifest struct {
+	FMEServer PackageServerInfo `json:"fmeserver"`
+	Items     []PackageItem     `json:"items"`
+}
+
+// the FME Flow that created a package
+type PackageServerInfo struct {
+	Version string `json:"version" yaml:"version"`
+	Build   int    `json:"build" yaml:"build"`
+}
+
+// an item exported in a package. Path is the file or directory in the archive holding its contents
+type PackageItem struct {
+	Type string `json:"type" yaml:"type"`
+	Name string `json:"name" yaml:"name"`
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+}
+
+// a backup or project package opened from disk
+type fmeflowPackage struct {
+	name     string
+	manifest PackageManifest
+	reader   *zip.ReadCloser
+}
+
+// the item types in the order they are listed, with their plural names
+var packageItemTypes = []struct {
+	singular string
+	plural   string
+}{
+	{"repository", "repositories"},
+	{"workspace", "workspaces"},
+	{"connection", "connections"},
+	{"schedule", "schedules"},
+	{"automation", "automations"},
+	{"topic", "topics"},
+	{"subscription", "subscriptions"},
+	{"publication", "publications"},
+	{"user", "users"},
+	{"role", "roles"},
+	{"project", "projects"},
+}
+
+// open a package and read its manifest
+func openPackage(name string) (*fmeflowPackage, error) {
+	reader, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid package: %w", name, err)
+	}
+	p := &fmeflowPackage{name: name, reader: reader}
+
+	manifestFile := p.file(packageManifestName)
+	if manifestFile == nil {
+		reader.Close()
+		return nil, fmt.Errorf("%s is not a valid package: no %s found", name, packageManifestName)
+	}
+	contents, err := readPackageFile(manifestFile)
+	if err != nil {
+		reader.Close()
+		return nil, fmt.Errorf("could not read the manifest of %s: %w", name, err)
+	}
+	if err := json.Unmarshal(contents, &p.manifest); err != nil {
+		reader.Close()
+		return nil, fmt.Errorf("could not read the manifest of %s: %w", name, err)
+	}
+	return p, nil
+}
+
+func (p *fmeflowPackage) Close() error {
+	return p.reader.Close()
+}
+
+func (p *fmeflowPackage) file(name string) *zip.File {
+	for _, f := range p.reader.File {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// the files in the archive that hold the contents of an item
+func (p *fmeflowPackage) itemFiles(item PackageItem) []*zip.File {
+	files := []*zip.File{}
+	if item.Path == "" {
+		return files
+	}
+	prefix := strings.TrimSuffix(item.Path, "/") + "/"
+	for _, f := range p.reader.File {
+		if f.Name == item.Path || strings.HasPrefix(f.Name, prefix) {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// check that every file in the package can be read and that every item in the manifest has its contents
+func (p *fmeflowPackage) verify() error {
+	for _, f := range p.reader.File {
+		if err := checkPackageFile(f); err != nil {
+			return fmt.Errorf("%s is corrupt: could not read %s: %w", p.name, f.Name, err)
+		}
+	}
+	for _, item := range p.manifest.Items {
+		if item.Path != "" && len(p.itemFiles(item)) == 0 {
+			return fmt.Errorf("%s is incomplete: the contents of %s %s are missing", p.name, item.Type, item.Name)
+		}
+	}
+	return nil
+}
+
+// a summary of what the package contains, such as "3 items (1 repository, 2 workspaces) from FME Flow 2024.1 (build 24612)"
+func (p *fmeflowPackage) summary() string {
+	summary := fmt.Sprintf("%d items", len(p.manifest.Items))
+	if len(p.manifest.Items) != 0 {
+		summary += " (" + formatPackageItemCounts(p.manifest.Items) + ")"
+	}
+	if version := p.version(); version != "" {
+		summary += " from " + version
+	}
+	return summary
+}
+
+// a description of the FME Flow version the package was created by
+func (p *fmeflowPackage) version() string {
+	return p.manifest.FMEServer.String()
+}
+
+func (s PackageServerInfo) String() string {
+	if s.Build != 0 {
+		build := "build " + strconv.Itoa(s.Build)
+		if s.Version == "" {
+			return build
+		}
+		return s.Version + " (" + build + ")"
+	}
+	return s.Version
+}
+
+// read a file in the archive. Reading to the end checks the file against its checksum
+func readPackageFile(f *zip.File) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	contents, err := io.ReadAll(r)
+	if errors.Is(err, zip.ErrChecksum) {
+		return nil, errors.New("checksum mismatch")
+	}
+	return contents, err
+}
+
+// check a file in the archive against its checksum without keeping its contents, as packages can be large
+func checkPackageFile(f *zip.File) error {
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	_, err = io.Copy(io.Discard, r)
+	if errors.Is(err, zip.ErrChecksum) {
+		return errors.New("checksum mismatch")
+	}
+	return err
+}
+
+// the plural name of an item type
+func packageItemTypePlural(itemType string) string {
+	for _, t := range packageItemTypes {
+		if t.singular == itemType {
+			return t.plural
+		}
+	}
+	return itemType + "s"
+}
+
+// the position of an item type when items are listed, with unknown types after the known ones
+func packageItemTypeOrder(itemType string) int {
+	for i, t := range packageItemTypes {
+		if t.singular == itemType {
+			return i
+		}
+	}
+	return len(packageItemTypes)
+}
+
+// sort items by type, then by name
+func sortedPackageItems(items []PackageItem) []PackageItem {
+	sorted := sortedByName(items, func(item PackageItem) string { return item.Name })
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if packageItemTypeOrder(sorted[i].Type) != packageItemTypeOrder(sorted[j].Type) {
+			return packageItemTypeOrder(sorted[i].Type) < packageItemTypeOrder(sorted[j].Type)
+		}
+		return sorted[i].Type < sorted[j].Type
+	})
+	return sorted
+}
+
+// summarize the number of items of each type, such as "2 repositories, 5 workspaces"
+func formatPackageItemCounts(items []PackageItem) string {
+	counts := map[string]int{}
+	for _, item := range items {
+		counts[item.Type]++
+	}
+	types := sortedKeys(counts)
+	sort.SliceStable(types, func(i, j int) bool {
+		return packageItemTypeOrder(types[i]) < packageItemTypeOrder(types[j])
+	})
+	parts := []string{}
+	for _, itemType := range types {
+		name := packageItemTypePlural(itemType)
+		if counts[itemType] == 1 {
+			name = itemType
+		}
+		parts = append(parts, strconv.Itoa(counts[itemType])+" "+name)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type PackageDifference struct {
+	Type    string   `json:"type" yaml:"type"`
+	Name    string   `json:"name" yaml:"name"`
+	Change  string   `json:"change" yaml:"change"`
+	Details []string `json:"details" yaml:"details"`
+}
+
+type packageDiffFlags struct {
+	outputType string
+	noHeaders  bool
+}
+
+func newPackageDiffCmd() *cobra.Command {
+	f := packageDiffFlags{}
+	cmd := &cobra.Command{
+		Use:   "diff FILE1 FILE2",
+		Short: "Show the differences between two packages",
+		Long: `Compare two .fsconfig or .fsproject packages and show the items that were added, removed or changed in the second package.
+An item has changed if any of the files holding its contents are different. The files that differ are listed in the details.`,
+		Example: `
+  # Show what changed between two versions of a project
+  fmeflow package diff v1.fsproject v2.fsproject
+
+  # Output the differences between two backups as yaml
+  fmeflow package diff monday.fsconfig tuesday.fsconfig --output yaml`,
+		// the files are the only arguments
+		Args: cobra.ExactArgs(2),
+		RunE: packageDiffRun(&f),
+	}
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, yaml or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	return cmd
+}
+
+func packageDiffRun(f *packageDiffFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		before, err := openPackage(args[0])
+		if err != nil {
+			return err
+		}
+		defer before.Close()
+		after, err := openPackage(args[1])
+		if err != nil {
+			return err
+		}
+		defer after.Close()
+
+		differences := diffPackages(before, after)
+
+		if f.outputType == "table" {
+			if len(differences) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "The packages contain the same items.")
+				return nil
+			}
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Type", "Name", "Change", "Details"})
+
+			for _, element := range differences {
+				t.AppendRow(table.Row{element.Type, element.Name, element.Change, strings.Join(element.Details, ", ")})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" || f.outputType == "yaml" {
+			return printPackageResult(cmd, differences, f.outputType)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			marshalledItems := [][]byte{}
+			for _, element := range differences {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// compare the items in two packages. The version of FME Flow that created them is listed first if it differs
+func diffPackages(before *fmeflowPackage, after *fmeflowPackage) []PackageDifference {
+	differences := []PackageDifference{}
+	if before.manifest.FMEServer != after.manifest.FMEServer {
+		differences = append(differences, PackageDifference{Type: "fmeserver", Name: "version", Change: "changed", Details: []string{before.version() + " to " + after.version()}})
+	}
+
+	key := func(item PackageItem) string { return item.Type + "/" + item.Name }
+	beforeItems := map[string]PackageItem{}
+	for _, item := range before.manifest.Items {
+		beforeItems[key(item)] = item
+	}
+	afterItems := map[string]PackageItem{}
+	for _, item := range after.manifest.Items {
+		afterItems[key(item)] = item
+	}
+
+	all := sortedPackageItems(append(append([]PackageItem{}, before.manifest.Items...), after.manifest.Items...))
+	seen := map[string]bool{}
+	for _, item := range all {
+		k := key(item)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+
+		beforeItem, inBefore := beforeItems[k]
+		afterItem, inAfter := afterItems[k]
+		switch {
+		case !inBefore:
+			differences = append(differences, PackageDifference{Type: item.Type, Name: item.Name, Change: "added", Details: []string{}})
+		case !inAfter:
+			differences = append(differences, PackageDifference{Type: item.Type, Name: item.Name, Change: "removed", Details: []string{}})
+		default:
+			if details := diffPackageItemFiles(before.itemFiles(beforeItem), beforeItem.Path, after.itemFiles(afterItem), afterItem.Path); len(details) != 0 {
+				differences = append(differences, PackageDifference{Type: item.Type, Name: item.Name, Change: "changed", Details: details})
+			}
+		}
+	}
+	return differences
+}
+
+// compare the files holding the contents of an item in two packages, using their checksums and sizes.
+// Files are named relative to the path of the item
+func diffPackageItemFiles(before []*zip.File, beforePath string, after []*zip.File, afterPath string) []string {
+	relative := func(f *zip.File, itemPath string) string {
+		if f.Name == itemPath {
+			return "contents"
+		}
+		return strings.TrimPrefix(f.Name, strings.TrimSuffix(itemPath, "/")+"/")
+	}
+	beforeFiles := map[string]*zip.File{}
+	for _, f := range before {
+		beforeFiles[relative(f, beforePath)] = f
+	}
+	afterFiles := map[string]*zip.File{}
+	for _, f := range after {
+		afterFiles[relative(f, afterPath)] = f
+	}
+
+	details := []string{}
+	for _, name := range sortedKeys(beforeFiles) {
+		a, ok := afterFiles[name]
+		b := beforeFiles[name]
+		if !ok {
+			details = append(details, "removed "+name)
+		} else if a.CRC32 != b.CRC32 || a.UncompressedSize64 != b.UncompressedSize64 {
+			if name == "contents" {
+				details = append(details, "contents changed")
+			} else {
+				details = append(details, "changed "+name)
+			}
+		}
+	}
+	for _, name := range sortedKeys(afterFiles) {
+		if _, ok := beforeFiles[name]; !ok {
+			details = append(details, "added "+name)
+		}
+	}
+	return details
+}
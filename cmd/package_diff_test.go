@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestPackageDiff(t *testing.T) {
+	dir := t.TempDir()
+	before := writeTestPackage(t, dir, "v1.fsproject", testPackageManifest, testPackageFiles)
+	same := writeTestPackage(t, dir, "same.fsproject", testPackageManifest, testPackageFiles)
+	after := writeTestPackage(t, dir, "v2.fsproject", `{
+  "fmeserver": {"version": "FME Flow 2025.0", "build": 25208},
+  "items": [
+    {"type": "repository", "name": "Samples", "path": "repositories/Samples"},
+    {"type": "workspace", "name": "Samples/austinApartments.fmw", "path": "repositories/Samples/austinApartments.fmw"},
+    {"type": "connection", "name": "warehouse", "path": "connections/warehouse.json"},
+    {"type": "schedule", "name": "Nightly/Refresh", "path": "schedules/Nightly/Refresh.json"},
+    {"type": "user", "name": "jsmith"}
+  ]
+}`, map[string]string{
+		"repositories/Samples/austinApartments.fmw": "austin",
+		"repositories/Samples/readme.txt":           "readme",
+		"connections/warehouse.json":                `{"name": "warehouse", "database": "prod"}`,
+		"schedules/Nightly/Refresh.json":            `{"name": "Refresh"}`,
+	})
+
+	cases := []testCase{
+		{
+			name:        "missing second file",
+			args:        []string{"package", "diff", before},
+			wantErrText: "accepts 2 arg(s), received 1",
+			omitConfig:  true,
+		},
+		{
+			name:            "same packages",
+			args:            []string{"package", "diff", before, same},
+			wantOutputRegex: "^The packages contain the same items.\\n$",
+			omitConfig:      true,
+		},
+		{
+			name:            "diff packages",
+			args:            []string{"package", "diff", before, after},
+			wantOutputRegex: "^[\\s]*TYPE[\\s]*NAME[\\s]*CHANGE[\\s]*DETAILS[\\s]*fmeserver[\\s]*version[\\s]*changed[\\s]*FME Flow 2024.1 \\(build 24612\\) to FME Flow 2025.0 \\(build 25208\\)[\\s]*repository[\\s]*Samples[\\s]*changed[\\s]*removed easyTranslator.fmw, added readme.txt[\\s]*workspace[\\s]*Samples/easyTranslator.fmw[\\s]*removed[\\s]*connection[\\s]*warehouse[\\s]*changed[\\s]*contents changed[\\s]*schedule[\\s]*Nightly/Refresh[\\s]*added[\\s]*$",
+			omitConfig:      true,
+		},
+		{
+			name: "diff packages json",
+			args: []string{"package", "diff", before, after, "--json"},
+			wantOutputJson: `[
+				{"type": "fmeserver", "name": "version", "change": "changed", "details": ["FME Flow 2024.1 (build 24612) to FME Flow 2025.0 (build 25208)"]},
+				{"type": "repository", "name": "Samples", "change": "changed", "details": ["removed easyTranslator.fmw", "added readme.txt"]},
+				{"type": "workspace", "name": "Samples/easyTranslator.fmw", "change": "removed", "details": []},
+				{"type": "connection", "name": "warehouse", "change": "changed", "details": ["contents changed"]},
+				{"type": "schedule", "name": "Nightly/Refresh", "change": "added", "details": []}
+			]`,
+			omitConfig: true,
+		},
+		{
+			name:            "diff packages yaml",
+			args:            []string{"package", "diff", same, after, "--output", "yaml"},
+			wantOutputRegex: "- type: workspace\\n  name: Samples/easyTranslator.fmw\\n  change: removed\\n  details: \\[\\]\\n",
+			omitConfig:      true,
+		},
+		{
+			name:            "diff packages custom columns",
+			args:            []string{"package", "diff", before, after, "--output", "custom-columns=NAME:.name,CHANGE:.change", "--no-headers"},
+			wantOutputRegex: "^[\\s]*version[\\s]*changed[\\s]*Samples[\\s]*changed[\\s]*Samples/easyTranslator.fmw[\\s]*removed[\\s]*warehouse[\\s]*changed[\\s]*Nightly/Refresh[\\s]*added[\\s]*$",
+			omitConfig:      true,
+		},
+	}
+
+	runTests(cases, t)
+}
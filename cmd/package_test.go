@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// write a package to a file in a temporary directory
+func writeTestPackage(t *testing.T, dir string, name string, manifest string, files map[string]string) string {
+	file := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(file, newTestPackage(t, manifest, files), 0644))
+	return file
+}
+
+func TestPackageInspect(t *testing.T) {
+	dir := t.TempDir()
+	backup := writeTestPackage(t, dir, "backup.fsconfig", testPackageManifest, testPackageFiles)
+	notAPackage := filepath.Join(dir, "notapackage.fsconfig")
+	require.NoError(t, os.WriteFile(notAPackage, []byte("Random file contents"), 0644))
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			args:               []string{"package", "inspect", backup, "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+			omitConfig:         true,
+		},
+		{
+			name:        "missing file",
+			args:        []string{"package", "inspect"},
+			wantErrText: "accepts 1 arg(s), received 0",
+			omitConfig:  true,
+		},
+		{
+			name:        "file does not exist",
+			args:        []string{"package", "inspect", filepath.Join(dir, "missing.fsconfig")},
+			wantErrText: filepath.Join(dir, "missing.fsconfig") + " is not a valid package: open " + filepath.Join(dir, "missing.fsconfig") + ": no such file or directory",
+			omitConfig:  true,
+		},
+		{
+			name:        "not a package",
+			args:        []string{"package", "inspect", notAPackage},
+			wantErrText: notAPackage + " is not a valid package: zip: not a valid zip file",
+			omitConfig:  true,
+		},
+		{
+			name:            "inspect package",
+			args:            []string{"package", "inspect", backup},
+			wantOutputRegex: "^.*backup.fsconfig: 5 items \\(1 repository, 2 workspaces, 1 connection, 1 user\\) from FME Flow 2024.1 \\(build 24612\\)\\n[\\s]*TYPE[\\s]*NAME[\\s]*repository[\\s]*Samples[\\s]*workspace[\\s]*Samples/austinApartments.fmw[\\s]*workspace[\\s]*Samples/easyTranslator.fmw[\\s]*connection[\\s]*warehouse[\\s]*user[\\s]*jsmith[\\s]*$",
+			omitConfig:      true,
+		},
+		{
+			name:            "inspect package no headers",
+			args:            []string{"package", "inspect", backup, "--no-headers"},
+			wantOutputRegex: "^[\\s]*repository[\\s]*Samples[\\s]*workspace[\\s]*Samples/austinApartments.fmw[\\s]*workspace[\\s]*Samples/easyTranslator.fmw[\\s]*connection[\\s]*warehouse[\\s]*user[\\s]*jsmith[\\s]*$",
+			omitConfig:      true,
+		},
+		{
+			name: "inspect package json",
+			args: []string{"package", "inspect", backup, "--json"},
+			wantOutputJson: `{
+				"fmeserver": {"version": "FME Flow 2024.1", "build": 24612},
+				"counts": {"repositories": 1, "workspaces": 2, "connections": 1, "users": 1},
+				"items": [
+					{"type": "repository", "name": "Samples", "path": "repositories/Samples"},
+					{"type": "workspace", "name": "Samples/austinApartments.fmw", "path": "repositories/Samples/austinApartments.fmw"},
+					{"type": "workspace", "name": "Samples/easyTranslator.fmw", "path": "repositories/Samples/easyTranslator.fmw"},
+					{"type": "connection", "name": "warehouse", "path": "connections/warehouse.json"},
+					{"type": "user", "name": "jsmith"}
+				]
+			}`,
+			omitConfig: true,
+		},
+		{
+			name:            "inspect package yaml",
+			args:            []string{"package", "inspect", backup, "--output", "yaml"},
+			wantOutputRegex: "^fmeserver:\\n  version: FME Flow 2024.1\\n  build: 24612\\ncounts:\\n  connections: 1\\n  repositories: 1\\n  users: 1\\n  workspaces: 2\\nitems:\\n  - type: repository\\n    name: Samples\\n    path: repositories/Samples\\n",
+			omitConfig:      true,
+		},
+		{
+			name:            "inspect package custom columns",
+			args:            []string{"package", "inspect", backup, "--output", "custom-columns=NAME:.name", "--no-headers"},
+			wantOutputRegex: "^[\\s]*Samples[\\s]*Samples/austinApartments.fmw[\\s]*Samples/easyTranslator.fmw[\\s]*warehouse[\\s]*jsmith[\\s]*$",
+			omitConfig:      true,
+		},
+		{
+			name:        "invalid output",
+			args:        []string{"package", "inspect", backup, "--output", "xml"},
+			wantErrText: "invalid output format specified",
+			omitConfig:  true,
+		},
+	}
+
+	runTests(cases, t)
+}
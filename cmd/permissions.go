@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type PermissionCheckResult struct {
+	Resource  string   `json:"resource"`
+	Action    string   `json:"action"`
+	Allowed   bool     `json:"allowed"`
+	GrantedBy []string `json:"grantedBy"`
+}
+
+type permissionsCheckFlags struct {
+	user       string
+	resource   string
+	actions    []string
+	outputType string
+	noHeaders  bool
+}
+
+// what a super user is shown as being granted by
+const superUserGrant = "super user"
+
+func newPermissionsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "permissions",
+		Short: "Check the permissions of users",
+		Long:  `Check the permissions that users have through their roles. Use "fmeflow roles" to manage the permissions of roles.`,
+		Example: `
+  # Check what the user "jsmith" can do with the Samples repository
+  fmeflow permissions check --user jsmith --resource repository:Samples`,
+		Args: NoArgs,
+	}
+	cmd.AddCommand(newPermissionsCheckCmd())
+	return cmd
+}
+
+func newPermissionsCheckCmd() *cobra.Command {
+	f := permissionsCheckFlags{}
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check the effective access of a user to a resource",
+		Long: `Check the effective access of a user to a category, such as repository, or to a single item, such as repository:Samples.
+Access comes from the permissions of every role the user has, and a super user has access to everything. For a single item, permissions granted on the whole category are included. Access a user has because they own an item, or because it was shared with them, isn't included.
+
+Without --action, every action the user can do is listed along with the roles that allow it. With --action, each action is checked and the command fails if the user can't do any of them, so it can be used to audit access in scripts.`,
+		Example: `
+  # List what the user "jsmith" can do with the Samples repository
+  fmeflow permissions check --user jsmith --resource repository:Samples
+
+  # Fail if the user "jsmith" can't read and run the workspaces in the Samples repository
+  fmeflow permissions check --user jsmith --resource repository:Samples --action read --action run`,
+		Args: NoArgs,
+		RunE: permissionsCheckRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.user, "user", "", "Name of the user to check.")
+	cmd.Flags().StringVar(&f.resource, "resource", "", "The resource to check, in the form category or category:item.")
+	cmd.Flags().StringArrayVar(&f.actions, "action", []string{}, "An action to check, such as read or run. Can be passed in multiple times.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.MarkFlagRequired("user")
+	cmd.MarkFlagRequired("resource")
+	return cmd
+}
+
+func permissionsCheckRun(f *permissionsCheckFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		category, item, _ := strings.Cut(f.resource, ":")
+		if category == "" || strings.Contains(item, ":") {
+			return fmt.Errorf("invalid resource %q. Must be in the form category or category:item", f.resource)
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		user, _, err := getAccountV4(client, f.user)
+		if err != nil {
+			return err
+		}
+
+		// the actions allowed on the resource and the roles that allow them
+		granted := map[string][]string{}
+		if user.IsSuperUser {
+			granted["*"] = []string{superUserGrant}
+		} else {
+			roles, err := getAccountRolesV4(client, user.ID)
+			if err != nil {
+				return err
+			}
+			for _, summary := range roles {
+				role, _, err := getRoleV4(client, summary.Name)
+				if err != nil {
+					return err
+				}
+				for _, permission := range role.Permissions {
+					if permission.Category == category && (permission.Item == "" || permission.Item == item) && !slices.Contains(granted[permission.Action], role.Name) {
+						granted[permission.Action] = append(granted[permission.Action], role.Name)
+					}
+				}
+			}
+		}
+
+		results := []PermissionCheckResult{}
+		if len(f.actions) == 0 {
+			for _, action := range sortedKeys(granted) {
+				results = append(results, PermissionCheckResult{Resource: f.resource, Action: action, Allowed: true, GrantedBy: granted[action]})
+			}
+		}
+		denied := []string{}
+		for _, action := range f.actions {
+			result := PermissionCheckResult{Resource: f.resource, Action: action, GrantedBy: granted[action]}
+			if user.IsSuperUser {
+				result.GrantedBy = granted["*"]
+			}
+			result.Allowed = len(result.GrantedBy) != 0
+			if !result.Allowed {
+				result.GrantedBy = []string{}
+				denied = append(denied, action)
+			}
+			results = append(results, result)
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Resource", "Action", "Allowed", "Granted By"})
+
+			for _, element := range results {
+				t.AppendRow(table.Row{element.Resource, element.Action, element.Allowed, strings.Join(element.GrantedBy, ", ")})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			jsonData, err := json.Marshal(results)
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(jsonData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			marshalledItems := [][]byte{}
+			for _, element := range results {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		if len(denied) != 0 {
+			return fmt.Errorf("user %s can't %s %s", f.user, strings.Join(denied, " or "), f.resource)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionsCheck(t *testing.T) {
+	usersListBody := `{
+	  "items": [
+	    {
+	      "id": "7d3b9d43-3b8a-4b52-9a1f-0c4a1d2f6e01",
+	      "name": "admin",
+	      "fullName": "Administrator",
+	      "email": "admin@example.com",
+	      "isSuperUser": true,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    },
+	    {
+	      "id": "c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02",
+	      "name": "jsmith",
+	      "fullName": "Jane Smith",
+	      "email": "jsmith@example.com",
+	      "isSuperUser": false,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    }
+	  ],
+	  "totalCount": 2,
+	  "limit": 2,
+	  "offset": 0
+	}`
+
+	userJsmithBody := `{
+	  "id": "c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02",
+	  "name": "jsmith",
+	  "fullName": "Jane Smith",
+	  "email": "jsmith@example.com",
+	  "isSuperUser": false,
+	  "enabled": true,
+	  "sharingEnabled": true,
+	  "type": "fme"
+	}`
+
+	roleAnalystsBody := `{
+	  "name": "analysts",
+	  "description": "Run the sample workspaces",
+	  "permissions": [
+	    {
+	      "category": "repository",
+	      "item": "Samples",
+	      "action": "read"
+	    },
+	    {
+	      "category": "repository",
+	      "item": "Samples",
+	      "action": "run"
+	    }
+	  ]
+	}`
+
+	roleAuditorsBody := `{
+	  "name": "auditors",
+	  "description": "View jobs and repositories",
+	  "permissions": [
+	    {
+	      "category": "jobs",
+	      "action": "access"
+	    },
+	    {
+	      "category": "repository",
+	      "action": "read"
+	    }
+	  ]
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(usersListBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts/7d3b9d43-3b8a-4b52-9a1f-0c4a1d2f6e01" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"id": "7d3b9d43-3b8a-4b52-9a1f-0c4a1d2f6e01", "name": "admin", "isSuperUser": true, "enabled": true}`))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts/c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(userJsmithBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts/c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02/roles" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items": [{"name": "analysts", "description": "Run the sample workspaces"}, {"name": "auditors", "description": "View jobs and repositories"}], "totalCount": 2, "limit": 2, "offset": 0}`))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/roles/analysts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(roleAnalystsBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/roles/auditors" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(roleAuditorsBody))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:        "missing flags",
+			args:        []string{"permissions", "check"},
+			wantErrText: "required flag(s) \"resource\", \"user\" not set",
+		},
+		{
+			name:        "invalid resource",
+			args:        []string{"permissions", "check", "--user", "jsmith", "--resource", "repository:Samples:read"},
+			wantErrText: "invalid resource \"repository:Samples:read\". Must be in the form category or category:item",
+		},
+		{
+			name:        "user does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"permissions", "check", "--user", "bjones", "--resource", "repository:Samples"},
+			wantErrText: "account name 'bjones' not found",
+		},
+		{
+			name:            "list access to item",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"permissions", "check", "--user", "jsmith", "--resource", "repository:Samples"},
+			wantOutputRegex: "^[\\s]*RESOURCE[\\s]*ACTION[\\s]*ALLOWED[\\s]*GRANTED BY[\\s]*repository:Samples[\\s]*read[\\s]*true[\\s]*analysts, auditors[\\s]*repository:Samples[\\s]*run[\\s]*true[\\s]*analysts[\\s]*$",
+		},
+		{
+			name:           "list access to category",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"permissions", "check", "--user", "jsmith", "--resource", "repository", "--json"},
+			wantOutputJson: `[{"resource":"repository","action":"read","allowed":true,"grantedBy":["auditors"]}]`,
+		},
+		{
+			name:            "check allowed and denied actions",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"permissions", "check", "--user", "jsmith", "--resource", "repository:Training", "--action", "read", "--action", "run", "--action", "write"},
+			wantOutputRegex: "^[\\s]*RESOURCE[\\s]*ACTION[\\s]*ALLOWED[\\s]*GRANTED BY[\\s]*repository:Training[\\s]*read[\\s]*true[\\s]*auditors[\\s]*repository:Training[\\s]*run[\\s]*false[\\s]*repository:Training[\\s]*write[\\s]*false[\\s]*$",
+			wantErrText:     "user jsmith can't run or write repository:Training",
+		},
+		{
+			name:           "super user",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"permissions", "check", "--user", "admin", "--resource", "repository:Samples", "--action", "write", "--json"},
+			wantOutputJson: `[{"resource":"repository:Samples","action":"write","allowed":true,"grantedBy":["super user"]}]`,
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type FMEFlowQueuesV4 struct {
+	Items      []FMEFlowQueueV4 `json:"items"`
+	Limit      int              `json:"limit"`
+	Offset     int              `json:"offset"`
+	TotalCount int              `json:"totalCount"`
+}
+
+type FMEFlowQueueV4 struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Priority    int           `json:"priority"`
+	Engines     []string      `json:"engines"`
+	Rules       []QueueRuleV4 `json:"rules"`
+}
+
+// a rule that routes jobs for a whole repository, or a single workspace in it, to a queue
+type QueueRuleV4 struct {
+	Repository string `json:"repository"`
+	Workspace  string `json:"workspace,omitempty"`
+}
+
+type NewQueueV4 struct {
+	Name        string        `json:"name,omitempty"`
+	Description string        `json:"description"`
+	Priority    int           `json:"priority,omitempty"`
+	Rules       []QueueRuleV4 `json:"rules,omitempty"`
+}
+
+type queuesFlags struct {
+	name       string
+	outputType string
+	noHeaders  bool
+}
+
+func newQueuesCmd() *cobra.Command {
+	f := queuesFlags{}
+	cmd := &cobra.Command{
+		Use:   "queues",
+		Short: "List, Create, Update and Delete queues",
+		Long: `Lists the queues on FME Flow with their priority, assigned engines and routing rules. Pass in a name to get information on a specific queue.
+Use the subcommands to create, update or delete queues. Routing rules send the jobs for a repository, or a single workspace, to a queue.`,
+		Example: `
+  # List all queues
+  fmeflow queues
+
+  # List a single queue with the name "Priority"
+  fmeflow queues --name Priority
+
+  # Output the name and priority of every queue
+  fmeflow queues --output=custom-columns=NAME:.name,PRIORITY:.priority`,
+		Args: NoArgs,
+		RunE: queuesRun(&f),
+	}
+
+	addQueuesListFlags(cmd, &f)
+	cmd.AddCommand(newQueueListCmd())
+	cmd.AddCommand(newQueueCreateCmd())
+	cmd.AddCommand(newQueueUpdateCmd())
+	cmd.AddCommand(newQueueDeleteCmd())
+	return cmd
+}
+
+func newQueueListCmd() *cobra.Command {
+	f := queuesFlags{}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List queues",
+		Long:  `Lists the queues on FME Flow with their priority, assigned engines and routing rules. This is the same as running "fmeflow queues".`,
+		Example: `
+  # List all queues
+  fmeflow queues list
+
+  # Output all queues in json format
+  fmeflow queues list --json`,
+		Args: NoArgs,
+		RunE: queuesRun(&f),
+	}
+
+	addQueuesListFlags(cmd, &f)
+	return cmd
+}
+
+func addQueuesListFlags(cmd *cobra.Command, f *queuesFlags) {
+	cmd.Flags().StringVar(&f.name, "name", "", "If specified, only the queue with that name will be returned")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+}
+
+func queuesRun(f *queuesFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		var result FMEFlowQueuesV4
+		var responseData []byte
+		if f.name == "" {
+			items, err := getAllItemsV4[FMEFlowQueueV4](client, "/fmeapiv4/queues")
+			if err != nil {
+				return err
+			}
+			result.Items = items
+			result.TotalCount = len(items)
+			result.Limit = len(items)
+			responseData, err = json.Marshal(result)
+			if err != nil {
+				return err
+			}
+		} else {
+			queue, data, err := getQueueV4(client, f.name)
+			if err != nil {
+				return err
+			}
+			result.TotalCount = 1
+			result.Items = append(result.Items, queue)
+			responseData = data
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Name", "Priority", "Engines", "Rules", "Description"})
+
+			for _, element := range result.Items {
+				t.AppendRow(table.Row{element.Name, element.Priority, strings.Join(element.Engines, ", "), formatQueueRules(element.Rules), element.Description})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			// we have to marshal the Items array, then create an array of marshalled items
+			// to pass to the creation of the table.
+			marshalledItems := [][]byte{}
+			for _, element := range result.Items {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// get a single queue by name, along with the raw response
+func getQueueV4(client *http.Client, name string) (FMEFlowQueueV4, []byte, error) {
+	var result FMEFlowQueueV4
+
+	request, err := buildFmeFlowRequest("/fmeapiv4/queues/"+name, "GET", nil)
+	if err != nil {
+		return result, nil, err
+	}
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return result, nil, err
+	} else if response.StatusCode != http.StatusOK {
+		if response.StatusCode == http.StatusNotFound {
+			return result, nil, fmt.Errorf("%w: check that the specified queue exists", errors.New(response.Status))
+		}
+		return result, nil, parseResponseMessage(response)
+	}
+
+	responseData, err := io.ReadAll(response.Body)
+	if err != nil {
+		return result, nil, err
+	}
+
+	err = json.Unmarshal(responseData, &result)
+	return result, responseData, err
+}
+
+// parse a rule in the form repository or repository/workspace
+func parseQueueRule(rule string) (QueueRuleV4, error) {
+	repository, workspace, _ := strings.Cut(rule, "/")
+	if repository == "" || strings.Contains(workspace, "/") {
+		return QueueRuleV4{}, fmt.Errorf("invalid rule %q. Must be in the form repository or repository/workspace", rule)
+	}
+	return QueueRuleV4{Repository: repository, Workspace: workspace}, nil
+}
+
+func (r QueueRuleV4) String() string {
+	if r.Workspace == "" {
+		return r.Repository + "/*"
+	}
+	return r.Repository + "/" + r.Workspace
+}
+
+func formatQueueRules(rules []QueueRuleV4) string {
+	formatted := []string{}
+	for _, rule := range rules {
+		formatted = append(formatted, rule.String())
+	}
+	return strings.Join(formatted, ", ")
+}
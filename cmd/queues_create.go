@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+type queueCreateFlags struct {
+	name        string
+	description string
+	priority    int
+	engines     []string
+	rules       []string
+}
+
+func newQueueCreateCmd() *cobra.Command {
+	f := queueCreateFlags{}
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a queue",
+		Long: `Create a new queue on FME Flow. Engines can be assigned to the queue and routing rules can be added so that jobs for a repository, or a single workspace in a repository, are sent to the queue.
+A rule is given as REPOSITORY to route every workspace in a repository, or REPOSITORY/WORKSPACE to route a single workspace.`,
+		Example: `
+  # Create a queue named "Priority" with a priority of 1
+  fmeflow queues create --name Priority --priority 1
+
+  # Create a queue, assign an engine to it and route every workspace in the "Production" repository to it
+  fmeflow queues create --name Production --engine FMESERVER_Engine1 --rule Production
+
+  # Create a queue for a single workspace
+  fmeflow queues create --name Nightly --rule Samples/austinApartments.fmw`,
+		Args: NoArgs,
+		RunE: queueCreateRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the queue to create.")
+	cmd.Flags().StringVar(&f.description, "description", "", "Description of the queue.")
+	cmd.Flags().IntVar(&f.priority, "priority", 0, "Priority of the queue. Jobs in queues with a lower number run first.")
+	cmd.Flags().StringArrayVar(&f.engines, "engine", []string{}, "Name of an engine to assign to the queue. Can be passed in multiple times.")
+	cmd.Flags().StringArrayVar(&f.rules, "rule", []string{}, "Routing rule in the form REPOSITORY or REPOSITORY/WORKSPACE. Can be passed in multiple times.")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func queueCreateRun(f *queueCreateFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		newQueue := NewQueueV4{Name: f.name, Description: f.description, Priority: f.priority}
+		for _, rule := range f.rules {
+			parsed, err := parseQueueRule(rule)
+			if err != nil {
+				return err
+			}
+			newQueue.Rules = append(newQueue.Rules, parsed)
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		if _, err := sendFmeFlowJSON(client, "/fmeapiv4/queues", "POST", newQueue, http.StatusCreated); err != nil {
+			return err
+		}
+
+		for _, engine := range f.engines {
+			if _, err := sendFmeFlowJSON(client, "/fmeapiv4/queues/"+f.name+"/engines/"+engine, "PUT", nil, http.StatusOK, http.StatusNoContent); err != nil {
+				return fmt.Errorf("queue %s was created but engine %s could not be assigned: %w", f.name, engine, err)
+			}
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Queue successfully created.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
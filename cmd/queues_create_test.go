@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestQueuesCreate(t *testing.T) {
+	response := `{
+		"message": "A queue with the name Priority already exists."
+	  }`
+
+	// creates the queue, then assigns the engine to it
+	customHttpServerHandlerEngine := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/queues" {
+			w.WriteHeader(http.StatusCreated)
+		} else if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/queues/Nightly/engines/FMESERVER_Engine1" {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusCreated,
+			args:               []string{"queues", "create", "--name", "Priority", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing name",
+			args:        []string{"queues", "create"},
+			wantErrText: "required flag(s) \"name\" not set",
+		},
+		{
+			name:        "queue already exists",
+			statusCode:  http.StatusConflict,
+			body:        response,
+			args:        []string{"queues", "create", "--name", "Priority"},
+			wantErrText: "A queue with the name Priority already exists.",
+		},
+		{
+			name:            "create queue",
+			statusCode:      http.StatusCreated,
+			args:            []string{"queues", "create", "--name", "Priority", "--priority", "1", "--description", "Urgent jobs"},
+			wantOutputRegex: "^Queue successfully created.\n$",
+			wantBodyJson:    `{"name":"Priority","description":"Urgent jobs","priority":1}`,
+		},
+		{
+			name:            "create queue with rules",
+			statusCode:      http.StatusCreated,
+			args:            []string{"queues", "create", "--name", "Nightly", "--rule", "Production", "--rule", "Samples/austinApartments.fmw"},
+			wantOutputRegex: "^Queue successfully created.\n$",
+			wantBodyJson:    `{"name":"Nightly","description":"","rules":[{"repository":"Production"},{"repository":"Samples","workspace":"austinApartments.fmw"}]}`,
+		},
+		{
+			name:        "invalid rule",
+			statusCode:  http.StatusCreated,
+			args:        []string{"queues", "create", "--name", "Nightly", "--rule", "a/b/c"},
+			wantErrText: "invalid rule \"a/b/c\". Must be in the form repository or repository/workspace",
+		},
+		{
+			name:            "create queue with engine",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerEngine)),
+			args:            []string{"queues", "create", "--name", "Nightly", "--engine", "FMESERVER_Engine1"},
+			wantOutputRegex: "^Queue successfully created.\n$",
+		},
+		{
+			name:           "create queue json",
+			statusCode:     http.StatusCreated,
+			args:           []string{"queues", "create", "--name", "Priority", "--json"},
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+}
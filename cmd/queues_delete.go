@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+type queueDeleteFlags struct {
+	name     string
+	noprompt bool
+}
+
+func newQueueDeleteCmd() *cobra.Command {
+	f := queueDeleteFlags{}
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a queue",
+		Long:  `Delete a queue. Jobs that were routed to the queue by its rules go to the Default queue instead. The Default queue can't be deleted.`,
+		Example: `
+  # Delete the queue "Priority"
+  fmeflow queues delete --name Priority
+
+  # Delete the queue "Priority" with no confirmation
+  fmeflow queues delete --name Priority --no-prompt`,
+		Args: NoArgs,
+		RunE: queueDeleteRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the queue to delete.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func queueDeleteRun(f *queueDeleteFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if strings.EqualFold(f.name, defaultQueueName) {
+			return fmt.Errorf("the %s queue can't be deleted", defaultQueueName)
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		// check the queue exists first
+		if _, _, err := getQueueV4(client, f.name); err != nil {
+			return err
+		}
+
+		if !f.noprompt {
+			// prompt to confirm deletion
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: "Are you sure you want to delete the queue " + f.name + "?",
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		if _, err := sendFmeFlowJSON(client, "/fmeapiv4/queues/"+f.name, "DELETE", nil, http.StatusNoContent, http.StatusOK); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Queue successfully deleted.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueuesDelete(t *testing.T) {
+	queuePriorityBody := `{
+		"name": "Priority",
+		"description": "Urgent jobs",
+		"priority": 1,
+		"engines": ["FMESERVER_Engine2"],
+		"rules": [
+		  {"repository": "Production"},
+		  {"repository": "Samples", "workspace": "austinApartments.fmw"}
+		]
+	  }`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/queues/Priority" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(queuePriorityBody))
+			require.NoError(t, err)
+		} else if r.Method == "DELETE" && r.URL.Path == "/fmeapiv4/queues/Priority" {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"queues", "delete", "--name", "Priority", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flag",
+			args:        []string{"queues", "delete"},
+			wantErrText: "required flag(s) \"name\" not set",
+		},
+		{
+			name:        "delete default queue",
+			args:        []string{"queues", "delete", "--name", "Default", "-y"},
+			wantErrText: "the Default queue can't be deleted",
+		},
+		{
+			name:        "queue not found",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"queues", "delete", "--name", "Missing", "-y"},
+			wantErrText: "404 Not Found: check that the specified queue exists",
+		},
+		{
+			name:            "delete queue",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"queues", "delete", "--name", "Priority", "-y"},
+			wantOutputRegex: "^Queue successfully deleted.\n$",
+		},
+		{
+			name:           "delete queue json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"queues", "delete", "--name", "Priority", "-y", "--json"},
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+}
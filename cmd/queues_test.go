@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueues(t *testing.T) {
+	queuesListBody := `{
+		"items": [
+		  {
+			"name": "Default",
+			"description": "",
+			"priority": 5,
+			"engines": ["FMESERVER_Engine1", "FMESERVER_Engine2"],
+			"rules": []
+		  },
+		  {
+			"name": "Priority",
+			"description": "Urgent jobs",
+			"priority": 1,
+			"engines": ["FMESERVER_Engine2"],
+			"rules": [
+			  {"repository": "Production"},
+			  {"repository": "Samples", "workspace": "austinApartments.fmw"}
+			]
+		  }
+		],
+		"limit": 100,
+		"offset": 0,
+		"totalCount": 2
+	  }`
+
+	queuePriorityBody := `{
+		"name": "Priority",
+		"description": "Urgent jobs",
+		"priority": 1,
+		"engines": ["FMESERVER_Engine2"],
+		"rules": [
+		  {"repository": "Production"},
+		  {"repository": "Samples", "workspace": "austinApartments.fmw"}
+		]
+	  }`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/queues" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(queuesListBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/queues/Priority" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(queuePriorityBody))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"queues", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"queues"},
+		},
+		{
+			name:        "queue not found",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			wantErrText: "404 Not Found: check that the specified queue exists",
+			args:        []string{"queues", "--name", "Missing"},
+		},
+		{
+			name:            "get queues table output",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"queues"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*PRIORITY[\\s]*ENGINES[\\s]*RULES[\\s]*DESCRIPTION[\\s]*Default[\\s]*5[\\s]*FMESERVER_Engine1, FMESERVER_Engine2[\\s]*Priority[\\s]*1[\\s]*FMESERVER_Engine2[\\s]*Production/\\*, Samples/austinApartments.fmw[\\s]*Urgent jobs[\\s]*$",
+		},
+		{
+			name:            "list subcommand no headers",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"queues", "list", "--no-headers"},
+			wantOutputRegex: "^[\\s]*Default[\\s]*5[\\s]*FMESERVER_Engine1, FMESERVER_Engine2[\\s]*Priority[\\s]*1[\\s]*FMESERVER_Engine2[\\s]*Production/\\*, Samples/austinApartments.fmw[\\s]*Urgent jobs[\\s]*$",
+		},
+		{
+			name:            "get single queue",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"queues", "--name", "Priority"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*PRIORITY[\\s]*ENGINES[\\s]*RULES[\\s]*DESCRIPTION[\\s]*Priority[\\s]*1[\\s]*FMESERVER_Engine2[\\s]*Production/\\*, Samples/austinApartments.fmw[\\s]*Urgent jobs[\\s]*$",
+		},
+		{
+			name:           "get single queue json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"queues", "--name", "Priority", "--json"},
+			wantOutputJson: queuePriorityBody,
+		},
+		{
+			name:            "get queues custom columns",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"queues", "--output=custom-columns=NAME:.name,PRIORITY:.priority"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*PRIORITY[\\s]*Default[\\s]*5[\\s]*Priority[\\s]*1[\\s]*$",
+		},
+		{
+			name:        "invalid output",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"queues", "-o", "yaml"},
+			wantErrText: "invalid output format specified",
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/spf13/cobra"
+)
+
+type queueUpdateFlags struct {
+	name          string
+	description   string
+	priority      int
+	addEngines    []string
+	removeEngines []string
+	addRules      []string
+	removeRules   []string
+}
+
+// the body of a queue update. Rules are always sent so that removing the last rule clears them
+type UpdateQueueV4 struct {
+	Description string        `json:"description"`
+	Priority    int           `json:"priority"`
+	Rules       []QueueRuleV4 `json:"rules"`
+}
+
+func newQueueUpdateCmd() *cobra.Command {
+	f := queueUpdateFlags{}
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update a queue",
+		Long: `Update the description, priority, engines or routing rules of a queue. Anything that isn't specified is left unchanged.
+A rule is given as REPOSITORY to route every workspace in a repository, or REPOSITORY/WORKSPACE to route a single workspace.`,
+		Example: `
+  # Change the priority of the queue "Priority"
+  fmeflow queues update --name Priority --priority 2
+
+  # Route the "Production" repository to the queue and stop routing a single workspace to it
+  fmeflow queues update --name Priority --add-rule Production --remove-rule Samples/austinApartments.fmw
+
+  # Move an engine to the queue
+  fmeflow queues update --name Priority --add-engine FMESERVER_Engine2`,
+		Args: NoArgs,
+		RunE: queueUpdateRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the queue to update.")
+	cmd.Flags().StringVar(&f.description, "description", "", "Description of the queue.")
+	cmd.Flags().IntVar(&f.priority, "priority", 0, "Priority of the queue. Jobs in queues with a lower number run first.")
+	cmd.Flags().StringArrayVar(&f.addEngines, "add-engine", []string{}, "Name of an engine to assign to the queue. Can be passed in multiple times.")
+	cmd.Flags().StringArrayVar(&f.removeEngines, "remove-engine", []string{}, "Name of an engine to unassign from the queue. Can be passed in multiple times.")
+	cmd.Flags().StringArrayVar(&f.addRules, "add-rule", []string{}, "Routing rule to add in the form REPOSITORY or REPOSITORY/WORKSPACE. Can be passed in multiple times.")
+	cmd.Flags().StringArrayVar(&f.removeRules, "remove-rule", []string{}, "Routing rule to remove in the form REPOSITORY or REPOSITORY/WORKSPACE. Can be passed in multiple times.")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagsOneRequired("description", "priority", "add-engine", "remove-engine", "add-rule", "remove-rule")
+	return cmd
+}
+
+func queueUpdateRun(f *queueUpdateFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		queue, _, err := getQueueV4(client, f.name)
+		if err != nil {
+			return err
+		}
+
+		for _, engine := range f.removeEngines {
+			if !slices.Contains(queue.Engines, engine) {
+				return fmt.Errorf("engine %s is not assigned to queue %s", engine, f.name)
+			}
+		}
+
+		update := UpdateQueueV4{Description: queue.Description, Priority: queue.Priority, Rules: queue.Rules}
+		if update.Rules == nil {
+			update.Rules = []QueueRuleV4{}
+		}
+		if cmd.Flags().Changed("description") {
+			update.Description = f.description
+		}
+		if cmd.Flags().Changed("priority") {
+			update.Priority = f.priority
+		}
+		for _, rule := range f.removeRules {
+			parsed, err := parseQueueRule(rule)
+			if err != nil {
+				return err
+			}
+			index := slices.Index(update.Rules, parsed)
+			if index == -1 {
+				return fmt.Errorf("queue %s does not have the rule %s", f.name, parsed)
+			}
+			update.Rules = slices.Delete(update.Rules, index, index+1)
+		}
+		for _, rule := range f.addRules {
+			parsed, err := parseQueueRule(rule)
+			if err != nil {
+				return err
+			}
+			if slices.Contains(update.Rules, parsed) {
+				return fmt.Errorf("queue %s already has the rule %s", f.name, parsed)
+			}
+			update.Rules = append(update.Rules, parsed)
+		}
+
+		if cmd.Flags().Changed("description") || cmd.Flags().Changed("priority") || len(f.addRules) != 0 || len(f.removeRules) != 0 {
+			if _, err := sendFmeFlowJSON(client, "/fmeapiv4/queues/"+f.name, "PUT", update, http.StatusOK, http.StatusNoContent); err != nil {
+				return err
+			}
+		}
+
+		for _, engine := range f.addEngines {
+			if _, err := sendFmeFlowJSON(client, "/fmeapiv4/queues/"+f.name+"/engines/"+engine, "PUT", nil, http.StatusOK, http.StatusNoContent); err != nil {
+				return err
+			}
+		}
+		for _, engine := range f.removeEngines {
+			if _, err := sendFmeFlowJSON(client, "/fmeapiv4/queues/"+f.name+"/engines/"+engine, "DELETE", nil, http.StatusOK, http.StatusNoContent); err != nil {
+				return err
+			}
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Queue successfully updated.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueuesUpdate(t *testing.T) {
+	queuePriorityBody := `{
+		"name": "Priority",
+		"description": "Urgent jobs",
+		"priority": 1,
+		"engines": ["FMESERVER_Engine2"],
+		"rules": [
+		  {"repository": "Production"},
+		  {"repository": "Samples", "workspace": "austinApartments.fmw"}
+		]
+	  }`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/queues/Priority" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(queuePriorityBody))
+			require.NoError(t, err)
+		} else if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/queues/Priority" {
+			w.WriteHeader(http.StatusNoContent)
+		} else if (r.Method == "PUT" || r.Method == "DELETE") && strings.HasPrefix(r.URL.Path, "/fmeapiv4/queues/Priority/engines/") {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// the update keeps the rules of the queue
+	customHttpServerHandlerPriority := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/queues/Priority" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"description":"Urgent jobs","priority":2,"rules":[{"repository":"Production"},{"repository":"Samples","workspace":"austinApartments.fmw"}]}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// the update replaces the rules of the queue
+	customHttpServerHandlerRules := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/queues/Priority" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"description":"Urgent jobs","priority":1,"rules":[{"repository":"Samples","workspace":"austinApartments.fmw"},{"repository":"Nightly","workspace":"report.fmw"}]}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// the update removes every rule
+	customHttpServerHandlerNoRules := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/queues/Priority" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"description":"Urgent jobs","priority":1,"rules":[]}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"queues", "update", "--name", "Priority", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "nothing to update",
+			args:        []string{"queues", "update", "--name", "Priority"},
+			wantErrText: "at least one of the flags in the group [description priority add-engine remove-engine add-rule remove-rule] is required",
+		},
+		{
+			name:        "queue not found",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"queues", "update", "--name", "Missing", "--priority", "2"},
+			wantErrText: "404 Not Found: check that the specified queue exists",
+		},
+		{
+			name:            "update priority",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerPriority)),
+			args:            []string{"queues", "update", "--name", "Priority", "--priority", "2"},
+			wantOutputRegex: "^Queue successfully updated.\n$",
+		},
+		{
+			name:            "add and remove rules",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerRules)),
+			args:            []string{"queues", "update", "--name", "Priority", "--remove-rule", "Production", "--add-rule", "Nightly/report.fmw"},
+			wantOutputRegex: "^Queue successfully updated.\n$",
+		},
+		{
+			name:            "remove all rules",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerNoRules)),
+			args:            []string{"queues", "update", "--name", "Priority", "--remove-rule", "Production", "--remove-rule", "Samples/austinApartments.fmw"},
+			wantOutputRegex: "^Queue successfully updated.\n$",
+		},
+		{
+			name:        "remove missing rule",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"queues", "update", "--name", "Priority", "--remove-rule", "Samples"},
+			wantErrText: "queue Priority does not have the rule Samples/*",
+		},
+		{
+			name:        "add existing rule",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"queues", "update", "--name", "Priority", "--add-rule", "Production"},
+			wantErrText: "queue Priority already has the rule Production/*",
+		},
+		{
+			name:            "add and remove engines",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"queues", "update", "--name", "Priority", "--add-engine", "FMESERVER_Engine1", "--remove-engine", "FMESERVER_Engine2"},
+			wantOutputRegex: "^Queue successfully updated.\n$",
+		},
+		{
+			name:        "remove engine not assigned",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"queues", "update", "--name", "Priority", "--remove-engine", "FMESERVER_Engine1"},
+			wantErrText: "engine FMESERVER_Engine1 is not assigned to queue Priority",
+		},
+		{
+			name:           "update json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"queues", "update", "--name", "Priority", "--description", "Urgent", "--json"},
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+}
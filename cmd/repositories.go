@@ -63,8 +63,8 @@ func newRepositoryCmd() *cobra.Command {
 	f := repositoryFlags{}
 	cmd := &cobra.Command{
 		Use:   "repositories",
-		Short: "List, Create and Delete repositories",
-		Long:  `Lists repositories on the given FME Server. Pass in a name to get information on a specific repository. Use the subcommands to create or delete repositories.`,
+		Short: "List, Create, Update, Delete and Sync repositories",
+		Long:  `Lists repositories on the given FME Server. Pass in a name to get information on a specific repository. Use the subcommands to create, update, delete or sync repositories, or manage who has access to them.`,
 		Example: `
   # List all repositories
   fmeflow repositories
@@ -112,7 +112,10 @@ func newRepositoryCmd() *cobra.Command {
 	cmd.Flags().MarkHidden("api-version")
 	cmd.RegisterFlagCompletionFunc("api-version", apiVersionFlagCompletion)
 	cmd.AddCommand(newRepositoryCreateCmd())
+	cmd.AddCommand(newRepositoryUpdateCmd())
 	cmd.AddCommand(newRepositoryDeleteCmd())
+	cmd.AddCommand(newRepositoryPermissionsCmd())
+	cmd.AddCommand(newRepositorySyncCmd())
 
 	return cmd
 }
@@ -200,61 +203,7 @@ func repositoriesRun(f *repositoryFlags) func(cmd *cobra.Command, args []string)
 				result.Items = append(result.Items, singleResult)
 			}
 
-			if f.outputType == "table" {
-
-				t := table.NewWriter()
-				t.SetStyle(defaultStyle)
-
-				t.AppendHeader(table.Row{"Name", "Owner", "Description", "Workspaces"})
-
-				for _, element := range result.Items {
-					t.AppendRow(table.Row{element.Name, element.Owner, element.Description, element.WorkspaceCount})
-				}
-				if f.noHeaders {
-					t.ResetHeaders()
-				}
-				fmt.Fprintln(cmd.OutOrStdout(), t.Render())
-
-			} else if f.outputType == "json" {
-				prettyJSON, err := prettyPrintJSON(responseData)
-				if err != nil {
-					return err
-				}
-				fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
-			} else if strings.HasPrefix(f.outputType, "custom-columns") {
-				// parse the columns and json queries
-				columnsString := ""
-				if strings.HasPrefix(f.outputType, "custom-columns=") {
-					columnsString = f.outputType[len("custom-columns="):]
-				}
-				if len(columnsString) == 0 {
-					return errors.New("custom-columns format specified but no custom columns given")
-				}
-
-				// we have to marshal the Items array, then create an array of marshalled items
-				// to pass to the creation of the table.
-				marshalledItems := [][]byte{}
-				for _, element := range result.Items {
-					mJson, err := json.Marshal(element)
-					if err != nil {
-						return err
-					}
-					marshalledItems = append(marshalledItems, mJson)
-				}
-
-				columnsInput := strings.Split(columnsString, ",")
-				t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
-				if err != nil {
-					return err
-				}
-				if f.noHeaders {
-					t.ResetHeaders()
-				}
-				fmt.Fprintln(cmd.OutOrStdout(), t.Render())
-
-			} else {
-				return errors.New("invalid output format specified")
-			}
+			return outputRepositoriesV4(cmd, result, responseData, f.outputType, f.noHeaders)
 		} else if f.apiVersion == "v3" {
 			// set up the URL to query
 			url := "/fmerest/v3/repositories"
@@ -368,3 +317,64 @@ func repositoriesRun(f *repositoryFlags) func(cmd *cobra.Command, args []string)
 		return nil
 	}
 }
+
+// output a list of V4 repositories as a table, json or custom columns. This is shared
+// by all repository commands that output repositories
+func outputRepositoriesV4(cmd *cobra.Command, result FMEFlowRepositoriesV4, responseData []byte, outputType string, noHeaders bool) error {
+	if outputType == "table" {
+
+		t := table.NewWriter()
+		t.SetStyle(defaultStyle)
+
+		t.AppendHeader(table.Row{"Name", "Owner", "Description", "Workspaces"})
+
+		for _, element := range result.Items {
+			t.AppendRow(table.Row{element.Name, element.Owner, element.Description, element.WorkspaceCount})
+		}
+		if noHeaders {
+			t.ResetHeaders()
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+	} else if outputType == "json" {
+		prettyJSON, err := prettyPrintJSON(responseData)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+	} else if strings.HasPrefix(outputType, "custom-columns") {
+		// parse the columns and json queries
+		columnsString := ""
+		if strings.HasPrefix(outputType, "custom-columns=") {
+			columnsString = outputType[len("custom-columns="):]
+		}
+		if len(columnsString) == 0 {
+			return errors.New("custom-columns format specified but no custom columns given")
+		}
+
+		// we have to marshal the Items array, then create an array of marshalled items
+		// to pass to the creation of the table.
+		marshalledItems := [][]byte{}
+		for _, element := range result.Items {
+			mJson, err := json.Marshal(element)
+			if err != nil {
+				return err
+			}
+			marshalledItems = append(marshalledItems, mJson)
+		}
+
+		columnsInput := strings.Split(columnsString, ",")
+		t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+		if err != nil {
+			return err
+		}
+		if noHeaders {
+			t.ResetHeaders()
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+	} else {
+		return errors.New("invalid output format specified")
+	}
+	return nil
+}
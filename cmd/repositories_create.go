@@ -17,11 +17,13 @@ import (
 type NewRepository struct {
 	Description string `json:"description"`
 	Name        string `json:"name"`
+	OwnerID     string `json:"ownerID,omitempty"`
 }
 
 type repositoryCreateFlags struct {
 	description string
 	name        string
+	owner       string
 	apiVersion  apiVersionFlag
 }
 
@@ -37,6 +39,9 @@ func newRepositoryCreateCmd() *cobra.Command {
 	
   # Output just the name of all the repositories
   fmeflow repositories create --name myRepository --description "This is my new repository"
+
+  # Create a repository owned by the user "author1"
+  fmeflow repositories create --name myRepository --owner author1
 `,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			// get build to decide if we should use v3 or v4
@@ -49,6 +54,11 @@ func newRepositoryCreateCmd() *cobra.Command {
 					f.apiVersion = apiVersionFlagV4
 				}
 			}
+			if f.apiVersion == apiVersionFlagV3 {
+				if f.owner != "" {
+					return errors.New("cannot set the owner flag when using the V3 API")
+				}
+			}
 
 			return nil
 		},
@@ -58,6 +68,7 @@ func newRepositoryCreateCmd() *cobra.Command {
 
 	cmd.Flags().StringVar(&f.description, "description", "", "Description of the new repository.")
 	cmd.Flags().StringVar(&f.name, "name", "", "Name of the repository to create.")
+	cmd.Flags().StringVar(&f.owner, "owner", "", "Name of the user that will own the repository. Defaults to the current user. Only usable with V4 API.")
 	cmd.Flags().Var(&f.apiVersion, "api-version", "The api version to use when contacting FME Server. Must be one of v3 or v4")
 	cmd.Flags().MarkHidden("api-version")
 	cmd.RegisterFlagCompletionFunc("api-version", apiVersionFlagCompletion)
@@ -76,6 +87,13 @@ func repositoriesCreateRun(f *repositoryCreateFlags) func(cmd *cobra.Command, ar
 			var newRepo NewRepository
 			newRepo.Name = f.name
 			newRepo.Description = f.description
+			if f.owner != "" {
+				ownerID, err := GetAccountIDByName(f.owner)
+				if err != nil {
+					return err
+				}
+				newRepo.OwnerID = ownerID
+			}
 			jsonData, err := json.Marshal(newRepo)
 			if err != nil {
 				return err
@@ -1,14 +1,35 @@
 package cmd
 
 import (
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestRepositoriesCreate(t *testing.T) {
 	repoExistsBodyV4 := `{
 		"message": "MyRepo is not a valid name. A repository named Samples already exists."
 	  }`
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fmeapiv4/accounts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items":[{"id":"b5e6a1c2-7d3f-4e8a-9c01-2f3e4d5a6b7c","name":"author1"}],"totalCount":1,"limit":100,"offset":0}`))
+			require.NoError(t, err)
+			return
+		}
+		if r.URL.Path == "/fmeapiv4/repositories" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"MyRepo","description":"","ownerID":"b5e6a1c2-7d3f-4e8a-9c01-2f3e4d5a6b7c"}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}
+
 	cases := []testCase{
 		{
 			name:               "unknown flag",
@@ -64,6 +85,24 @@ func TestRepositoriesCreate(t *testing.T) {
 			args:        []string{"repositories", "create", "--name", "MyRepo", "--api-version", "v3"},
 			wantErrText: "409 Conflict: The repository already exists",
 		},
+		{
+			name:            "create repository with owner V4",
+			args:            []string{"repositories", "create", "--name", "MyRepo", "--owner", "author1", "--api-version", "v4"},
+			wantOutputRegex: "^Repository successfully created.[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+		},
+		{
+			name:        "owner not found V4",
+			statusCode:  http.StatusOK,
+			body:        `{"items":[],"totalCount":0,"limit":100,"offset":0}`,
+			args:        []string{"repositories", "create", "--name", "MyRepo", "--owner", "nobody", "--api-version", "v4"},
+			wantErrText: "account name 'nobody' not found",
+		},
+		{
+			name:        "owner with V3",
+			args:        []string{"repositories", "create", "--name", "MyRepo", "--owner", "author1", "--api-version", "v3"},
+			wantErrText: "cannot set the owner flag when using the V3 API",
+		},
 	}
 
 	runTests(cases, t)
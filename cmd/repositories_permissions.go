@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type RepositoryPermissionsV4 struct {
+	Items      []RepositoryPermissionV4 `json:"items"`
+	Limit      int                      `json:"limit"`
+	Offset     int                      `json:"offset"`
+	TotalCount int                      `json:"totalCount"`
+}
+
+type RepositoryPermissionV4 struct {
+	Type        string   `json:"type"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+type RepositoryPermissionUpdate struct {
+	Permissions []string `json:"permissions"`
+}
+
+type repositoryPermissionsFlags struct {
+	name       string
+	outputType string
+	noHeaders  bool
+}
+
+func newRepositoryPermissionsCmd() *cobra.Command {
+	f := repositoryPermissionsFlags{}
+	cmd := &cobra.Command{
+		Use:   "permissions",
+		Short: "List, grant and revoke access to a repository.",
+		Long:  `Lists the users and roles that have access to a repository and the permissions they have. Use the subcommands to grant or revoke access.`,
+		Example: `
+  # List the users and roles with access to the repository "Samples"
+  fmeflow repositories permissions --name Samples
+
+  # Output just the names of the users and roles with access to the repository "Samples"
+  fmeflow repositories permissions --name Samples --output=custom-columns=NAME:.name --no-headers
+
+  # Output the permissions in json format
+  fmeflow repositories permissions --name Samples --json`,
+		Args: NoArgs,
+		RunE: repositoryPermissionsRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the repository.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.MarkFlagRequired("name")
+	cmd.AddCommand(newRepositoryPermissionsGrantCmd())
+	cmd.AddCommand(newRepositoryPermissionsRevokeCmd())
+	return cmd
+}
+
+func repositoryPermissionsRun(f *repositoryPermissionsFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		result, responseData, err := getRepositoryPermissions(client, f.name)
+		if err != nil {
+			return err
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Type", "Name", "Permissions"})
+
+			for _, element := range result.Items {
+				t.AppendRow(table.Row{element.Type, element.Name, strings.Join(element.Permissions, ", ")})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			// we have to marshal the Items array, then create an array of marshalled items
+			// to pass to the creation of the table.
+			marshalledItems := [][]byte{}
+			for _, element := range result.Items {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// get the users and roles with access to a repository
+func getRepositoryPermissions(client *http.Client, name string) (RepositoryPermissionsV4, []byte, error) {
+	var result RepositoryPermissionsV4
+
+	request, err := buildFmeFlowRequest("/fmeapiv4/repositories/"+name+"/permissions", "GET", nil)
+	if err != nil {
+		return result, nil, err
+	}
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return result, nil, err
+	} else if response.StatusCode != http.StatusOK {
+		if response.StatusCode == http.StatusNotFound {
+			return result, nil, fmt.Errorf("%w: check that the specified repository exists", errors.New(response.Status))
+		}
+		return result, nil, parseResponseMessage(response)
+	}
+
+	responseData, err := io.ReadAll(response.Body)
+	if err != nil {
+		return result, nil, err
+	}
+
+	err = json.Unmarshal(responseData, &result)
+	return result, responseData, err
+}
+
+// returns the endpoint for the permissions of a single user or role on a repository
+func repositoryPermissionEndpoint(repository string, user string, role string) string {
+	if user != "" {
+		return "/fmeapiv4/repositories/" + repository + "/permissions/users/" + user
+	}
+	return "/fmeapiv4/repositories/" + repository + "/permissions/roles/" + role
+}
+
+// find the permissions currently held by a user or role on a repository
+func findRepositoryPermission(permissions RepositoryPermissionsV4, user string, role string) []string {
+	principalType, principal := "role", role
+	if user != "" {
+		principalType, principal = "user", user
+	}
+	for _, element := range permissions.Items {
+		if element.Type == principalType && element.Name == principal {
+			return element.Permissions
+		}
+	}
+	return []string{}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/spf13/cobra"
+)
+
+type repositoryPermissionsGrantFlags struct {
+	name        string
+	user        string
+	role        string
+	permissions []string
+}
+
+func newRepositoryPermissionsGrantCmd() *cobra.Command {
+	f := repositoryPermissionsGrantFlags{}
+	cmd := &cobra.Command{
+		Use:   "grant",
+		Short: "Grant a user or role access to a repository.",
+		Long:  `Grant a user or role permissions on a repository. The permissions are added to any permissions the user or role already has.`,
+		Example: `
+  # Grant the user "author1" read and run access to the repository "Samples"
+  fmeflow repositories permissions grant --name Samples --user author1 --permission read --permission run
+
+  # Grant the role "fmeauthor" full access to the repository "Samples"
+  fmeflow repositories permissions grant --name Samples --role fmeauthor --permission access,read,run,upload,manage`,
+		Args: NoArgs,
+		RunE: repositoryPermissionsGrantRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the repository.")
+	cmd.Flags().StringVar(&f.user, "user", "", "Name of the user to grant access to.")
+	cmd.Flags().StringVar(&f.role, "role", "", "Name of the role to grant access to.")
+	cmd.Flags().StringSliceVar(&f.permissions, "permission", []string{}, "Permission to grant. Can be specified multiple times or as a comma separated list.")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("permission")
+	cmd.MarkFlagsOneRequired("user", "role")
+	cmd.MarkFlagsMutuallyExclusive("user", "role")
+	return cmd
+}
+
+func repositoryPermissionsGrantRun(f *repositoryPermissionsGrantFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		existing, _, err := getRepositoryPermissions(client, f.name)
+		if err != nil {
+			return err
+		}
+
+		permissions := findRepositoryPermission(existing, f.user, f.role)
+		for _, permission := range f.permissions {
+			if !slices.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+
+		jsonData, err := json.Marshal(RepositoryPermissionUpdate{Permissions: permissions})
+		if err != nil {
+			return err
+		}
+
+		request, err := buildFmeFlowRequest(repositoryPermissionEndpoint(f.name, f.user, f.role), "PUT", bytes.NewBuffer(jsonData))
+		if err != nil {
+			return err
+		}
+		request.Header.Add("Content-Type", "application/json")
+
+		response, err := client.Do(&request)
+		if err != nil {
+			return err
+		} else if response.StatusCode != http.StatusNoContent {
+			return parseResponseMessage(response)
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Permissions successfully granted.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
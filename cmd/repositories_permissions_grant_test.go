@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoriesPermissionsGrant(t *testing.T) {
+	permissionsBody := `{
+		"items": [
+		  {
+			"type": "user",
+			"name": "author1",
+			"permissions": [
+			  "read"
+			]
+		  }
+		],
+		"limit": -1,
+		"offset": -1,
+		"totalCount": 1
+	  }`
+
+	newHandler := func(wantPath string, wantBody string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "GET" && r.URL.Path == "/fmeapiv4/repositories/MyRepo/permissions" {
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(permissionsBody))
+				require.NoError(t, err)
+				return
+			}
+			if r.Method == "PUT" && r.URL.Path == wantPath {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, wantBody, string(body))
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"repositories", "permissions", "grant", "--name", "MyRepo", "--user", "author1", "--permission", "run", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flag",
+			wantErrText: "required flag(s) \"name\", \"permission\" not set",
+			args:        []string{"repositories", "permissions", "grant", "--user", "author1"},
+		},
+		{
+			name:        "missing user or role",
+			wantErrText: "at least one of the flags in the group [user role] is required",
+			args:        []string{"repositories", "permissions", "grant", "--name", "MyRepo", "--permission", "run"},
+		},
+		{
+			name:        "user and role",
+			wantErrText: "if any flags in the group [user role] are set none of the others can be; [role user] were all set",
+			args:        []string{"repositories", "permissions", "grant", "--name", "MyRepo", "--user", "author1", "--role", "fmeauthor", "--permission", "run"},
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"repositories", "permissions", "grant", "--name", "MyRepo", "--user", "author1", "--permission", "run"},
+		},
+		{
+			name:            "grant user permissions",
+			args:            []string{"repositories", "permissions", "grant", "--name", "MyRepo", "--user", "author1", "--permission", "read,run"},
+			wantOutputRegex: "^Permissions successfully granted.[\\s]*$",
+			httpServer:      httptest.NewServer(newHandler("/fmeapiv4/repositories/MyRepo/permissions/users/author1", `{"permissions":["read","run"]}`)),
+		},
+		{
+			name:            "grant role permissions",
+			args:            []string{"repositories", "permissions", "grant", "--name", "MyRepo", "--role", "fmeauthor", "--permission", "read", "--permission", "upload"},
+			wantOutputRegex: "^Permissions successfully granted.[\\s]*$",
+			httpServer:      httptest.NewServer(newHandler("/fmeapiv4/repositories/MyRepo/permissions/roles/fmeauthor", `{"permissions":["read","upload"]}`)),
+		},
+		{
+			name:            "grant json output",
+			args:            []string{"repositories", "permissions", "grant", "--name", "MyRepo", "--user", "author1", "--permission", "run", "--json"},
+			wantOutputRegex: "^{}[\\s]*$",
+			httpServer:      httptest.NewServer(newHandler("/fmeapiv4/repositories/MyRepo/permissions/users/author1", `{"permissions":["read","run"]}`)),
+		},
+	}
+
+	runTests(cases, t)
+
+}
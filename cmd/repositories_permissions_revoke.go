@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+type repositoryPermissionsRevokeFlags struct {
+	name        string
+	user        string
+	role        string
+	permissions []string
+	noprompt    bool
+}
+
+func newRepositoryPermissionsRevokeCmd() *cobra.Command {
+	f := repositoryPermissionsRevokeFlags{}
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "Revoke access to a repository from a user or role.",
+		Long:  `Revoke permissions on a repository from a user or role. If no permissions are specified, all access to the repository is removed.`,
+		Example: `
+  # Revoke the upload permission on the repository "Samples" from the user "author1"
+  fmeflow repositories permissions revoke --name Samples --user author1 --permission upload
+
+  # Remove all access to the repository "Samples" from the role "fmeauthor" with no confirmation
+  fmeflow repositories permissions revoke --name Samples --role fmeauthor --no-prompt`,
+		Args: NoArgs,
+		RunE: repositoryPermissionsRevokeRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the repository.")
+	cmd.Flags().StringVar(&f.user, "user", "", "Name of the user to revoke access from.")
+	cmd.Flags().StringVar(&f.role, "role", "", "Name of the role to revoke access from.")
+	cmd.Flags().StringSliceVar(&f.permissions, "permission", []string{}, "Permission to revoke. Can be specified multiple times or as a comma separated list. If not specified, all permissions are revoked.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagsOneRequired("user", "role")
+	cmd.MarkFlagsMutuallyExclusive("user", "role")
+	return cmd
+}
+
+func repositoryPermissionsRevokeRun(f *repositoryPermissionsRevokeFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		existing, _, err := getRepositoryPermissions(client, f.name)
+		if err != nil {
+			return err
+		}
+
+		permissions := []string{}
+		if len(f.permissions) != 0 {
+			for _, permission := range findRepositoryPermission(existing, f.user, f.role) {
+				if !slices.Contains(f.permissions, permission) {
+					permissions = append(permissions, permission)
+				}
+			}
+		}
+
+		var request http.Request
+		if len(permissions) == 0 {
+			if !f.noprompt {
+				// prompt to confirm removing all access
+				principal := f.role
+				if f.user != "" {
+					principal = f.user
+				}
+				confirm := false
+				promptUser := &survey.Confirm{
+					Message: "Are you sure you want to remove all access to repository " + f.name + " from " + principal + "?",
+				}
+				survey.AskOne(promptUser, &confirm)
+				if !confirm {
+					return nil
+				}
+			}
+
+			request, err = buildFmeFlowRequest(repositoryPermissionEndpoint(f.name, f.user, f.role), "DELETE", nil)
+			if err != nil {
+				return err
+			}
+		} else {
+			jsonData, err := json.Marshal(RepositoryPermissionUpdate{Permissions: permissions})
+			if err != nil {
+				return err
+			}
+
+			request, err = buildFmeFlowRequest(repositoryPermissionEndpoint(f.name, f.user, f.role), "PUT", bytes.NewBuffer(jsonData))
+			if err != nil {
+				return err
+			}
+			request.Header.Add("Content-Type", "application/json")
+		}
+
+		response, err := client.Do(&request)
+		if err != nil {
+			return err
+		} else if response.StatusCode != http.StatusNoContent {
+			return parseResponseMessage(response)
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Permissions successfully revoked.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoriesPermissionsRevoke(t *testing.T) {
+	permissionsBody := `{
+		"items": [
+		  {
+			"type": "user",
+			"name": "author1",
+			"permissions": [
+			  "read",
+			  "run",
+			  "upload"
+			]
+		  }
+		],
+		"limit": -1,
+		"offset": -1,
+		"totalCount": 1
+	  }`
+
+	newHandler := func(wantMethod string, wantBody string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "GET" && r.URL.Path == "/fmeapiv4/repositories/MyRepo/permissions" {
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(permissionsBody))
+				require.NoError(t, err)
+				return
+			}
+			if r.Method == wantMethod && r.URL.Path == "/fmeapiv4/repositories/MyRepo/permissions/users/author1" {
+				if wantBody != "" {
+					body, err := io.ReadAll(r.Body)
+					require.NoError(t, err)
+					require.JSONEq(t, wantBody, string(body))
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"repositories", "permissions", "revoke", "--name", "MyRepo", "--user", "author1", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flag",
+			wantErrText: "required flag(s) \"name\" not set",
+			args:        []string{"repositories", "permissions", "revoke", "--user", "author1"},
+		},
+		{
+			name:        "missing user or role",
+			wantErrText: "at least one of the flags in the group [user role] is required",
+			args:        []string{"repositories", "permissions", "revoke", "--name", "MyRepo"},
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"repositories", "permissions", "revoke", "--name", "MyRepo", "--user", "author1", "--no-prompt"},
+		},
+		{
+			name:            "revoke some permissions",
+			args:            []string{"repositories", "permissions", "revoke", "--name", "MyRepo", "--user", "author1", "--permission", "upload"},
+			wantOutputRegex: "^Permissions successfully revoked.[\\s]*$",
+			httpServer:      httptest.NewServer(newHandler("PUT", `{"permissions":["read","run"]}`)),
+		},
+		{
+			name:            "revoke remaining permissions deletes access",
+			args:            []string{"repositories", "permissions", "revoke", "--name", "MyRepo", "--user", "author1", "--permission", "read,run,upload", "--no-prompt"},
+			wantOutputRegex: "^Permissions successfully revoked.[\\s]*$",
+			httpServer:      httptest.NewServer(newHandler("DELETE", "")),
+		},
+		{
+			name:            "revoke all access",
+			args:            []string{"repositories", "permissions", "revoke", "--name", "MyRepo", "--user", "author1", "--no-prompt", "--json"},
+			wantOutputRegex: "^{}[\\s]*$",
+			httpServer:      httptest.NewServer(newHandler("DELETE", "")),
+		},
+	}
+
+	runTests(cases, t)
+
+}
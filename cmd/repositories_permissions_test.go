@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRepositoriesPermissions(t *testing.T) {
+	responseV4 := `{
+		"items": [
+		  {
+			"type": "user",
+			"name": "author1",
+			"permissions": [
+			  "read",
+			  "run"
+			]
+		  },
+		  {
+			"type": "role",
+			"name": "fmeauthor",
+			"permissions": [
+			  "read",
+			  "run",
+			  "upload"
+			]
+		  }
+		],
+		"limit": -1,
+		"offset": -1,
+		"totalCount": 2
+	  }`
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"repositories", "permissions", "--name", "MyRepo", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flag",
+			wantErrText: "required flag(s) \"name\" not set",
+			args:        []string{"repositories", "permissions"},
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"repositories", "permissions", "--name", "MyRepo"},
+		},
+		{
+			name:        "repository not found",
+			statusCode:  http.StatusNotFound,
+			wantErrText: "404 Not Found: check that the specified repository exists",
+			args:        []string{"repositories", "permissions", "--name", "MyRepo"},
+		},
+		{
+			name:            "list permissions",
+			statusCode:      http.StatusOK,
+			body:            responseV4,
+			args:            []string{"repositories", "permissions", "--name", "MyRepo"},
+			wantOutputRegex: "^[\\s]*TYPE[\\s]*NAME[\\s]*PERMISSIONS[\\s]*user[\\s]*author1[\\s]*read, run[\\s]*role[\\s]*fmeauthor[\\s]*read, run, upload[\\s]*$",
+			wantURLContains: "/fmeapiv4/repositories/MyRepo/permissions",
+		},
+		{
+			name:            "list permissions no headers",
+			statusCode:      http.StatusOK,
+			body:            responseV4,
+			args:            []string{"repositories", "permissions", "--name", "MyRepo", "--no-headers"},
+			wantOutputRegex: "^[\\s]*user[\\s]*author1[\\s]*read, run[\\s]*role[\\s]*fmeauthor[\\s]*read, run, upload[\\s]*$",
+		},
+		{
+			name:           "list permissions json",
+			statusCode:     http.StatusOK,
+			body:           responseV4,
+			args:           []string{"repositories", "permissions", "--name", "MyRepo", "--json"},
+			wantOutputJson: responseV4,
+		},
+		{
+			name:            "list permissions custom columns",
+			statusCode:      http.StatusOK,
+			body:            responseV4,
+			args:            []string{"repositories", "permissions", "--name", "MyRepo", "--output=custom-columns=NAME:.name", "--no-headers"},
+			wantOutputRegex: "^[\\s]*author1[\\s]*fmeauthor[\\s]*$",
+		},
+	}
+
+	runTests(cases, t)
+
+}
@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type repositorySyncFlags struct {
+	repository string
+	dir        string
+	prune      bool
+	dryRun     bool
+	noprompt   bool
+	noHeaders  bool
+}
+
+type RepositorySyncAction struct {
+	Action    string `json:"action"`
+	Workspace string `json:"workspace"`
+	Reason    string `json:"reason"`
+	path      string
+}
+
+const (
+	syncActionCreate    = "create"
+	syncActionUpdate    = "update"
+	syncActionDelete    = "delete"
+	syncActionUnchanged = "unchanged"
+	syncActionSkip      = "skip"
+)
+
+// workspaces store the date they were last saved in the header of the file
+var lastSaveDateRegexp = regexp.MustCompile(`LAST_SAVE_DATE="([^"]+)"`)
+
+func newRepositorySyncCmd() *cobra.Command {
+	f := repositorySyncFlags{}
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Sync a repository with a local directory of workspaces.",
+		Long: `Sync a repository with the workspaces (.fmw files) in a local directory. The local workspaces are compared with the workspaces in the repository and a plan is printed before any changes are made.
+A workspace is considered changed if its last save date is different from the one in the repository. Otherwise, the workspace in the repository is downloaded and compared by hash, so that changes made outside of Workbench are found.
+New and changed workspaces are uploaded. Workspaces that only exist in the repository are deleted if --prune is specified.`,
+		Example: `
+  # Show what would change when syncing the "workspaces" directory to the repository "MyRepository"
+  fmeflow repositories sync --repository MyRepository --dir ./workspaces --dry-run
+
+  # Sync the "workspaces" directory to the repository "MyRepository", prompting before making changes
+  fmeflow repositories sync --repository MyRepository --dir ./workspaces
+
+  # Sync and delete workspaces from the repository that don't exist locally, with no confirmation
+  fmeflow repositories sync --repository MyRepository --dir ./workspaces --prune --no-prompt`,
+		Args: NoArgs,
+		RunE: repositorySyncRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.repository, "repository", "", "Name of the repository to sync.")
+	cmd.Flags().StringVar(&f.dir, "dir", "", "Local directory containing the workspaces to sync.")
+	cmd.Flags().BoolVar(&f.prune, "prune", false, "Delete workspaces from the repository that do not exist in the local directory.")
+	cmd.Flags().BoolVar(&f.dryRun, "dry-run", false, "Print the plan without making any changes.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation before applying the plan.")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.MarkFlagRequired("repository")
+	cmd.MarkFlagRequired("dir")
+	cmd.MarkFlagsMutuallyExclusive("dry-run", "no-prompt")
+	return cmd
+}
+
+func repositorySyncRun(f *repositorySyncFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		// find the local workspaces
+		entries, err := os.ReadDir(f.dir)
+		if err != nil {
+			return err
+		}
+		localWorkspaces := map[string]string{}
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".fmw") {
+				localWorkspaces[entry.Name()] = filepath.Join(f.dir, entry.Name())
+			}
+		}
+
+		remoteWorkspaces, err := getRepositoryWorkspaces(client, f.repository)
+		if err != nil {
+			return err
+		}
+
+		// build the plan
+		plan := []RepositorySyncAction{}
+		for name, path := range localWorkspaces {
+			remote, exists := remoteWorkspaces[name]
+			if !exists {
+				plan = append(plan, RepositorySyncAction{Action: syncActionCreate, Workspace: name, Reason: "new workspace", path: path})
+				continue
+			}
+
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			// a different last save date means the workspace has changed without downloading it. The same date
+			// doesn't mean it is unchanged, as editing the file outside of Workbench keeps the date
+			if match := lastSaveDateRegexp.FindSubmatch(contents); match != nil && !couldBeSameSaveDate(string(match[1]), remote.LastSaveDate) {
+				plan = append(plan, RepositorySyncAction{Action: syncActionUpdate, Workspace: name, Reason: "last save date changed", path: path})
+				continue
+			}
+
+			remoteHash, err := getRepositoryWorkspaceHash(client, f.repository, name)
+			if err != nil {
+				return err
+			}
+			localHash := sha256.Sum256(contents)
+			if bytes.Equal(localHash[:], remoteHash) {
+				plan = append(plan, RepositorySyncAction{Action: syncActionUnchanged, Workspace: name, Reason: "same contents", path: path})
+			} else {
+				plan = append(plan, RepositorySyncAction{Action: syncActionUpdate, Workspace: name, Reason: "contents changed", path: path})
+			}
+		}
+		for name := range remoteWorkspaces {
+			if _, exists := localWorkspaces[name]; !exists {
+				if f.prune {
+					plan = append(plan, RepositorySyncAction{Action: syncActionDelete, Workspace: name, Reason: "not in local directory"})
+				} else {
+					plan = append(plan, RepositorySyncAction{Action: syncActionSkip, Workspace: name, Reason: "not in local directory"})
+				}
+			}
+		}
+		sort.Slice(plan, func(i, j int) bool {
+			return plan[i].Workspace < plan[j].Workspace
+		})
+
+		changes := 0
+		for _, action := range plan {
+			if action.Action == syncActionCreate || action.Action == syncActionUpdate || action.Action == syncActionDelete {
+				changes++
+			}
+		}
+
+		if !jsonOutput {
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Action", "Workspace", "Reason"})
+
+			for _, action := range plan {
+				t.AppendRow(table.Row{action.Action, action.Workspace, action.Reason})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+		}
+
+		if f.dryRun || changes == 0 {
+			if jsonOutput {
+				return printSyncPlanJSON(cmd, plan)
+			}
+			if changes == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "Repository is already in sync.")
+			}
+			return nil
+		}
+
+		if !f.noprompt {
+			// prompt to confirm the changes
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: "Apply " + strconv.Itoa(changes) + " change(s) to repository " + f.repository + "?",
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		for _, action := range plan {
+			switch action.Action {
+			case syncActionCreate, syncActionUpdate:
+				url := "/fmeapiv4/repositories/" + f.repository + "/items"
+				if err := uploadRepositoryFile(client, viper.GetViper(), url, action.path, action.Action == syncActionUpdate); err != nil {
+					return fmt.Errorf("failed to upload %s: %w", action.Workspace, err)
+				}
+			case syncActionDelete:
+				request, err := buildFmeFlowRequest("/fmeapiv4/repositories/"+f.repository+"/items/"+action.Workspace, "DELETE", nil)
+				if err != nil {
+					return err
+				}
+				response, err := client.Do(&request)
+				if err != nil {
+					return err
+				} else if response.StatusCode != http.StatusNoContent {
+					return fmt.Errorf("failed to delete %s: %w", action.Workspace, parseResponseMessage(response))
+				}
+			}
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Repository successfully synced.")
+		} else {
+			return printSyncPlanJSON(cmd, plan)
+		}
+
+		return nil
+	}
+}
+
+// whether the last save date in a workspace could be the same as the one in the repository. Workbench
+// writes the date in the local time of whoever saved the workspace, so dates that differ by a time zone
+// offset could still be the same save
+func couldBeSameSaveDate(local string, remote time.Time) bool {
+	localDate, err := time.Parse("2006-01-02T15:04:05", local)
+	if err != nil {
+		return true
+	}
+	difference := localDate.Sub(remote.UTC().Truncate(time.Second))
+	if difference < 0 {
+		difference = -difference
+	}
+	return difference <= 14*time.Hour && difference%(15*time.Minute) == 0
+}
+
+func printSyncPlanJSON(cmd *cobra.Command, plan []RepositorySyncAction) error {
+	jsonData, err := json.Marshal(plan)
+	if err != nil {
+		return err
+	}
+	prettyJSON, err := prettyPrintJSON(jsonData)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+	return nil
+}
+
+// get all workspaces in a repository, keyed by name
+func getRepositoryWorkspaces(client *http.Client, repository string) (map[string]FMEFlowWorkspaceV4, error) {
+	workspaces := map[string]FMEFlowWorkspaceV4{}
+	limit := 100
+	offset := 0
+
+	for {
+		request, err := buildFmeFlowRequest("/fmeapiv4/workspaces", "GET", nil)
+		if err != nil {
+			return nil, err
+		}
+
+		q := request.URL.Query()
+		q.Add("repository", repository)
+		q.Add("limit", strconv.Itoa(limit))
+		q.Add("offset", strconv.Itoa(offset))
+		request.URL.RawQuery = q.Encode()
+
+		response, err := client.Do(&request)
+		if err != nil {
+			return nil, err
+		} else if response.StatusCode != http.StatusOK {
+			return nil, parseResponseMessage(response)
+		}
+
+		responseData, err := io.ReadAll(response.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		var result FMEFlowWorkspacesV4
+		if err := json.Unmarshal(responseData, &result); err != nil {
+			return nil, err
+		}
+
+		for _, workspace := range result.Items {
+			workspaces[workspace.Name] = workspace
+		}
+
+		if len(result.Items) < limit || offset+limit >= result.TotalCount {
+			break
+		}
+		offset += limit
+	}
+
+	return workspaces, nil
+}
+
+// download a workspace from the repository and return the sha256 hash of its contents
+func getRepositoryWorkspaceHash(client *http.Client, repository string, name string) ([]byte, error) {
+	request, err := buildFmeFlowRequest("/fmeapiv4/repositories/"+repository+"/items/"+name+"/download", "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Add("Accept", "application/octet-stream")
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return nil, err
+	} else if response.StatusCode != http.StatusOK {
+		if response.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: check that the specified repository and workspace exist", errors.New(response.Status))
+		}
+		return nil, parseResponseMessage(response)
+	}
+	defer response.Body.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, response.Body); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoriesSync(t *testing.T) {
+	dir, err := os.MkdirTemp("", "workspaces")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new.fmw"), []byte(`#! <WORKSPACE LAST_SAVE_DATE="2024-01-01T10:00:00">`), 0644))
+	// saved.fmw has been edited outside of Workbench, so it still has the last save date of the repository copy
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "saved.fmw"), []byte(`#! <WORKSPACE LAST_SAVE_DATE="2022-06-15T10:59:24"> edited`), 0644))
+	// offset.fmw was saved in a time zone seven hours behind UTC and hasn't changed
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "offset.fmw"), []byte(`#! <WORKSPACE LAST_SAVE_DATE="2022-06-15T03:59:24">`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "changed.fmw"), []byte(`#! <WORKSPACE LAST_SAVE_DATE="2024-02-01T10:00:00">`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "same.fmw"), []byte("same contents"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "readme.txt"), []byte("not a workspace"), 0644))
+
+	emptyDir, err := os.MkdirTemp("", "workspaces")
+	require.NoError(t, err)
+	defer os.RemoveAll(emptyDir)
+
+	workspacesBody := `{
+		"items": [
+		  {
+			"name": "saved.fmw",
+			"repositoryName": "MyRepo",
+			"lastSaveDate": "2022-06-15T10:59:24.000Z"
+		  },
+		  {
+			"name": "offset.fmw",
+			"repositoryName": "MyRepo",
+			"lastSaveDate": "2022-06-15T10:59:24.000Z"
+		  },
+		  {
+			"name": "changed.fmw",
+			"repositoryName": "MyRepo",
+			"lastSaveDate": "2022-06-15T10:59:24.000Z"
+		  },
+		  {
+			"name": "same.fmw",
+			"repositoryName": "MyRepo",
+			"lastSaveDate": "2022-06-15T10:59:24.000Z"
+		  },
+		  {
+			"name": "remoteOnly.fmw",
+			"repositoryName": "MyRepo",
+			"lastSaveDate": "2022-06-15T10:59:24.000Z"
+		  }
+		],
+		"limit": 100,
+		"offset": 0,
+		"totalCount": 5
+	  }`
+
+	uploaded := []string{}
+	deleted := []string{}
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/fmeapiv4/workspaces":
+			require.Equal(t, "MyRepo", r.URL.Query().Get("repository"))
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(workspacesBody))
+			require.NoError(t, err)
+		case r.Method == "GET" && r.URL.Path == "/fmeapiv4/repositories/MyRepo/items/saved.fmw/download":
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`#! <WORKSPACE LAST_SAVE_DATE="2022-06-15T10:59:24">`))
+			require.NoError(t, err)
+		case r.Method == "GET" && r.URL.Path == "/fmeapiv4/repositories/MyRepo/items/offset.fmw/download":
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`#! <WORKSPACE LAST_SAVE_DATE="2022-06-15T03:59:24">`))
+			require.NoError(t, err)
+		case r.Method == "GET" && r.URL.Path == "/fmeapiv4/repositories/MyRepo/items/same.fmw/download":
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte("same contents"))
+			require.NoError(t, err)
+		case r.Method == "POST" && r.URL.Path == "/fmeapiv4/repositories/MyRepo/items":
+			_, header, err := r.FormFile("file")
+			require.NoError(t, err)
+			uploaded = append(uploaded, header.Filename+":"+r.URL.Query().Get("overwrite"))
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == "DELETE" && r.URL.Path == "/fmeapiv4/repositories/MyRepo/items/remoteOnly.fmw":
+			deleted = append(deleted, "remoteOnly.fmw")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	emptyRepoBody := `{
+		"items": [],
+		"limit": 100,
+		"offset": 0,
+		"totalCount": 0
+	  }`
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"repositories", "sync", "--repository", "MyRepo", "--dir", dir, "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flag",
+			wantErrText: "required flag(s) \"dir\", \"repository\" not set",
+			args:        []string{"repositories", "sync"},
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"repositories", "sync", "--repository", "MyRepo", "--dir", dir, "--dry-run"},
+		},
+		{
+			name:            "dry run plan",
+			args:            []string{"repositories", "sync", "--repository", "MyRepo", "--dir", dir, "--dry-run"},
+			wantOutputRegex: "^[\\s]*ACTION[\\s]*WORKSPACE[\\s]*REASON[\\s]*update[\\s]*changed.fmw[\\s]*last save date changed[\\s]*create[\\s]*new.fmw[\\s]*new workspace[\\s]*unchanged[\\s]*offset.fmw[\\s]*same contents[\\s]*skip[\\s]*remoteOnly.fmw[\\s]*not in local directory[\\s]*unchanged[\\s]*same.fmw[\\s]*same contents[\\s]*update[\\s]*saved.fmw[\\s]*contents changed[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+		},
+		{
+			name:           "dry run plan json",
+			args:           []string{"repositories", "sync", "--repository", "MyRepo", "--dir", dir, "--dry-run", "--prune", "--json"},
+			wantOutputJson: `[{"action":"update","workspace":"changed.fmw","reason":"last save date changed"},{"action":"create","workspace":"new.fmw","reason":"new workspace"},{"action":"unchanged","workspace":"offset.fmw","reason":"same contents"},{"action":"delete","workspace":"remoteOnly.fmw","reason":"not in local directory"},{"action":"unchanged","workspace":"same.fmw","reason":"same contents"},{"action":"update","workspace":"saved.fmw","reason":"contents changed"}]`,
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+		},
+		{
+			name:            "already in sync",
+			statusCode:      http.StatusOK,
+			body:            emptyRepoBody,
+			args:            []string{"repositories", "sync", "--repository", "MyRepo", "--dir", emptyDir, "--no-headers"},
+			wantOutputRegex: "Repository is already in sync.[\\s]*$",
+		},
+		{
+			name:            "sync with prune",
+			args:            []string{"repositories", "sync", "--repository", "MyRepo", "--dir", dir, "--prune", "--no-prompt"},
+			wantOutputRegex: "Repository successfully synced.[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+		},
+	}
+
+	runTests(cases, t)
+
+	require.Equal(t, []string{"changed.fmw:true", "new.fmw:false", "saved.fmw:true"}, uploaded)
+	require.Equal(t, []string{"remoteOnly.fmw"}, deleted)
+}
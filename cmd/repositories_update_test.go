@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoriesUpdate(t *testing.T) {
+	repositoryBody := `{
+		"customFormatCount": 0,
+		"customTransformerCount": 0,
+		"description": "Old description",
+		"fileCount": 2,
+		"name": "MyRepo",
+		"owner": "admin",
+		"ownerID": "fb2dd313-e5cf-432e-a24a-814e46929ab7",
+		"sharable": true,
+		"templateCount": 0,
+		"totalFileSize": 1024,
+		"workspaceCount": 2
+	  }`
+
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fmeapiv4/repositories/MyRepo" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == "GET" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(repositoryBody))
+			require.NoError(t, err)
+			return
+		}
+		if r.Method == "PUT" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"description":"New description","sharable":true}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+
+	sharableHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(repositoryBody))
+			require.NoError(t, err)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"description":"Old description","sharable":false}`, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"repositories", "update", "--name", "MyRepo", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flag",
+			wantErrText: "required flag(s) \"name\" not set",
+			args:        []string{"repositories", "update"},
+		},
+		{
+			name:        "nothing to update",
+			wantErrText: "nothing to update. Specify at least one of --description or --sharable",
+			args:        []string{"repositories", "update", "--name", "MyRepo"},
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"repositories", "update", "--name", "MyRepo", "--description", "New description"},
+		},
+		{
+			name:        "repository not found",
+			statusCode:  http.StatusNotFound,
+			wantErrText: "404 Not Found: check that the specified repository exists",
+			args:        []string{"repositories", "update", "--name", "MyRepo", "--description", "New description"},
+		},
+		{
+			name:            "update description",
+			args:            []string{"repositories", "update", "--name", "MyRepo", "--description", "New description"},
+			wantOutputRegex: "[\\s]*NAME[\\s]*OWNER[\\s]*DESCRIPTION[\\s]*WORKSPACES[\\s]*MyRepo[\\s]*admin[\\s]*New description[\\s]*2[\\s]*",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+		},
+		{
+			name:            "update sharable",
+			args:            []string{"repositories", "update", "--name", "MyRepo", "--sharable=false", "--output", "custom-columns=NAME:.name,SHARABLE:.sharable", "--no-headers"},
+			wantOutputRegex: "^[\\s]*MyRepo[\\s]*false[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(sharableHttpServerHandler)),
+		},
+		{
+			name:            "update json output",
+			args:            []string{"repositories", "update", "--name", "MyRepo", "--description", "New description", "--json"},
+			wantOutputRegex: "\"description\": \"New description\"",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+		},
+	}
+
+	runTests(cases, t)
+
+}
@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type ResourceConnectionV4 struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+}
+
+type ResourceConnectionsV4 struct {
+	Items      []ResourceConnectionV4 `json:"items"`
+	Limit      int                    `json:"limit"`
+	Offset     int                    `json:"offset"`
+	TotalCount int                    `json:"totalCount"`
+}
+
+// a file or directory in a shared resource
+type ResourceItemV4 struct {
+	Name         string `json:"name"`
+	Path         string `json:"path"`
+	Type         string `json:"type"`
+	Size         int64  `json:"size"`
+	LastModified string `json:"lastModified"`
+}
+
+const (
+	resourceItemFile      = "FILE"
+	resourceItemDirectory = "DIRECTORY"
+)
+
+type resourcesFlags struct {
+	outputType string
+	noHeaders  bool
+}
+
+func newResourcesCmd() *cobra.Command {
+	f := resourcesFlags{}
+	cmd := &cobra.Command{
+		Use:   "resources",
+		Short: "List and manage the files in shared resources",
+		Long: `Lists the shared resources on FME Flow, such as FME_SHAREDRESOURCE_DATA and FME_SHAREDRESOURCE_BACKUP.
+Use the subcommands to list, upload, download, delete and move the files in a shared resource. Files and directories in a shared resource are given as RESOURCE:/path, for example FME_SHAREDRESOURCE_DATA:/staging/parcels.gdb.`,
+		Example: `
+  # List all shared resources
+  fmeflow resources
+
+  # List the files in the root of the data resource
+  fmeflow resources ls FME_SHAREDRESOURCE_DATA:/
+
+  # Upload a directory of source data
+  fmeflow resources upload ./parcels FME_SHAREDRESOURCE_DATA:/staging
+
+  # Download a backup
+  fmeflow resources download FME_SHAREDRESOURCE_BACKUP:/ServerConfigPackage.fsconfig .`,
+		Args: NoArgs,
+		RunE: resourcesRun(&f),
+	}
+
+	addResourcesListFlags(cmd, &f)
+	cmd.AddCommand(newResourcesListCmd())
+	cmd.AddCommand(newResourcesLsCmd())
+	cmd.AddCommand(newResourcesUploadCmd())
+	cmd.AddCommand(newResourcesDownloadCmd())
+	cmd.AddCommand(newResourcesRmCmd())
+	cmd.AddCommand(newResourcesMkdirCmd())
+	cmd.AddCommand(newResourcesMvCmd())
+	return cmd
+}
+
+func newResourcesListCmd() *cobra.Command {
+	f := resourcesFlags{}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List shared resources",
+		Long:  `Lists the shared resources on FME Flow. This is the same as running "fmeflow resources".`,
+		Example: `
+  # List all shared resources
+  fmeflow resources list
+
+  # Output just the names of the shared resources
+  fmeflow resources list --output=custom-columns=NAME:.name --no-headers`,
+		Args: NoArgs,
+		RunE: resourcesRun(&f),
+	}
+
+	addResourcesListFlags(cmd, &f)
+	return cmd
+}
+
+func addResourcesListFlags(cmd *cobra.Command, f *resourcesFlags) {
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+}
+
+func resourcesRun(f *resourcesFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		items, err := getAllItemsV4[ResourceConnectionV4](client, "/fmeapiv4/resources/connections")
+		if err != nil {
+			return err
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Name", "Display Name", "Type", "Description"})
+
+			for _, element := range items {
+				t.AppendRow(table.Row{element.Name, element.DisplayName, element.Type, element.Description})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			responseData, err := json.Marshal(ResourceConnectionsV4{Items: items, TotalCount: len(items), Limit: len(items)})
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			marshalledItems := [][]byte{}
+			for _, element := range items {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// a file or directory in a shared resource, given on the command line as RESOURCE:/path
+type resourcePath struct {
+	resource string
+	path     string
+}
+
+func (p resourcePath) String() string {
+	return p.resource + ":" + p.path
+}
+
+// the endpoint for the files in the shared resource
+func (p resourcePath) endpoint(parts ...string) string {
+	return "/fmeapiv4/resources/connections/" + url.PathEscape(p.resource) + "/" + strings.Join(parts, "/")
+}
+
+// the endpoint for the file or directory itself
+func (p resourcePath) itemEndpoint(parts ...string) string {
+	return p.endpoint(parts...) + "?path=" + url.QueryEscape(p.path)
+}
+
+func (p resourcePath) join(name string) resourcePath {
+	return resourcePath{resource: p.resource, path: path.Join(p.path, name)}
+}
+
+// parse an argument in the form RESOURCE:/path. A single letter before the colon is treated
+// as a Windows drive rather than a resource so that local paths aren't mistaken for resources.
+func parseResourcePath(arg string) (resourcePath, bool) {
+	resource, filePath, found := strings.Cut(arg, ":")
+	if !found || len(resource) < 2 || strings.ContainsAny(resource, `/\`) {
+		return resourcePath{}, false
+	}
+	return resourcePath{resource: resource, path: path.Join("/", filePath)}, true
+}
+
+// validate that the arguments at the given positions are resource paths
+func resourcePathArgs(names []string, remote ...int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) != len(names) {
+			cmd.Usage()
+			return fmt.Errorf("requires %s", strings.Join(names, " and "))
+		}
+		for _, i := range remote {
+			if _, ok := parseResourcePath(args[i]); !ok {
+				return fmt.Errorf("invalid resource path %q. Must be in the form RESOURCE:/path", args[i])
+			}
+		}
+		return nil
+	}
+}
+
+// get the file or directory at a path in a shared resource
+func getResourceItemV4(client *http.Client, p resourcePath) (ResourceItemV4, error) {
+	var result ResourceItemV4
+
+	request, err := buildFmeFlowRequest(p.itemEndpoint("item"), "GET", nil)
+	if err != nil {
+		return result, err
+	}
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return result, err
+	} else if response.StatusCode != http.StatusOK {
+		if response.StatusCode == http.StatusNotFound {
+			return result, fmt.Errorf("%w: check that %s exists", errors.New(response.Status), p)
+		}
+		return result, parseResponseMessage(response)
+	}
+
+	responseData, err := io.ReadAll(response.Body)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(responseData, &result)
+	return result, err
+}
+
+// list the contents of a directory in a shared resource
+func getResourceItemsV4(client *http.Client, p resourcePath) ([]ResourceItemV4, error) {
+	return getAllItemsV4[ResourceItemV4](client, p.itemEndpoint("items"))
+}
+
+// format a number of bytes for people to read
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// reports the progress of a file transfer as a percentage, writing a new line each time it changes
+// by at least 10% so that the output is still readable when it isn't going to a terminal
+type transferProgress struct {
+	out      io.Writer
+	action   string
+	name     string
+	total    int64
+	done     int64
+	reported int64
+}
+
+func newTransferProgress(out io.Writer, action string, name string, total int64, done int64) *transferProgress {
+	p := &transferProgress{out: out, action: action, name: name, total: total, done: done, reported: -1}
+	p.report()
+	return p
+}
+
+func (p *transferProgress) Write(b []byte) (int, error) {
+	p.done += int64(len(b))
+	p.report()
+	return len(b), nil
+}
+
+func (p *transferProgress) report() {
+	if p.out == nil {
+		return
+	}
+	percent := int64(100)
+	if p.total > 0 {
+		percent = p.done * 100 / p.total
+	}
+	if p.reported >= 0 && percent < 100 && percent-p.reported < 10 {
+		return
+	}
+	if percent == p.reported {
+		return
+	}
+	p.reported = percent
+	fmt.Fprintf(p.out, "%s %s: %d%% (%s of %s)\n", p.action, p.name, percent, formatBytes(p.done), formatBytes(p.total))
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+type resourcesDownloadFlags struct {
+	noProgress bool
+}
+
+func newResourcesDownloadCmd() *cobra.Command {
+	f := resourcesDownloadFlags{}
+	cmd := &cobra.Command{
+		Use:   "download RESOURCE:/path LOCAL_DIRECTORY",
+		Short: "Download files from a shared resource",
+		Long:  `Download a file, or a directory and everything in it, from a shared resource into a local directory. The local directory is created if it doesn't exist, and existing files with the same name are replaced.`,
+		Example: `
+  # Download a backup to the current directory
+  fmeflow resources download FME_SHAREDRESOURCE_BACKUP:/ServerConfigPackage.fsconfig .
+
+  # Download the results directory, creating ./output/results
+  fmeflow resources download FME_SHAREDRESOURCE_DATA:/results ./output`,
+		Args: resourcePathArgs([]string{"RESOURCE:/path", "LOCAL_DIRECTORY"}, 0),
+		RunE: resourcesDownloadRun(&f),
+	}
+	cmd.Flags().BoolVar(&f.noProgress, "no-progress", false, "Don't print the progress of each file.")
+	return cmd
+}
+
+func resourcesDownloadRun(f *resourcesDownloadFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		source, _ := parseResourcePath(args[0])
+		local := filepath.Clean(args[1])
+
+		var progress io.Writer
+		if !jsonOutput && !f.noProgress {
+			progress = cmd.ErrOrStderr()
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		item, err := getResourceItemV4(client, source)
+		if err != nil {
+			return err
+		}
+		if source.path == "/" {
+			// the root of a resource has no name to create locally
+			item.Name = source.resource
+		}
+
+		target := filepath.Join(local, item.Name)
+		files := 0
+		var total int64
+		if item.Type != resourceItemDirectory {
+			if err := os.MkdirAll(local, 0755); err != nil {
+				return err
+			}
+			if err := downloadResourceFile(client, source, item.Size, target, progress); err != nil {
+				return err
+			}
+			files, total = 1, item.Size
+		} else {
+			items, err := listResourceDirectory(client, source, true)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			for _, element := range items {
+				relative := strings.TrimPrefix(element.Path, source.path)
+				file := filepath.Join(target, filepath.FromSlash(relative))
+				if element.Type == resourceItemDirectory {
+					if err := os.MkdirAll(file, 0755); err != nil {
+						return err
+					}
+					continue
+				}
+				if err := downloadResourceFile(client, resourcePath{resource: source.resource, path: path.Clean(element.Path)}, element.Size, file, progress); err != nil {
+					return err
+				}
+				files++
+				total += element.Size
+			}
+		}
+
+		if !jsonOutput {
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully downloaded %d file(s) (%s) to %s.\n", files, formatBytes(total), target)
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
+
+func downloadResourceFile(client *http.Client, source resourcePath, size int64, file string, progress io.Writer) error {
+	request, err := buildFmeFlowRequest(source.itemEndpoint("download"), "GET", nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Add("Accept", "application/octet-stream")
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not download %s: %w", source, parseResponseMessage(response))
+	}
+
+	out, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	// use Copy so that it doesn't store the entire file in memory
+	var writer io.Writer = out
+	if progress != nil {
+		writer = io.MultiWriter(out, newTransferProgress(progress, "Downloading", source.String(), size, 0))
+	}
+	_, err = io.Copy(writer, response.Body)
+	return err
+}
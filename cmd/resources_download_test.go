@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourcesDownload(t *testing.T) {
+	dir := t.TempDir()
+
+	// the files and directories in the FME_SHAREDRESOURCE_DATA resource
+	stagingItem := `{"name": "staging", "path": "/staging", "type": "DIRECTORY", "lastModified": "2024-05-01T10:00:00Z"}`
+	parcelsItem := `{"name": "parcels.zip", "path": "/staging/parcels.zip", "type": "FILE", "size": 12, "lastModified": "2024-05-01T10:05:00Z"}`
+	roadsItem := `{"name": "roads", "path": "/staging/roads", "type": "DIRECTORY", "lastModified": "2024-05-01T10:10:00Z"}`
+	csvItem := `{"name": "a.csv", "path": "/staging/roads/a.csv", "type": "FILE", "size": 5, "lastModified": "2024-05-01T10:15:00Z"}`
+	itemBodies := map[string]string{
+		"/staging":             stagingItem,
+		"/staging/parcels.zip": parcelsItem,
+		"/staging/roads":       roadsItem,
+		"/staging/roads/a.csv": csvItem,
+	}
+	directoryBodies := map[string]string{
+		"/staging":       `{"items": [` + parcelsItem + `, ` + roadsItem + `], "limit": 2, "offset": 0, "totalCount": 2}`,
+		"/staging/roads": `{"items": [` + csvItem + `], "limit": 1, "offset": 0, "totalCount": 1}`,
+	}
+	downloadBodies := map[string]string{
+		"/staging/parcels.zip": "parcels data",
+		"/staging/roads/a.csv": "a,b,c",
+	}
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		itemBody, itemExists := itemBodies[r.URL.Query().Get("path")]
+		directoryBody, directoryExists := directoryBodies[r.URL.Query().Get("path")]
+		contents, fileExists := downloadBodies[r.URL.Query().Get("path")]
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/resources/connections/FME_SHAREDRESOURCE_DATA/item" && itemExists {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(itemBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/resources/connections/FME_SHAREDRESOURCE_DATA/items" && directoryExists {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(directoryBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/resources/connections/FME_SHAREDRESOURCE_DATA/download" && fileExists {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(contents))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:        "missing local directory",
+			args:        []string{"resources", "download", "FME_SHAREDRESOURCE_DATA:/staging"},
+			wantErrText: "requires RESOURCE:/path and LOCAL_DIRECTORY",
+		},
+		{
+			name:        "path does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"resources", "download", "FME_SHAREDRESOURCE_DATA:/missing.zip", dir},
+			wantErrText: "404 Not Found: check that FME_SHAREDRESOURCE_DATA:/missing.zip exists",
+		},
+		{
+			name:               "download file",
+			httpServer:         httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:               []string{"resources", "download", "FME_SHAREDRESOURCE_DATA:/staging/parcels.zip", filepath.Join(dir, "file")},
+			wantOutputRegex:    "^Successfully downloaded 1 file\\(s\\) \\(12 B\\) to " + filepath.Join(dir, "file", "parcels.zip") + ".\n$",
+			wantErrOutputRegex: "Downloading FME_SHAREDRESOURCE_DATA:/staging/parcels.zip: 100% \\(12 B of 12 B\\)\n$",
+			wantFileContents:   fileContents{file: filepath.Join(dir, "file", "parcels.zip"), contents: "parcels data"},
+		},
+		{
+			name:             "download directory",
+			httpServer:       httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:             []string{"resources", "download", "FME_SHAREDRESOURCE_DATA:/staging", filepath.Join(dir, "directory"), "--no-progress"},
+			wantOutputRegex:  "^Successfully downloaded 2 file\\(s\\) \\(17 B\\) to " + filepath.Join(dir, "directory", "staging") + ".\n$",
+			wantFileContents: fileContents{file: filepath.Join(dir, "directory", "staging", "roads", "a.csv"), contents: "a,b,c"},
+		},
+	}
+
+	runTests(cases, t)
+
+	contents, err := os.ReadFile(filepath.Join(dir, "directory", "staging", "parcels.zip"))
+	require.NoError(t, err)
+	require.Equal(t, "parcels data", string(contents))
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type ResourceItemsV4 struct {
+	Items      []ResourceItemV4 `json:"items"`
+	Limit      int              `json:"limit"`
+	Offset     int              `json:"offset"`
+	TotalCount int              `json:"totalCount"`
+}
+
+type resourcesLsFlags struct {
+	recursive  bool
+	outputType string
+	noHeaders  bool
+}
+
+func newResourcesLsCmd() *cobra.Command {
+	f := resourcesLsFlags{}
+	cmd := &cobra.Command{
+		Use:   "ls RESOURCE:/path",
+		Short: "List the files in a shared resource",
+		Long:  `Lists the files and directories at a path in a shared resource. Use --recursive to include the contents of every subdirectory.`,
+		Example: `
+  # List the files in the root of the data resource
+  fmeflow resources ls FME_SHAREDRESOURCE_DATA:/
+
+  # List every file under the staging directory
+  fmeflow resources ls FME_SHAREDRESOURCE_DATA:/staging --recursive
+
+  # Output just the paths of the backups
+  fmeflow resources ls FME_SHAREDRESOURCE_BACKUP:/ --output=custom-columns=PATH:.path --no-headers`,
+		Args: resourcePathArgs([]string{"RESOURCE:/path"}, 0),
+		RunE: resourcesLsRun(&f),
+	}
+	cmd.Flags().BoolVarP(&f.recursive, "recursive", "r", false, "List the contents of subdirectories.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	return cmd
+}
+
+func resourcesLsRun(f *resourcesLsFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		p, _ := parseResourcePath(args[0])
+
+		// set up http
+		client := &http.Client{}
+
+		item, err := getResourceItemV4(client, p)
+		if err != nil {
+			return err
+		}
+
+		items := []ResourceItemV4{item}
+		if item.Type == resourceItemDirectory {
+			items, err = listResourceDirectory(client, p, f.recursive)
+			if err != nil {
+				return err
+			}
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Name", "Type", "Size", "Last Modified"})
+
+			for _, element := range items {
+				// show paths relative to the listed directory so nested files can be told apart
+				name := strings.TrimPrefix(strings.TrimPrefix(element.Path, p.path), "/")
+				if name == "" {
+					name = element.Name
+				}
+				size := ""
+				if element.Type == resourceItemFile {
+					size = formatBytes(element.Size)
+				}
+				t.AppendRow(table.Row{name, element.Type, size, element.LastModified})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			responseData, err := json.Marshal(ResourceItemsV4{Items: items, TotalCount: len(items), Limit: len(items)})
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			marshalledItems := [][]byte{}
+			for _, element := range items {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// list a directory, and optionally everything under it, with each directory followed by its contents
+func listResourceDirectory(client *http.Client, p resourcePath, recursive bool) ([]ResourceItemV4, error) {
+	items, err := getResourceItemsV4(client, p)
+	if err != nil {
+		return nil, err
+	}
+	if !recursive {
+		return items, nil
+	}
+	result := []ResourceItemV4{}
+	for _, item := range items {
+		result = append(result, item)
+		if item.Type == resourceItemDirectory {
+			children, err := listResourceDirectory(client, p.join(item.Name), true)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, children...)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourcesLs(t *testing.T) {
+	// the files and directories in the FME_SHAREDRESOURCE_DATA resource
+	rootItem := `{"name": "", "path": "/", "type": "DIRECTORY"}`
+	stagingItem := `{"name": "staging", "path": "/staging", "type": "DIRECTORY", "lastModified": "2024-05-01T10:00:00Z"}`
+	parcelsItem := `{"name": "parcels.zip", "path": "/staging/parcels.zip", "type": "FILE", "size": 12, "lastModified": "2024-05-01T10:05:00Z"}`
+	roadsItem := `{"name": "roads", "path": "/staging/roads", "type": "DIRECTORY", "lastModified": "2024-05-01T10:10:00Z"}`
+	csvItem := `{"name": "a.csv", "path": "/staging/roads/a.csv", "type": "FILE", "size": 5, "lastModified": "2024-05-01T10:15:00Z"}`
+	itemBodies := map[string]string{
+		"/":                    rootItem,
+		"/staging":             stagingItem,
+		"/staging/parcels.zip": parcelsItem,
+		"/staging/roads":       roadsItem,
+		"/staging/roads/a.csv": csvItem,
+	}
+	directoryBodies := map[string]string{
+		"/":              `{"items": [` + stagingItem + `], "limit": 1, "offset": 0, "totalCount": 1}`,
+		"/staging":       `{"items": [` + parcelsItem + `, ` + roadsItem + `], "limit": 2, "offset": 0, "totalCount": 2}`,
+		"/staging/roads": `{"items": [` + csvItem + `], "limit": 1, "offset": 0, "totalCount": 1}`,
+	}
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		itemBody, itemExists := itemBodies[r.URL.Query().Get("path")]
+		directoryBody, directoryExists := directoryBodies[r.URL.Query().Get("path")]
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/resources/connections/FME_SHAREDRESOURCE_DATA/item" && itemExists {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(itemBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/resources/connections/FME_SHAREDRESOURCE_DATA/items" && directoryExists {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(directoryBody))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:        "missing path",
+			args:        []string{"resources", "ls"},
+			wantErrText: "requires RESOURCE:/path",
+		},
+		{
+			name:        "invalid path",
+			args:        []string{"resources", "ls", "/staging"},
+			wantErrText: "invalid resource path \"/staging\". Must be in the form RESOURCE:/path",
+		},
+		{
+			name:        "path does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"resources", "ls", "FME_SHAREDRESOURCE_DATA:/missing"},
+			wantErrText: "404 Not Found: check that FME_SHAREDRESOURCE_DATA:/missing exists",
+		},
+		{
+			name:            "list directory",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"resources", "ls", "FME_SHAREDRESOURCE_DATA:/staging"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*TYPE[\\s]*SIZE[\\s]*LAST MODIFIED[\\s]*parcels.zip[\\s]*FILE[\\s]*12 B[\\s]*2024-05-01T10:05:00Z[\\s]*roads[\\s]*DIRECTORY[\\s]*2024-05-01T10:10:00Z[\\s]*$",
+		},
+		{
+			name:            "list directory recursively",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"resources", "ls", "FME_SHAREDRESOURCE_DATA:/", "-r", "--output", "custom-columns=PATH:.path", "--no-headers"},
+			wantOutputRegex: "^[\\s]*/staging[\\s]*/staging/parcels.zip[\\s]*/staging/roads[\\s]*/staging/roads/a.csv[\\s]*$",
+		},
+		{
+			name:           "list file json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"resources", "ls", "FME_SHAREDRESOURCE_DATA:/staging/roads/a.csv", "--json"},
+			wantOutputJson: `{"items":[{"name":"a.csv","path":"/staging/roads/a.csv","type":"FILE","size":5,"lastModified":"2024-05-01T10:15:00Z"}],"limit":1,"offset":0,"totalCount":1}`,
+		},
+		{
+			name:        "unknown resource",
+			statusCode:  http.StatusNotFound,
+			args:        []string{"resources", "ls", "MISSING:/"},
+			wantErrText: "404 Not Found: check that MISSING:/ exists",
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+type NewResourceDirectoryV4 struct {
+	Path          string `json:"path"`
+	CreateParents bool   `json:"createParents"`
+}
+
+type resourcesMkdirFlags struct {
+	parents bool
+}
+
+func newResourcesMkdirCmd() *cobra.Command {
+	f := resourcesMkdirFlags{}
+	cmd := &cobra.Command{
+		Use:   "mkdir RESOURCE:/path",
+		Short: "Create a directory in a shared resource",
+		Long:  `Create a directory in a shared resource. Use --parents to also create any parent directories that don't exist.`,
+		Example: `
+  # Create the staging directory in the data resource
+  fmeflow resources mkdir FME_SHAREDRESOURCE_DATA:/staging
+
+  # Create a directory and its parents
+  fmeflow resources mkdir FME_SHAREDRESOURCE_DATA:/staging/2024/parcels --parents`,
+		Args: resourcePathArgs([]string{"RESOURCE:/path"}, 0),
+		RunE: resourcesMkdirRun(&f),
+	}
+	cmd.Flags().BoolVarP(&f.parents, "parents", "p", false, "Create any parent directories that don't exist.")
+	return cmd
+}
+
+func resourcesMkdirRun(f *resourcesMkdirFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		p, _ := parseResourcePath(args[0])
+
+		// set up http
+		client := &http.Client{}
+
+		if _, err := sendFmeFlowJSON(client, p.endpoint("directories"), "POST", NewResourceDirectoryV4{Path: p.path, CreateParents: f.parents}, http.StatusCreated); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Directory "+p.String()+" successfully created.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
+
+// create a directory, doing nothing if it already exists
+func createResourceDirectory(client *http.Client, p resourcePath, parents bool) error {
+	_, err := sendFmeFlowJSON(client, p.endpoint("directories"), "POST", NewResourceDirectoryV4{Path: p.path, CreateParents: parents}, http.StatusCreated, http.StatusConflict)
+	return err
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourcesMkdir(t *testing.T) {
+	// every directory that is created is recorded in requests
+	requests := []string{}
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/resources/connections/FME_SHAREDRESOURCE_DATA/directories" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var directory NewResourceDirectoryV4
+			require.NoError(t, json.Unmarshal(body, &directory))
+			if directory.Path == "/staging" {
+				w.WriteHeader(http.StatusConflict)
+				_, err = w.Write([]byte(`{"message": "The directory already exists."}`))
+				require.NoError(t, err)
+				return
+			}
+			requests = append(requests, string(body))
+			w.WriteHeader(http.StatusCreated)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:        "too many arguments",
+			args:        []string{"resources", "mkdir", "FME_SHAREDRESOURCE_DATA:/a", "FME_SHAREDRESOURCE_DATA:/b"},
+			wantErrText: "requires RESOURCE:/path",
+		},
+		{
+			name:        "directory already exists",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"resources", "mkdir", "FME_SHAREDRESOURCE_DATA:/staging"},
+			wantErrText: "The directory already exists.",
+		},
+		{
+			name:            "create directory",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"resources", "mkdir", "FME_SHAREDRESOURCE_DATA:/archive/2024", "--parents"},
+			wantOutputRegex: "^Directory FME_SHAREDRESOURCE_DATA:/archive/2024 successfully created.\n$",
+		},
+	}
+
+	runTests(cases, t)
+	require.Equal(t, []string{`{"path":"/archive/2024","createParents":true}`}, requests)
+}
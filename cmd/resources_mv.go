@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+type ResourceMoveV4 struct {
+	SourcePath      string `json:"sourcePath"`
+	DestinationPath string `json:"destinationPath"`
+}
+
+func newResourcesMvCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mv RESOURCE:/source RESOURCE:/destination",
+		Short: "Move or rename a file or directory in a shared resource",
+		Long:  `Move or rename a file or directory within a shared resource. To copy files between shared resources, download them and upload them to the other resource.`,
+		Example: `
+  # Rename a file
+  fmeflow resources mv FME_SHAREDRESOURCE_DATA:/staging/parcels.zip FME_SHAREDRESOURCE_DATA:/staging/parcels_2024.zip
+
+  # Move a directory
+  fmeflow resources mv FME_SHAREDRESOURCE_DATA:/staging/parcels FME_SHAREDRESOURCE_DATA:/archive/parcels`,
+		Args: resourcePathArgs([]string{"RESOURCE:/source", "RESOURCE:/destination"}, 0, 1),
+		RunE: resourcesMvRun,
+	}
+	return cmd
+}
+
+func resourcesMvRun(cmd *cobra.Command, args []string) error {
+	source, _ := parseResourcePath(args[0])
+	destination, _ := parseResourcePath(args[1])
+	if source.resource != destination.resource {
+		return errors.New("files can only be moved within a shared resource. Download the files and upload them to the other resource instead")
+	}
+	if source.path == "/" {
+		return errors.New("the root of a shared resource can't be moved")
+	}
+
+	// set up http
+	client := &http.Client{}
+
+	if _, err := sendFmeFlowJSON(client, source.endpoint("move"), "POST", ResourceMoveV4{SourcePath: source.path, DestinationPath: destination.path}, http.StatusOK, http.StatusNoContent); err != nil {
+		return err
+	}
+
+	if !jsonOutput {
+		fmt.Fprintln(cmd.OutOrStdout(), source.String()+" successfully moved to "+destination.String()+".")
+	} else {
+		fmt.Fprintln(cmd.OutOrStdout(), "{}")
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourcesMv(t *testing.T) {
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/resources/connections/FME_SHAREDRESOURCE_DATA/move" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"sourcePath":"/staging/parcels.zip","destinationPath":"/archive/parcels.zip"}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:        "destination not in a resource",
+			args:        []string{"resources", "mv", "FME_SHAREDRESOURCE_DATA:/staging", "./staging"},
+			wantErrText: "invalid resource path \"./staging\". Must be in the form RESOURCE:/path",
+		},
+		{
+			name:        "move between resources",
+			args:        []string{"resources", "mv", "FME_SHAREDRESOURCE_DATA:/staging", "FME_SHAREDRESOURCE_BACKUP:/staging"},
+			wantErrText: "files can only be moved within a shared resource. Download the files and upload them to the other resource instead",
+		},
+		{
+			name:        "server error",
+			statusCode:  http.StatusNotFound,
+			body:        `{"message": "The path /missing does not exist."}`,
+			args:        []string{"resources", "mv", "FME_SHAREDRESOURCE_DATA:/missing", "FME_SHAREDRESOURCE_DATA:/archive"},
+			wantErrText: "The path /missing does not exist.",
+		},
+		{
+			name:            "move file",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"resources", "mv", "FME_SHAREDRESOURCE_DATA:/staging/parcels.zip", "FME_SHAREDRESOURCE_DATA:/archive/parcels.zip"},
+			wantOutputRegex: "^FME_SHAREDRESOURCE_DATA:/staging/parcels.zip successfully moved to FME_SHAREDRESOURCE_DATA:/archive/parcels.zip.\n$",
+		},
+	}
+
+	runTests(cases, t)
+}
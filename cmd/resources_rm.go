@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+type resourcesRmFlags struct {
+	recursive bool
+	noprompt  bool
+}
+
+func newResourcesRmCmd() *cobra.Command {
+	f := resourcesRmFlags{}
+	cmd := &cobra.Command{
+		Use:   "rm RESOURCE:/path",
+		Short: "Delete a file or directory in a shared resource",
+		Long:  `Delete a file or directory in a shared resource. Use --recursive to delete a directory and everything in it.`,
+		Example: `
+  # Delete a file
+  fmeflow resources rm FME_SHAREDRESOURCE_DATA:/staging/parcels.zip
+
+  # Delete a directory and everything in it without prompting
+  fmeflow resources rm FME_SHAREDRESOURCE_DATA:/staging --recursive --no-prompt`,
+		Args: resourcePathArgs([]string{"RESOURCE:/path"}, 0),
+		RunE: resourcesRmRun(&f),
+	}
+	cmd.Flags().BoolVarP(&f.recursive, "recursive", "r", false, "Delete a directory and everything in it.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	return cmd
+}
+
+func resourcesRmRun(f *resourcesRmFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		p, _ := parseResourcePath(args[0])
+		if p.path == "/" {
+			return errors.New("the root of a shared resource can't be deleted")
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		item, err := getResourceItemV4(client, p)
+		if err != nil {
+			return err
+		}
+		if item.Type == resourceItemDirectory && !f.recursive {
+			return fmt.Errorf("%s is a directory. Use --recursive to delete it and everything in it", p)
+		}
+
+		if !f.noprompt {
+			// prompt to confirm deletion
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: "Are you sure you want to delete " + p.String() + "?",
+			}
+			if item.Type == resourceItemDirectory {
+				promptUser.Message = "Are you sure you want to delete " + p.String() + " and everything in it?"
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		if _, err := sendFmeFlowJSON(client, p.itemEndpoint("item"), "DELETE", nil, http.StatusNoContent, http.StatusOK); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), p.String()+" successfully deleted.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
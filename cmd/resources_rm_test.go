@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourcesRm(t *testing.T) {
+	// the files and directories in the FME_SHAREDRESOURCE_DATA resource
+	stagingItem := `{"name": "staging", "path": "/staging", "type": "DIRECTORY", "lastModified": "2024-05-01T10:00:00Z"}`
+	parcelsItem := `{"name": "parcels.zip", "path": "/staging/parcels.zip", "type": "FILE", "size": 12, "lastModified": "2024-05-01T10:05:00Z"}`
+	roadsItem := `{"name": "roads", "path": "/staging/roads", "type": "DIRECTORY", "lastModified": "2024-05-01T10:10:00Z"}`
+	csvItem := `{"name": "a.csv", "path": "/staging/roads/a.csv", "type": "FILE", "size": 5, "lastModified": "2024-05-01T10:15:00Z"}`
+	itemBodies := map[string]string{
+		"/staging":             stagingItem,
+		"/staging/parcels.zip": parcelsItem,
+		"/staging/roads":       roadsItem,
+		"/staging/roads/a.csv": csvItem,
+	}
+
+	// every item that is deleted is recorded in requests
+	requests := []string{}
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		itemBody, itemExists := itemBodies[r.URL.Query().Get("path")]
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/resources/connections/FME_SHAREDRESOURCE_DATA/item" && itemExists {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(itemBody))
+			require.NoError(t, err)
+		} else if r.Method == "DELETE" && r.URL.Path == "/fmeapiv4/resources/connections/FME_SHAREDRESOURCE_DATA/item" && itemExists {
+			requests = append(requests, r.URL.Query().Get("path"))
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:        "delete root",
+			args:        []string{"resources", "rm", "FME_SHAREDRESOURCE_DATA:/", "-r", "-y"},
+			wantErrText: "the root of a shared resource can't be deleted",
+		},
+		{
+			name:        "path does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"resources", "rm", "FME_SHAREDRESOURCE_DATA:/missing", "-y"},
+			wantErrText: "404 Not Found: check that FME_SHAREDRESOURCE_DATA:/missing exists",
+		},
+		{
+			name:        "delete directory without recursive",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"resources", "rm", "FME_SHAREDRESOURCE_DATA:/staging/roads", "-y"},
+			wantErrText: "FME_SHAREDRESOURCE_DATA:/staging/roads is a directory. Use --recursive to delete it and everything in it",
+		},
+		{
+			name:            "delete file",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"resources", "rm", "FME_SHAREDRESOURCE_DATA:/staging/parcels.zip", "-y"},
+			wantOutputRegex: "^FME_SHAREDRESOURCE_DATA:/staging/parcels.zip successfully deleted.\n$",
+		},
+		{
+			name:           "delete directory",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"resources", "rm", "FME_SHAREDRESOURCE_DATA:/staging/roads", "-r", "-y", "--json"},
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+	require.Equal(t, []string{"/staging/parcels.zip", "/staging/roads"}, requests)
+}
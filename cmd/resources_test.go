@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResources(t *testing.T) {
+	resourcesListBody := `{
+		"items": [
+		  {
+			"name": "FME_SHAREDRESOURCE_BACKUP",
+			"displayName": "Backup",
+			"description": "Location for FME Flow backups",
+			"type": "FILESYSTEM"
+		  },
+		  {
+			"name": "FME_SHAREDRESOURCE_DATA",
+			"displayName": "Data",
+			"description": "Location for source data",
+			"type": "FILESYSTEM"
+		  }
+		],
+		"limit": 2,
+		"offset": 0,
+		"totalCount": 2
+	  }`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/resources/connections" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(resourcesListBody))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"resources", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"resources"},
+		},
+		{
+			name:            "list resources",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"resources"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*DISPLAY NAME[\\s]*TYPE[\\s]*DESCRIPTION[\\s]*FME_SHAREDRESOURCE_BACKUP[\\s]*Backup[\\s]*FILESYSTEM[\\s]*Location for FME Flow backups[\\s]*FME_SHAREDRESOURCE_DATA[\\s]*Data[\\s]*FILESYSTEM[\\s]*Location for source data[\\s]*$",
+		},
+		{
+			name:           "list resources json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"resources", "list", "--json"},
+			wantOutputJson: resourcesListBody,
+		},
+		{
+			name:            "list resources custom columns",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"resources", "list", "--output", "custom-columns=NAME:.name", "--no-headers"},
+			wantOutputRegex: "^[\\s]*FME_SHAREDRESOURCE_BACKUP[\\s]*FME_SHAREDRESOURCE_DATA[\\s]*$",
+		},
+		{
+			name:        "invalid output",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"resources", "--output", "xml"},
+			wantErrText: "invalid output format specified",
+		},
+	}
+
+	runTests(cases, t)
+}
+
+func TestParseResourcePath(t *testing.T) {
+	p, ok := parseResourcePath("FME_SHAREDRESOURCE_DATA:/staging/")
+	require.True(t, ok)
+	require.Equal(t, resourcePath{resource: "FME_SHAREDRESOURCE_DATA", path: "/staging"}, p)
+
+	p, ok = parseResourcePath("FME_SHAREDRESOURCE_DATA:")
+	require.True(t, ok)
+	require.Equal(t, "/", p.path)
+
+	_, ok = parseResourcePath(`C:\data\parcels.zip`)
+	require.False(t, ok)
+
+	_, ok = parseResourcePath("./data/parcels.zip")
+	require.False(t, ok)
+}
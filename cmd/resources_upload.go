@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// an upload of a single file, sent in chunks so that it can be resumed if it is interrupted
+type ResourceUploadV4 struct {
+	ID           string `json:"id"`
+	Path         string `json:"path"`
+	Size         int64  `json:"size"`
+	LastModified string `json:"lastModified,omitempty"`
+	Received     int64  `json:"received"`
+}
+
+type NewResourceUploadV4 struct {
+	Path         string `json:"path"`
+	Size         int64  `json:"size"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+type resourcesUploadFlags struct {
+	chunkSize  int
+	noProgress bool
+}
+
+func newResourcesUploadCmd() *cobra.Command {
+	f := resourcesUploadFlags{}
+	cmd := &cobra.Command{
+		Use:   "upload LOCAL_PATH RESOURCE:/directory",
+		Short: "Upload files to a shared resource",
+		Long: `Upload a file, or a directory and everything in it, into a directory in a shared resource. Existing files with the same name are replaced.
+Files are sent in chunks. If an upload is interrupted, running the same command again resumes each unfinished file from the last chunk FME Flow received. A file that has changed since its upload started is uploaded again from the beginning.`,
+		Example: `
+  # Upload a file to the staging directory of the data resource
+  fmeflow resources upload parcels.zip FME_SHAREDRESOURCE_DATA:/staging
+
+  # Upload a directory, creating FME_SHAREDRESOURCE_DATA:/staging/parcels
+  fmeflow resources upload ./parcels FME_SHAREDRESOURCE_DATA:/staging
+
+  # Upload a large file in 64 MiB chunks
+  fmeflow resources upload imagery.tif FME_SHAREDRESOURCE_DATA:/ --chunk-size 64`,
+		Args: resourcePathArgs([]string{"LOCAL_PATH", "RESOURCE:/directory"}, 1),
+		RunE: resourcesUploadRun(&f),
+	}
+	cmd.Flags().IntVar(&f.chunkSize, "chunk-size", 8, "The size of each chunk sent to FME Flow, in MiB.")
+	cmd.Flags().BoolVar(&f.noProgress, "no-progress", false, "Don't print the progress of each file.")
+	return cmd
+}
+
+func resourcesUploadRun(f *resourcesUploadFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if f.chunkSize < 1 {
+			return errors.New("the chunk size must be at least 1 MiB")
+		}
+		chunkSize := int64(f.chunkSize) * 1024 * 1024
+
+		local := filepath.Clean(args[0])
+		destination, _ := parseResourcePath(args[1])
+
+		info, err := os.Stat(local)
+		if err != nil {
+			return err
+		}
+
+		var progress io.Writer
+		if !jsonOutput && !f.noProgress {
+			progress = cmd.ErrOrStderr()
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		target := destination.join(filepath.Base(local))
+		files := 0
+		var total int64
+		if !info.IsDir() {
+			if err := uploadResourceFile(client, local, target, info, chunkSize, progress); err != nil {
+				return err
+			}
+			files, total = 1, info.Size()
+		} else {
+			err = filepath.WalkDir(local, func(file string, entry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				relative, err := filepath.Rel(local, file)
+				if err != nil {
+					return err
+				}
+				remote := target.join(filepath.ToSlash(relative))
+				if entry.IsDir() {
+					return createResourceDirectory(client, remote, true)
+				}
+				info, err := entry.Info()
+				if err != nil {
+					return err
+				}
+				if err := uploadResourceFile(client, file, remote, info, chunkSize, progress); err != nil {
+					return err
+				}
+				files++
+				total += info.Size()
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if !jsonOutput {
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully uploaded %d file(s) (%s) to %s.\n", files, formatBytes(total), target)
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
+
+// upload a file in chunks, resuming an unfinished upload of the same file if there is one
+func uploadResourceFile(client *http.Client, file string, target resourcePath, info fs.FileInfo, chunkSize int64, progress io.Writer) error {
+	size := info.Size()
+	// the modification time is stored with the upload so that a file that changed isn't resumed
+	lastModified := info.ModTime().UTC().Format(time.RFC3339Nano)
+	upload, err := findResourceUpload(client, target, size, lastModified)
+	if err != nil {
+		return err
+	}
+	if upload.ID == "" {
+		responseData, err := sendFmeFlowJSON(client, target.endpoint("uploads"), "POST", NewResourceUploadV4{Path: target.path, Size: size, LastModified: lastModified}, http.StatusCreated)
+		if err != nil {
+			return fmt.Errorf("could not upload %s: %w", file, err)
+		}
+		if err := json.Unmarshal(responseData, &upload); err != nil {
+			return err
+		}
+	}
+
+	in, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if _, err := in.Seek(upload.Received, io.SeekStart); err != nil {
+		return err
+	}
+
+	var p *transferProgress
+	if progress != nil {
+		p = newTransferProgress(progress, "Uploading", target.String(), size, upload.Received)
+	}
+
+	buffer := make([]byte, chunkSize)
+	for offset := upload.Received; offset < size; {
+		n, err := io.ReadFull(in, buffer)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		if err := sendResourceChunk(client, target, upload.ID, buffer[:n], offset, size); err != nil {
+			return fmt.Errorf("could not upload %s: %w. Run the command again to resume the upload", file, err)
+		}
+		offset += int64(n)
+		if p != nil {
+			p.Write(buffer[:n])
+		}
+	}
+
+	if _, err := sendFmeFlowJSON(client, target.endpoint("uploads", upload.ID, "complete"), "POST", nil, http.StatusOK, http.StatusCreated, http.StatusNoContent); err != nil {
+		return fmt.Errorf("could not upload %s: %w", file, err)
+	}
+	return nil
+}
+
+// find an unfinished upload to the same path of a file with the same size and modification time
+func findResourceUpload(client *http.Client, target resourcePath, size int64, lastModified string) (ResourceUploadV4, error) {
+	uploads, err := getAllItemsV4[ResourceUploadV4](client, target.itemEndpoint("uploads"))
+	if err != nil {
+		return ResourceUploadV4{}, err
+	}
+	for _, upload := range uploads {
+		if upload.Path == target.path && upload.Size == size && upload.LastModified == lastModified && upload.Received <= size {
+			return upload, nil
+		}
+	}
+	return ResourceUploadV4{}, nil
+}
+
+func sendResourceChunk(client *http.Client, target resourcePath, id string, chunk []byte, offset int64, size int64) error {
+	request, err := buildFmeFlowRequest(target.endpoint("uploads", id), "PUT", bytes.NewReader(chunk))
+	if err != nil {
+		return err
+	}
+	request.Header.Add("Content-Type", "application/octet-stream")
+	request.Header.Add("Content-Range", "bytes "+strconv.FormatInt(offset, 10)+"-"+strconv.FormatInt(offset+int64(len(chunk))-1, 10)+"/"+strconv.FormatInt(size, 10))
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		return parseResponseMessage(response)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourcesUpload(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "big.bin"), []byte("0123456789"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "small.txt"), []byte("hello"), 0644))
+	source := filepath.Join(dir, "parcels")
+	require.NoError(t, os.MkdirAll(filepath.Join(source, "roads"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(source, "roads", "a.csv"), []byte("a,b,c"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "changed.bin"), []byte("9876543210"), 0644))
+	modified := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	for _, file := range []string{"big.bin", "small.txt", "changed.bin", filepath.Join("parcels", "roads", "a.csv")} {
+		require.NoError(t, os.Chtimes(filepath.Join(dir, file), modified, modified))
+	}
+
+	// every request that changes the resource is recorded in requests as the method, the endpoint and the body
+	requests := []string{}
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond.
+	// Unfinished uploads of big.bin and of an older version of changed.bin are waiting to be resumed
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		endpoint := strings.TrimPrefix(r.URL.Path, "/fmeapiv4/resources/connections/FME_SHAREDRESOURCE_DATA/")
+		if r.Method != "GET" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			request := r.Method + " " + endpoint
+			if contentRange := r.Header.Get("Content-Range"); contentRange != "" {
+				request += " " + contentRange
+			}
+			if len(body) != 0 {
+				request += " " + string(body)
+			}
+			requests = append(requests, request)
+		}
+
+		if r.Method == "GET" && endpoint == "uploads" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items": [{"id": "u0", "path": "/staging/big.bin", "size": 10, "lastModified": "2024-05-01T10:00:00Z", "received": 4}, {"id": "u2", "path": "/staging/changed.bin", "size": 10, "lastModified": "2024-04-30T09:00:00Z", "received": 4}], "totalCount": 2, "limit": 100, "offset": 0}`))
+			require.NoError(t, err)
+		} else if r.Method == "POST" && endpoint == "uploads" {
+			w.WriteHeader(http.StatusCreated)
+			_, err := w.Write([]byte(`{"id": "u1", "received": 0}`))
+			require.NoError(t, err)
+		} else if r.Method == "PUT" && (endpoint == "uploads/u0" || endpoint == "uploads/u1") {
+			w.WriteHeader(http.StatusNoContent)
+		} else if r.Method == "POST" && (endpoint == "uploads/u0/complete" || endpoint == "uploads/u1/complete") {
+			w.WriteHeader(http.StatusNoContent)
+		} else if r.Method == "POST" && endpoint == "directories" {
+			w.WriteHeader(http.StatusCreated)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:        "missing destination",
+			args:        []string{"resources", "upload", filepath.Join(dir, "small.txt")},
+			wantErrText: "requires LOCAL_PATH and RESOURCE:/directory",
+		},
+		{
+			name:        "local file does not exist",
+			args:        []string{"resources", "upload", filepath.Join(dir, "missing.txt"), "FME_SHAREDRESOURCE_DATA:/staging"},
+			wantErrText: "stat " + filepath.Join(dir, "missing.txt") + ": no such file or directory",
+		},
+		{
+			name:        "invalid chunk size",
+			args:        []string{"resources", "upload", filepath.Join(dir, "small.txt"), "FME_SHAREDRESOURCE_DATA:/staging", "--chunk-size", "0"},
+			wantErrText: "the chunk size must be at least 1 MiB",
+		},
+		{
+			name:               "upload file",
+			httpServer:         httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:               []string{"resources", "upload", filepath.Join(dir, "small.txt"), "FME_SHAREDRESOURCE_DATA:/staging"},
+			wantOutputRegex:    "^Successfully uploaded 1 file\\(s\\) \\(5 B\\) to FME_SHAREDRESOURCE_DATA:/staging/small.txt.\n$",
+			wantErrOutputRegex: "Uploading FME_SHAREDRESOURCE_DATA:/staging/small.txt: 0% \\(0 B of 5 B\\)\nUploading FME_SHAREDRESOURCE_DATA:/staging/small.txt: 100% \\(5 B of 5 B\\)\n",
+		},
+		{
+			name:            "resume unfinished upload",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"resources", "upload", filepath.Join(dir, "big.bin"), "FME_SHAREDRESOURCE_DATA:/staging", "--no-progress"},
+			wantOutputRegex: "^Successfully uploaded 1 file\\(s\\) \\(10 B\\) to FME_SHAREDRESOURCE_DATA:/staging/big.bin.\n$",
+		},
+		{
+			name:            "restart upload of changed file",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"resources", "upload", filepath.Join(dir, "changed.bin"), "FME_SHAREDRESOURCE_DATA:/staging", "--no-progress"},
+			wantOutputRegex: "^Successfully uploaded 1 file\\(s\\) \\(10 B\\) to FME_SHAREDRESOURCE_DATA:/staging/changed.bin.\n$",
+		},
+		{
+			name:           "upload directory",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"resources", "upload", source, "FME_SHAREDRESOURCE_DATA:/staging", "--json"},
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+
+	require.Equal(t, []string{
+		`POST uploads {"path":"/staging/small.txt","size":5,"lastModified":"2024-05-01T10:00:00Z"}`,
+		"PUT uploads/u1 bytes 0-4/5 hello",
+		"POST uploads/u1/complete",
+		"PUT uploads/u0 bytes 4-9/10 456789",
+		"POST uploads/u0/complete",
+		`POST uploads {"path":"/staging/changed.bin","size":10,"lastModified":"2024-05-01T10:00:00Z"}`,
+		"PUT uploads/u1 bytes 0-9/10 9876543210",
+		"POST uploads/u1/complete",
+		`POST directories {"path":"/staging/parcels","createParents":true}`,
+		`POST directories {"path":"/staging/parcels/roads","createParents":true}`,
+		`POST uploads {"path":"/staging/parcels/roads/a.csv","size":5,"lastModified":"2024-05-01T10:00:00Z"}`,
+		"PUT uploads/u1 bytes 0-4/5 a,b,c",
+		"POST uploads/u1/complete",
+	}, requests)
+}
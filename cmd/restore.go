@@ -35,6 +35,11 @@ type restoreFlags struct {
 	failureTopic       string
 	successTopic       string
 	overwrite          bool
+	include            []string
+	exclude            []string
+	interactive        bool
+	getSelectable      bool
+	noprompt           bool
 	apiVersion         apiVersionFlag
 }
 
@@ -49,7 +54,12 @@ func newRestoreCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "restore",
 		Short: "Restores the FME Server configuration from an import package",
-		Long:  "Restores the FME Server configuration from an import package",
+		Long: `Restores the FME Server configuration from an import package.
+To restore only some of the items in a local package:
+- Using the --include and --exclude flags will restore only the matching items. Items are given as a type, such as repositories, or a type and a name, such as repositories/Samples. Names can contain * wildcards. The workspaces in a repository are included and excluded with it.
+- Using the --interactive flag will prompt for the items to restore.
+- Using the --get-selectable flag will just output the items in the package, whether they already exist on FME Flow and what restoring them would do, without restoring anything.
+Before a selective restore, the same report is output and you are prompted to confirm, so that conflicts with existing items can be reviewed first. Use --no-prompt to restore without confirming. The report is built with the v4 API. Items that can't be looked up, such as subscriptions, publications and projects, are reported with an unknown action.`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 
 			if f.apiVersion == "" {
@@ -80,6 +90,11 @@ func newRestoreCmd() *cobra.Command {
 				f.file = "ServerConfigPackage.fsconfig"
 			}
 
+			// selecting items means reading the package, so it has to be a local file
+			if f.resource && (len(f.include) != 0 || len(f.exclude) != 0 || f.interactive || f.getSelectable) {
+				return errors.New("selecting items to restore requires a local file. Use \"fmeflow resources download\" to download the package from the shared resource first")
+			}
+
 			// in V3, if a failure topic or success topic is set, the restore needs to be of type "resource" as the upload endpoint doesn't support success and failure topics
 			if (f.failureTopic != "" || f.successTopic != "") && !f.resource && f.apiVersion == apiVersionFlagV3 {
 				return errors.New("in V3, setting a failure and/or success topic is only supported if restoring from a shared resource")
@@ -95,6 +110,15 @@ func newRestoreCmd() *cobra.Command {
   
   # Restore from a backup file stored in the Data resource folder (FME_SHAREDRESOURCE_DATA) named ServerConfigPackage.fsconfig and set a failure and success topic to notify, overwrite items if they already exist
   fmeflow restore --resource --resource-name FME_SHAREDRESOURCE_DATA --file ServerConfigPackage.fsconfig --failure-topic MY_FAILURE_TOPIC --success-topic MY_SUCCESS_TOPIC --overwrite
+
+  # List the items in a backup and whether they already exist, without restoring anything
+  fmeflow restore --file ServerConfigPackage.fsconfig --get-selectable
+
+  # Restore only the Samples repository and the connections from a backup, leaving out the users, without prompting
+  fmeflow restore --file ServerConfigPackage.fsconfig --include repositories/Samples --include connections --exclude users --no-prompt
+
+  # Choose the items to restore from a list
+  fmeflow restore --file ServerConfigPackage.fsconfig --interactive
   `,
 		Args: NoArgs,
 		RunE: restoreRun(&f),
@@ -109,7 +133,13 @@ func newRestoreCmd() *cobra.Command {
 	cmd.Flags().StringVar(&f.failureTopic, "failure-topic", "", "Topic to notify on failure of the import. Default is MIGRATION_ASYNC_JOB_FAILURE. Not supported when restoring from downloaded package in v3.")
 	cmd.Flags().StringVar(&f.successTopic, "success-topic", "", "Topic to notify on success of the import. Default is MIGRATION_ASYNC_JOB_SUCCESS. Not supported when restoring from downloaded package in v3.")
 	cmd.Flags().BoolVar(&f.overwrite, "overwrite", false, "Whether the system restore should overwrite items if they already exist.")
+	cmd.Flags().StringSliceVar(&f.include, "include", []string{}, "Restore only the items matching these types or type/name pairs, e.g. repositories/Samples. Can be given more than once.")
+	cmd.Flags().StringSliceVar(&f.exclude, "exclude", []string{}, "Don't restore the items matching these types or type/name pairs, e.g. users. Can be given more than once.")
+	cmd.Flags().BoolVar(&f.interactive, "interactive", false, "Prompt interactively for the items to restore.")
+	cmd.Flags().BoolVar(&f.getSelectable, "get-selectable", false, "Output the items in the package and what restoring them would do, without restoring anything.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation before restoring the selected items.")
 	cmd.Flags().Var(&f.apiVersion, "api-version", "The api version to use when contacting FME Server. Must be one of v3 or v4")
+	cmd.MarkFlagsMutuallyExclusive("interactive", "get-selectable")
 
 	return cmd
 }
@@ -117,6 +147,15 @@ func restoreRun(f *restoreFlags) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		client := &http.Client{}
 
+		if len(f.include) != 0 || len(f.exclude) != 0 || f.interactive || f.getSelectable {
+			selected, err := selectRestoreItems(cmd, client, f)
+			if err != nil || selected == "" {
+				return err
+			}
+			defer os.Remove(selected)
+			f.file = selected
+		}
+
 		url := ""
 		var request http.Request
 
@@ -0,0 +1,401 @@
+package cmd
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// an item in a backup and what restoring it would do to FME Flow. Exists is nil and the action is
+// unknown for items that can't be looked up
+type RestoreSelectableItem struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Exists *bool  `json:"exists"`
+	Action string `json:"action"`
+}
+
+// choose the items to restore from a package, report any conflicts with FME Flow and write the chosen
+// items to a new package. The path of the new package is returned, or an empty string if nothing
+// should be restored
+func selectRestoreItems(cmd *cobra.Command, client *http.Client, f *restoreFlags) (string, error) {
+	p, err := openPackage(f.file)
+	if err != nil {
+		return "", err
+	}
+	defer p.Close()
+
+	items, err := selectPackageItems(p.manifest.Items, f.include, f.exclude)
+	if err != nil {
+		return "", err
+	}
+
+	if f.interactive {
+		options := []string{}
+		for _, item := range items {
+			options = append(options, item.Type+"/"+item.Name)
+		}
+		var chosen []string
+		prompt := &survey.MultiSelect{
+			Message: "Select items to restore",
+			Options: options,
+			Default: options,
+		}
+		if err := survey.AskOne(prompt, &chosen); err != nil {
+			return "", err
+		}
+		selected := []PackageItem{}
+		for _, item := range items {
+			for _, name := range chosen {
+				if name == item.Type+"/"+item.Name {
+					selected = append(selected, item)
+					break
+				}
+			}
+		}
+		items = selected
+		if len(items) == 0 {
+			if !jsonOutput {
+				fmt.Fprintln(cmd.OutOrStdout(), "No items selected to restore.")
+			}
+			return "", nil
+		}
+	}
+
+	// an existing item is only replaced when overwriting in v4 or updating in v3
+	overwrite := f.overwrite
+	if f.apiVersion == apiVersionFlagV3 {
+		overwrite = f.importMode == "UPDATE"
+	}
+	report, err := restoreConflictReport(client, items, overwrite)
+	if err != nil {
+		return "", err
+	}
+	if f.apiVersion == apiVersionFlagV3 && (f.getSelectable || !jsonOutput) {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Warning: the conflict report is built with the v4 API and may not match what a restore with the v3 API does.")
+	}
+
+	if f.getSelectable || !jsonOutput {
+		if err := printRestoreReport(cmd, report); err != nil {
+			return "", err
+		}
+	}
+	if f.getSelectable {
+		return "", nil
+	}
+
+	if !f.noprompt {
+		// the report is there so that conflicts can be reviewed before anything is changed
+		confirm := false
+		promptUser := &survey.Confirm{
+			Message: "Restore " + strconv.Itoa(len(items)) + " item(s) to FME Flow?",
+		}
+		survey.AskOne(promptUser, &confirm)
+		if !confirm {
+			return "", nil
+		}
+	}
+
+	selected, err := os.CreateTemp("", "*-"+path.Base(f.file))
+	if err != nil {
+		return "", err
+	}
+	defer selected.Close()
+	if err := writeSelectedPackage(p, items, selected); err != nil {
+		os.Remove(selected.Name())
+		return "", err
+	}
+	return selected.Name(), nil
+}
+
+// select the items matching any of the include patterns, or all items if there are none, then remove
+// the items matching any of the exclude patterns. A pattern is a type, such as repositories, or a type
+// and a name, such as repositories/Samples, where the name can contain wildcards. Items stored inside
+// a selected item, such as the workspaces in a repository, are selected with it
+func selectPackageItems(items []PackageItem, include []string, exclude []string) ([]PackageItem, error) {
+	matches := func(patterns []string) (map[string]bool, error) {
+		matched := map[string]bool{}
+		for _, pattern := range patterns {
+			itemType, name, err := parsePackageItemPattern(pattern)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				if item.Type != itemType {
+					continue
+				}
+				if ok, _ := path.Match(name, item.Name); name != "" && !ok {
+					continue
+				}
+				matched[item.Type+"/"+item.Name] = true
+				for _, child := range items {
+					if isPackageItemInside(child, item) {
+						matched[child.Type+"/"+child.Name] = true
+					}
+				}
+			}
+		}
+		return matched, nil
+	}
+
+	included, err := matches(include)
+	if err != nil {
+		return nil, err
+	}
+	excluded, err := matches(exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := []PackageItem{}
+	for _, item := range sortedPackageItems(items) {
+		key := item.Type + "/" + item.Name
+		if (len(include) == 0 || included[key]) && !excluded[key] {
+			selected = append(selected, item)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, errors.New("no items in the package match --include and --exclude")
+	}
+	return selected, nil
+}
+
+// parse an item pattern in the form TYPE or TYPE/NAME. The type can be singular or plural
+func parsePackageItemPattern(pattern string) (string, string, error) {
+	itemType, name, _ := strings.Cut(pattern, "/")
+	for _, t := range packageItemTypes {
+		if itemType == t.singular || itemType == t.plural {
+			return t.singular, name, nil
+		}
+	}
+	types := []string{}
+	for _, t := range packageItemTypes {
+		types = append(types, t.plural)
+	}
+	return "", "", fmt.Errorf("invalid item %q. Must be in the form TYPE or TYPE/NAME, where TYPE is one of %s", pattern, strings.Join(types, ", "))
+}
+
+// whether the contents of an item are stored inside the contents of another item
+func isPackageItemInside(item PackageItem, parent PackageItem) bool {
+	return parent.Path != "" && item.Path != parent.Path && strings.HasPrefix(item.Path, strings.TrimSuffix(parent.Path, "/")+"/")
+}
+
+// write a package containing only the given items. Each file in the archive belongs to the item with the
+// longest path containing it, and files that don't belong to any item are always kept
+func writeSelectedPackage(p *fmeflowPackage, items []PackageItem, out io.Writer) error {
+	selected := map[string]bool{}
+	for _, item := range items {
+		selected[item.Type+"/"+item.Name] = true
+	}
+
+	// only the list of items changes, so every other key in the manifest and on each item is copied as is
+	contents, err := readPackageFile(p.file(packageManifestName))
+	if err != nil {
+		return err
+	}
+	var manifest map[string]json.RawMessage
+	if err := json.Unmarshal(contents, &manifest); err != nil {
+		return err
+	}
+	var manifestItems []json.RawMessage
+	if err := json.Unmarshal(manifest["items"], &manifestItems); err != nil {
+		return err
+	}
+	keptItems := []json.RawMessage{}
+	for _, raw := range manifestItems {
+		var item PackageItem
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return err
+		}
+		if selected[item.Type+"/"+item.Name] {
+			keptItems = append(keptItems, raw)
+		}
+	}
+	if manifest["items"], err = json.Marshal(keptItems); err != nil {
+		return err
+	}
+
+	w := zip.NewWriter(out)
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	manifestFile, err := w.Create(packageManifestName)
+	if err != nil {
+		return err
+	}
+	if _, err := manifestFile.Write(manifestData); err != nil {
+		return err
+	}
+
+	for _, f := range p.reader.File {
+		if f.Name == packageManifestName {
+			continue
+		}
+		owner := ""
+		ownerPath := ""
+		for _, item := range p.manifest.Items {
+			inside := item.Path != "" && (f.Name == item.Path || strings.HasPrefix(f.Name, strings.TrimSuffix(item.Path, "/")+"/"))
+			if inside && len(item.Path) > len(ownerPath) {
+				owner = item.Type + "/" + item.Name
+				ownerPath = item.Path
+			}
+		}
+		if owner != "" && !selected[owner] {
+			continue
+		}
+		if err := w.Copy(f); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// check which of the items already exist on FME Flow and what restoring them would do
+func restoreConflictReport(client *http.Client, items []PackageItem, overwrite bool) ([]RestoreSelectableItem, error) {
+	// the names of the existing items of each type, and of the workspaces in each repository
+	existing := map[string]map[string]bool{}
+	workspaces := map[string]map[string]bool{}
+
+	report := []RestoreSelectableItem{}
+	for _, item := range items {
+		var exists bool
+		if item.Type == "workspace" {
+			repository, name, _ := strings.Cut(item.Name, "/")
+			if _, ok := workspaces[repository]; !ok {
+				if _, ok := existing["repository"]; !ok {
+					names, err := getExistingItemNames(client, "repository")
+					if err != nil {
+						return nil, err
+					}
+					existing["repository"] = names
+				}
+				workspaces[repository] = map[string]bool{}
+				if existing["repository"][repository] {
+					items, err := getRepositoryWorkspaces(client, repository)
+					if err != nil {
+						return nil, err
+					}
+					for workspace := range items {
+						workspaces[repository][workspace] = true
+					}
+				}
+			}
+			exists = workspaces[repository][name]
+		} else {
+			if _, ok := existing[item.Type]; !ok {
+				names, err := getExistingItemNames(client, item.Type)
+				if err != nil {
+					return nil, err
+				}
+				existing[item.Type] = names
+			}
+			if existing[item.Type] == nil {
+				report = append(report, RestoreSelectableItem{Type: item.Type, Name: item.Name, Action: "unknown"})
+				continue
+			}
+			exists = existing[item.Type][item.Name]
+		}
+
+		result := RestoreSelectableItem{Type: item.Type, Name: item.Name, Exists: &exists, Action: "create"}
+		if exists {
+			result.Action = "skip"
+			if overwrite {
+				result.Action = "overwrite"
+			}
+		}
+		report = append(report, result)
+	}
+	return report, nil
+}
+
+// get the names of the items of a type on FME Flow. Types that can't be looked up, such as subscriptions,
+// publications and projects, return nil
+func getExistingItemNames(client *http.Client, itemType string) (map[string]bool, error) {
+	var found []string
+	var err error
+	switch itemType {
+	case "repository":
+		found, err = getItemNamesV4(client, "/fmeapiv4/repositories", func(r FMEFlowRepositoryV4) string { return r.Name })
+	case "connection":
+		found, err = getItemNamesV4(client, "/fmeapiv4/connections", func(c Connection) string { return c.Name })
+	case "automation":
+		found, err = getItemNamesV4(client, "/fmeapiv4/automations", func(a AutomationV4) string { return a.Name })
+	case "topic":
+		found, err = getItemNamesV4(client, "/fmeapiv4/topics", func(t TopicV4) string { return t.Name })
+	case "user":
+		found, err = getItemNamesV4(client, "/fmeapiv4/accounts", func(a account) string { return a.Name })
+	case "role":
+		found, err = getItemNamesV4(client, "/fmeapiv4/roles", func(r RoleV4) string { return r.Name })
+	case "schedule":
+		var schedules []ScheduleV4
+		schedules, err = getSchedulesV4(client, "")
+		for _, schedule := range schedules {
+			found = append(found, schedule.Category+"/"+schedule.Name)
+		}
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, name := range found {
+		names[name] = true
+	}
+	return names, nil
+}
+
+// get the names of every item at an endpoint
+func getItemNamesV4[T any](client *http.Client, endpoint string, name func(T) string) ([]string, error) {
+	items, err := getAllItemsV4[T](client, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, item := range items {
+		names = append(names, name(item))
+	}
+	return names, nil
+}
+
+func printRestoreReport(cmd *cobra.Command, report []RestoreSelectableItem) error {
+	if jsonOutput {
+		jsonData, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+		prettyJSON, err := prettyPrintJSON(jsonData)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		return nil
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(defaultStyle)
+
+	t.AppendHeader(table.Row{"Type", "Name", "Exists", "Action"})
+
+	for _, element := range report {
+		exists := "unknown"
+		if element.Exists != nil {
+			exists = strconv.FormatBool(*element.Exists)
+		}
+		t.AppendRow(table.Row{element.Type, element.Name, exists, element.Action})
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+	return nil
+}
@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreSelect(t *testing.T) {
+	dir := t.TempDir()
+	backup := writeTestPackage(t, dir, "backup.fsconfig", testPackageManifest, testPackageFiles)
+	// subscriptions and projects can't be looked up on FME Flow
+	uncheckedBackup := writeTestPackage(t, dir, "unchecked.fsconfig", `{
+  "fmeserver": {"version": "FME Flow 2024.1", "build": 24612},
+  "items": [
+    {"type": "repository", "name": "Samples", "path": "repositories/Samples"},
+    {"type": "subscription", "name": "ops-email"},
+    {"type": "project", "name": "Nightly", "path": "projects/Nightly"}
+  ]
+}`, nil)
+
+	usersListBody := `{
+	  "items": [
+	    {
+	      "id": "7d3b9d43-3b8a-4b52-9a1f-0c4a1d2f6e01",
+	      "name": "admin",
+	      "fullName": "Administrator",
+	      "email": "admin@example.com",
+	      "isSuperUser": true,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    },
+	    {
+	      "id": "c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02",
+	      "name": "jsmith",
+	      "fullName": "Jane Smith",
+	      "email": "jsmith@example.com",
+	      "isSuperUser": false,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    }
+	  ],
+	  "totalCount": 2,
+	  "limit": 2,
+	  "offset": 0
+	}`
+
+	// the items and files in every uploaded package are recorded in uploads
+	uploads := []string{}
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond.
+	// The Samples repository, its austinApartments.fmw workspace and the user jsmith already exist
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/repositories" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items": [{"name": "Samples", "owner": "admin"}], "totalCount": 1, "limit": 1, "offset": 0}`))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/workspaces" {
+			require.Equal(t, "Samples", r.URL.Query().Get("repository"))
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items": [{"name": "austinApartments.fmw"}], "totalCount": 1, "limit": 100, "offset": 0}`))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/connections" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items": [], "totalCount": 0, "limit": 0, "offset": 0}`))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(usersListBody))
+			require.NoError(t, err)
+		} else if r.Method == "POST" && r.URL.Path == "/fmeapiv4/migrations/restore/upload" {
+			file, _, err := r.FormFile("file")
+			require.NoError(t, err)
+			contents, err := io.ReadAll(file)
+			require.NoError(t, err)
+			reader, err := zip.NewReader(bytes.NewReader(contents), int64(len(contents)))
+			require.NoError(t, err)
+
+			files := []string{}
+			for _, f := range reader.File {
+				files = append(files, f.Name)
+				if f.Name == packageManifestName {
+					data, err := readPackageFile(f)
+					require.NoError(t, err)
+					var manifest PackageManifest
+					require.NoError(t, json.Unmarshal(data, &manifest))
+					items := []string{}
+					for _, item := range manifest.Items {
+						items = append(items, item.Type+"/"+item.Name)
+					}
+					uploads = append(uploads, "items: "+strings.Join(items, ", "))
+				}
+			}
+			uploads = append(uploads, "files: "+strings.Join(files, ", "))
+			w.WriteHeader(http.StatusAccepted)
+			_, err = w.Write([]byte(`{"id": 1}`))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:            "get selectable",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"restore", "--file", backup, "--get-selectable"},
+			wantOutputRegex: "^[\\s]*TYPE[\\s]*NAME[\\s]*EXISTS[\\s]*ACTION[\\s]*repository[\\s]*Samples[\\s]*true[\\s]*skip[\\s]*workspace[\\s]*Samples/austinApartments.fmw[\\s]*true[\\s]*skip[\\s]*workspace[\\s]*Samples/easyTranslator.fmw[\\s]*false[\\s]*create[\\s]*connection[\\s]*warehouse[\\s]*false[\\s]*create[\\s]*user[\\s]*jsmith[\\s]*true[\\s]*skip[\\s]*$",
+			fmeflowBuild:    26000,
+		},
+		{
+			name:       "get selectable json with overwrite",
+			httpServer: httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:       []string{"restore", "--file", backup, "--get-selectable", "--include", "repositories", "--overwrite", "--json"},
+			wantOutputJson: `[
+				{"type": "repository", "name": "Samples", "exists": true, "action": "overwrite"},
+				{"type": "workspace", "name": "Samples/austinApartments.fmw", "exists": true, "action": "overwrite"},
+				{"type": "workspace", "name": "Samples/easyTranslator.fmw", "exists": false, "action": "create"}
+			]`,
+			fmeflowBuild: 26000,
+		},
+		{
+			name:           "get selectable items that can't be checked",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"restore", "--file", uncheckedBackup, "--get-selectable", "--json"},
+			wantOutputJson: `[{"type": "repository", "name": "Samples", "exists": true, "action": "skip"}, {"type": "subscription", "name": "ops-email", "exists": null, "action": "unknown"}, {"type": "project", "name": "Nightly", "exists": null, "action": "unknown"}]`,
+			fmeflowBuild:   26000,
+		},
+		{
+			name:               "get selectable with the v3 api",
+			httpServer:         httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:               []string{"restore", "--file", uncheckedBackup, "--get-selectable", "--api-version", "v3", "--import-mode", "UPDATE"},
+			wantOutputRegex:    "^[\\s]*TYPE[\\s]*NAME[\\s]*EXISTS[\\s]*ACTION[\\s]*repository[\\s]*Samples[\\s]*true[\\s]*overwrite[\\s]*subscription[\\s]*ops-email[\\s]*unknown[\\s]*unknown[\\s]*project[\\s]*Nightly[\\s]*unknown[\\s]*unknown[\\s]*$",
+			wantErrOutputRegex: "^Warning: the conflict report is built with the v4 API and may not match what a restore with the v3 API does.\n$",
+			fmeflowBuild:       26000,
+		},
+		{
+			name:            "include a repository without one of its workspaces",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"restore", "--file", backup, "--include", "repositories/Samples", "--exclude", "workspaces/Samples/easy*", "-y"},
+			wantOutputRegex: "^[\\s]*TYPE[\\s]*NAME[\\s]*EXISTS[\\s]*ACTION[\\s]*repository[\\s]*Samples[\\s]*true[\\s]*skip[\\s]*workspace[\\s]*Samples/austinApartments.fmw[\\s]*true[\\s]*skip[\\s]*Restore task submitted with id: 1\\n$",
+			fmeflowBuild:    26000,
+		},
+		{
+			name:            "exclude users",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"restore", "--file", backup, "--exclude", "users", "-y"},
+			wantOutputRegex: "Restore task submitted with id: 1\\n$",
+			fmeflowBuild:    26000,
+		},
+		{
+			name:            "restore not confirmed",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"restore", "--file", backup, "--include", "connections"},
+			wantOutputRegex: "^[\\s]*TYPE[\\s]*NAME[\\s]*EXISTS[\\s]*ACTION[\\s]*connection[\\s]*warehouse[\\s]*false[\\s]*create[\\s]*$",
+			fmeflowBuild:    26000,
+		},
+		{
+			name:         "invalid item",
+			httpServer:   httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:         []string{"restore", "--file", backup, "--include", "widgets/Samples"},
+			wantErrText:  "invalid item \"widgets/Samples\". Must be in the form TYPE or TYPE/NAME, where TYPE is one of repositories, workspaces, connections, schedules, automations, topics, subscriptions, publications, users, roles, projects",
+			fmeflowBuild: 26000,
+		},
+		{
+			name:         "no matching items",
+			httpServer:   httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:         []string{"restore", "--file", backup, "--include", "schedules"},
+			wantErrText:  "no items in the package match --include and --exclude",
+			fmeflowBuild: 26000,
+		},
+		{
+			name:         "select from a shared resource",
+			args:         []string{"restore", "--resource", "--include", "repositories"},
+			wantErrText:  "selecting items to restore requires a local file. Use \"fmeflow resources download\" to download the package from the shared resource first",
+			fmeflowBuild: 26000,
+		},
+		{
+			name:         "interactive and get selectable",
+			args:         []string{"restore", "--file", backup, "--interactive", "--get-selectable"},
+			wantErrText:  "if any flags in the group [interactive get-selectable] are set none of the others can be; [get-selectable interactive] were all set",
+			fmeflowBuild: 26000,
+		},
+	}
+
+	runTests(cases, t)
+	// only the two confirmed restores upload a package
+	require.Equal(t, []string{
+		"items: repository/Samples, workspace/Samples/austinApartments.fmw",
+		"files: manifest.json, repositories/Samples/austinApartments.fmw",
+		"items: repository/Samples, workspace/Samples/austinApartments.fmw, workspace/Samples/easyTranslator.fmw, connection/warehouse",
+		"files: manifest.json, connections/warehouse.json, repositories/Samples/austinApartments.fmw, repositories/Samples/easyTranslator.fmw",
+	}, uploads)
+}
+
+func TestWriteSelectedPackage(t *testing.T) {
+	// keys the cli doesn't know about, on the manifest and on its items, are kept
+	backup := writeTestPackage(t, t.TempDir(), "backup.fsconfig", `{
+  "fmeserver": {"version": "FME Flow 2024.1", "build": 24612},
+  "exportedBy": "admin",
+  "options": {"includeCredentials": false},
+  "items": [
+    {"type": "repository", "name": "Samples", "path": "repositories/Samples", "sharing": "public"},
+    {"type": "workspace", "name": "Samples/austinApartments.fmw", "path": "repositories/Samples/austinApartments.fmw"},
+    {"type": "workspace", "name": "Samples/easyTranslator.fmw", "path": "repositories/Samples/easyTranslator.fmw"},
+    {"type": "connection", "name": "warehouse", "path": "connections/warehouse.json"}
+  ]
+}`, testPackageFiles)
+	p, err := openPackage(backup)
+	require.NoError(t, err)
+	defer p.Close()
+
+	var out bytes.Buffer
+	require.NoError(t, writeSelectedPackage(p, []PackageItem{p.manifest.Items[0], p.manifest.Items[1]}, &out))
+
+	reader, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	require.NoError(t, err)
+	files := []string{}
+	for _, f := range reader.File {
+		files = append(files, f.Name)
+	}
+	require.Equal(t, []string{"manifest.json", "repositories/Samples/austinApartments.fmw"}, files)
+	manifest, err := readPackageFile(reader.File[0])
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "fmeserver": {"version": "FME Flow 2024.1", "build": 24612},
+  "exportedBy": "admin",
+  "options": {"includeCredentials": false},
+  "items": [
+    {"type": "repository", "name": "Samples", "path": "repositories/Samples", "sharing": "public"},
+    {"type": "workspace", "name": "Samples/austinApartments.fmw", "path": "repositories/Samples/austinApartments.fmw"}
+  ]
+}`, string(manifest))
+}
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type RolesV4 struct {
+	Items      []RoleV4 `json:"items"`
+	Limit      int      `json:"limit"`
+	Offset     int      `json:"offset"`
+	TotalCount int      `json:"totalCount"`
+}
+
+type RoleV4 struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Permissions []PermissionV4 `json:"permissions"`
+}
+
+// a single action on a category of item, such as running workspaces, or on one item, such as reading the Samples repository
+type PermissionV4 struct {
+	Category string `json:"category"`
+	Item     string `json:"item,omitempty"`
+	Action   string `json:"action"`
+}
+
+type UpdateRoleV4 struct {
+	Description string         `json:"description"`
+	Permissions []PermissionV4 `json:"permissions"`
+}
+
+type rolesFlags struct {
+	name       string
+	outputType string
+	noHeaders  bool
+}
+
+func newRolesCmd() *cobra.Command {
+	f := rolesFlags{}
+	cmd := &cobra.Command{
+		Use:   "roles",
+		Short: "List and manage roles and their permissions",
+		Long: `Lists the roles on FME Flow with the permissions they grant. Pass in a name to get information on a specific role.
+Use the subcommands to create and delete roles and to grant and revoke their permissions. Permissions are given in the form category:action for a whole category, or category:item:action for a single item, for example repository:Samples:read.
+Use "fmeflow users roles" to give roles to users.`,
+		Example: `
+  # List all roles
+  fmeflow roles
+
+  # List the role "analysts"
+  fmeflow roles --name analysts
+
+  # Create a role that can run the workspaces in the Samples repository
+  fmeflow roles create --name analysts
+  fmeflow roles grant --role analysts --permission repository:Samples:read --permission repository:Samples:run`,
+		Args: NoArgs,
+		RunE: rolesRun(&f),
+	}
+
+	addRolesListFlags(cmd, &f)
+	cmd.AddCommand(newRolesListCmd())
+	cmd.AddCommand(newRoleCreateCmd())
+	cmd.AddCommand(newRoleDeleteCmd())
+	cmd.AddCommand(newRoleGrantCmd())
+	cmd.AddCommand(newRoleRevokeCmd())
+	return cmd
+}
+
+func newRolesListCmd() *cobra.Command {
+	f := rolesFlags{}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List roles",
+		Long:  `Lists the roles on FME Flow with the permissions they grant. This is the same as running "fmeflow roles".`,
+		Example: `
+  # List all roles
+  fmeflow roles list
+
+  # Output all roles in json format
+  fmeflow roles list --json`,
+		Args: NoArgs,
+		RunE: rolesRun(&f),
+	}
+
+	addRolesListFlags(cmd, &f)
+	return cmd
+}
+
+func addRolesListFlags(cmd *cobra.Command, f *rolesFlags) {
+	cmd.Flags().StringVar(&f.name, "name", "", "If specified, only the role with that name will be returned")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+}
+
+func rolesRun(f *rolesFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		var result RolesV4
+		var responseData []byte
+		if f.name == "" {
+			items, err := getAllItemsV4[RoleV4](client, "/fmeapiv4/roles")
+			if err != nil {
+				return err
+			}
+			result.Items = items
+			result.TotalCount = len(items)
+			result.Limit = len(items)
+			responseData, err = json.Marshal(result)
+			if err != nil {
+				return err
+			}
+		} else {
+			role, data, err := getRoleV4(client, f.name)
+			if err != nil {
+				return err
+			}
+			result.TotalCount = 1
+			result.Items = append(result.Items, role)
+			responseData = data
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Name", "Description", "Permissions"})
+
+			for _, element := range result.Items {
+				t.AppendRow(table.Row{element.Name, element.Description, formatPermissions(element.Permissions)})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			marshalledItems := [][]byte{}
+			for _, element := range result.Items {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// get a single role by name, along with the raw response
+func getRoleV4(client *http.Client, name string) (RoleV4, []byte, error) {
+	var result RoleV4
+
+	request, err := buildFmeFlowRequest("/fmeapiv4/roles/"+url.PathEscape(name), "GET", nil)
+	if err != nil {
+		return result, nil, err
+	}
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return result, nil, err
+	} else if response.StatusCode != http.StatusOK {
+		if response.StatusCode == http.StatusNotFound {
+			return result, nil, fmt.Errorf("%w: check that the role %s exists", errors.New(response.Status), name)
+		}
+		return result, nil, parseResponseMessage(response)
+	}
+
+	responseData, err := io.ReadAll(response.Body)
+	if err != nil {
+		return result, nil, err
+	}
+
+	err = json.Unmarshal(responseData, &result)
+	return result, responseData, err
+}
+
+// parse a permission in the form category:action or category:item:action
+func parsePermission(permission string) (PermissionV4, error) {
+	parts := strings.Split(permission, ":")
+	for _, part := range parts {
+		if part == "" {
+			parts = nil
+		}
+	}
+	switch len(parts) {
+	case 2:
+		return PermissionV4{Category: parts[0], Action: parts[1]}, nil
+	case 3:
+		return PermissionV4{Category: parts[0], Item: parts[1], Action: parts[2]}, nil
+	}
+	return PermissionV4{}, fmt.Errorf("invalid permission %q. Must be in the form category:action or category:item:action", permission)
+}
+
+func (p PermissionV4) String() string {
+	if p.Item == "" {
+		return p.Category + ":" + p.Action
+	}
+	return p.Category + ":" + p.Item + ":" + p.Action
+}
+
+func formatPermissions(permissions []PermissionV4) string {
+	formatted := []string{}
+	for _, permission := range permissions {
+		formatted = append(formatted, permission.String())
+	}
+	return strings.Join(formatted, ", ")
+}
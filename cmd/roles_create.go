@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+type roleCreateFlags struct {
+	name        string
+	description string
+	permissions []string
+}
+
+func newRoleCreateCmd() *cobra.Command {
+	f := roleCreateFlags{}
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a role",
+		Long:  `Create a role, optionally with the permissions it grants. Permissions are given in the form category:action or category:item:action.`,
+		Example: `
+  # Create a role named "analysts"
+  fmeflow roles create --name analysts --description "Analysts who run the sample workspaces"
+
+  # Create a role that can read and run the workspaces in the Samples repository
+  fmeflow roles create --name analysts --permission repository:Samples:read --permission repository:Samples:run`,
+		Args: NoArgs,
+		RunE: roleCreateRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the role to create.")
+	cmd.Flags().StringVar(&f.description, "description", "", "Description of the role.")
+	cmd.Flags().StringArrayVar(&f.permissions, "permission", []string{}, "A permission the role grants, in the form category:action or category:item:action. Can be passed in multiple times.")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func roleCreateRun(f *roleCreateFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		role := RoleV4{Name: f.name, Description: f.description, Permissions: []PermissionV4{}}
+		for _, permission := range f.permissions {
+			p, err := parsePermission(permission)
+			if err != nil {
+				return err
+			}
+			role.Permissions = append(role.Permissions, p)
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		if _, err := sendFmeFlowJSON(client, "/fmeapiv4/roles", "POST", role, http.StatusCreated); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Role successfully created.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolesCreate(t *testing.T) {
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/roles" {
+			w.WriteHeader(http.StatusCreated)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// creates a role with a permission
+	customHttpServerHandlerPermissions := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/roles" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"publishers","description":"Publish workspaces","permissions":[{"category":"repository","item":"Samples","action":"write"}]}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// creates a role with an empty list of permissions
+	customHttpServerHandlerNoPermissions := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/roles" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"viewers","description":"","permissions":[]}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	cases := []testCase{
+		{
+			name:        "missing flag",
+			args:        []string{"roles", "create"},
+			wantErrText: "required flag(s) \"name\" not set",
+		},
+		{
+			name:        "invalid permission",
+			args:        []string{"roles", "create", "--name", "publishers", "--permission", "repository"},
+			wantErrText: "invalid permission \"repository\". Must be in the form category:action or category:item:action",
+		},
+		{
+			name:            "create role",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerPermissions)),
+			args:            []string{"roles", "create", "--name", "publishers", "--description", "Publish workspaces", "--permission", "repository:Samples:write"},
+			wantOutputRegex: "^Role successfully created.\n$",
+		},
+		{
+			name:           "create role with no permissions",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandlerNoPermissions)),
+			args:           []string{"roles", "create", "--name", "viewers", "--json"},
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+}
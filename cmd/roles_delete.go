@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+type roleDeleteFlags struct {
+	name     string
+	noprompt bool
+}
+
+func newRoleDeleteCmd() *cobra.Command {
+	f := roleDeleteFlags{}
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a role",
+		Long:  `Delete a role. Users with the role lose the permissions it granted.`,
+		Example: `
+  # Delete the role "analysts"
+  fmeflow roles delete --name analysts
+
+  # Delete the role "analysts" with no confirmation
+  fmeflow roles delete --name analysts --no-prompt`,
+		Args: NoArgs,
+		RunE: roleDeleteRun(&f),
+	}
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the role to delete.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func roleDeleteRun(f *roleDeleteFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		if _, _, err := getRoleV4(client, f.name); err != nil {
+			return err
+		}
+
+		if !f.noprompt {
+			// prompt to confirm deletion
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: "Are you sure you want to delete the role " + f.name + "?",
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		if _, err := sendFmeFlowJSON(client, "/fmeapiv4/roles/"+url.PathEscape(f.name), "DELETE", nil, http.StatusNoContent, http.StatusOK); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Role successfully deleted.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolesDelete(t *testing.T) {
+	roleAnalystsBody := `{
+	  "name": "analysts",
+	  "description": "Run the sample workspaces",
+	  "permissions": [
+	    {
+	      "category": "repository",
+	      "item": "Samples",
+	      "action": "read"
+	    },
+	    {
+	      "category": "repository",
+	      "item": "Samples",
+	      "action": "run"
+	    }
+	  ]
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/roles/analysts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(roleAnalystsBody))
+			require.NoError(t, err)
+		} else if r.Method == "DELETE" && r.URL.Path == "/fmeapiv4/roles/analysts" {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:        "missing flag",
+			args:        []string{"roles", "delete"},
+			wantErrText: "required flag(s) \"name\" not set",
+		},
+		{
+			name:        "role does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"roles", "delete", "--name", "publishers", "-y"},
+			wantErrText: "404 Not Found: check that the role publishers exists",
+		},
+		{
+			name:            "delete role",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"roles", "delete", "--name", "analysts", "-y"},
+			wantOutputRegex: "^Role successfully deleted.\n$",
+		},
+	}
+
+	runTests(cases, t)
+}
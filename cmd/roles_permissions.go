@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/spf13/cobra"
+)
+
+type rolePermissionsFlags struct {
+	role        string
+	permissions []string
+}
+
+func newRoleGrantCmd() *cobra.Command {
+	f := rolePermissionsFlags{}
+	cmd := &cobra.Command{
+		Use:   "grant",
+		Short: "Grant permissions to a role",
+		Long:  `Grant permissions to a role. Permissions are given in the form category:action for a whole category, or category:item:action for a single item. Permissions the role already has are left unchanged.`,
+		Example: `
+  # Allow the role "analysts" to read the Samples repository
+  fmeflow roles grant --role analysts --permission repository:Samples:read
+
+  # Allow the role "analysts" to view all jobs and run the workspaces in the Samples repository
+  fmeflow roles grant --role analysts --permission jobs:access --permission repository:Samples:run`,
+		Args: NoArgs,
+		RunE: rolePermissionsRun(true, &f),
+	}
+	addRolePermissionsFlags(cmd, &f, "grant")
+	return cmd
+}
+
+func newRoleRevokeCmd() *cobra.Command {
+	f := rolePermissionsFlags{}
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "Revoke permissions from a role",
+		Long:  `Revoke permissions from a role. Permissions are given in the form category:action or category:item:action, exactly as they are listed by "fmeflow roles".`,
+		Example: `
+  # Stop the role "analysts" from running the workspaces in the Samples repository
+  fmeflow roles revoke --role analysts --permission repository:Samples:run`,
+		Args: NoArgs,
+		RunE: rolePermissionsRun(false, &f),
+	}
+	addRolePermissionsFlags(cmd, &f, "revoke")
+	return cmd
+}
+
+func addRolePermissionsFlags(cmd *cobra.Command, f *rolePermissionsFlags, action string) {
+	cmd.Flags().StringVar(&f.role, "role", "", "Name of the role to "+action+" permissions for.")
+	cmd.Flags().StringArrayVar(&f.permissions, "permission", []string{}, "The permission to "+action+", in the form category:action or category:item:action. Can be passed in multiple times.")
+	cmd.MarkFlagRequired("role")
+	cmd.MarkFlagRequired("permission")
+}
+
+func rolePermissionsRun(grant bool, f *rolePermissionsFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		permissions := []PermissionV4{}
+		for _, permission := range f.permissions {
+			p, err := parsePermission(permission)
+			if err != nil {
+				return err
+			}
+			permissions = append(permissions, p)
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		role, _, err := getRoleV4(client, f.role)
+		if err != nil {
+			return err
+		}
+
+		for _, permission := range permissions {
+			index := slices.Index(role.Permissions, permission)
+			if grant && index == -1 {
+				role.Permissions = append(role.Permissions, permission)
+			} else if !grant {
+				if index == -1 {
+					return fmt.Errorf("role %s does not have the permission %s", f.role, permission)
+				}
+				role.Permissions = slices.Delete(role.Permissions, index, index+1)
+			}
+		}
+
+		update := UpdateRoleV4{Description: role.Description, Permissions: role.Permissions}
+		if update.Permissions == nil {
+			update.Permissions = []PermissionV4{}
+		}
+		if _, err := sendFmeFlowJSON(client, "/fmeapiv4/roles/"+url.PathEscape(f.role), "PUT", update, http.StatusOK, http.StatusNoContent); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			if grant {
+				fmt.Fprintln(cmd.OutOrStdout(), "Permissions successfully granted to role "+f.role+".")
+			} else {
+				fmt.Fprintln(cmd.OutOrStdout(), "Permissions successfully revoked from role "+f.role+".")
+			}
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
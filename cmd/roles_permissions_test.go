@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolesGrantRevoke(t *testing.T) {
+	roleAnalystsBody := `{
+	  "name": "analysts",
+	  "description": "Run the sample workspaces",
+	  "permissions": [
+	    {
+	      "category": "repository",
+	      "item": "Samples",
+	      "action": "read"
+	    },
+	    {
+	      "category": "repository",
+	      "item": "Samples",
+	      "action": "run"
+	    }
+	  ]
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/roles/analysts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(roleAnalystsBody))
+			require.NoError(t, err)
+		} else if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/roles/analysts" {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// adds the new permission and keeps the one the role already has
+	customHttpServerHandlerGrant := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/roles/analysts" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"description":"Run the sample workspaces","permissions":[{"category":"repository","item":"Samples","action":"read"},{"category":"repository","item":"Samples","action":"run"},{"category":"jobs","action":"access"}]}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// removes the revoked permission
+	customHttpServerHandlerRevoke := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/roles/analysts" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"description":"Run the sample workspaces","permissions":[{"category":"repository","item":"Samples","action":"read"}]}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	cases := []testCase{
+		{
+			name:        "missing flags",
+			args:        []string{"roles", "grant"},
+			wantErrText: "required flag(s) \"permission\", \"role\" not set",
+		},
+		{
+			name:        "invalid permission",
+			args:        []string{"roles", "grant", "--role", "analysts", "--permission", "repository:Samples:read:now"},
+			wantErrText: "invalid permission \"repository:Samples:read:now\". Must be in the form category:action or category:item:action",
+		},
+		{
+			name:        "role does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"roles", "grant", "--role", "publishers", "--permission", "jobs:access"},
+			wantErrText: "404 Not Found: check that the role publishers exists",
+		},
+		{
+			name:            "grant permissions",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerGrant)),
+			args:            []string{"roles", "grant", "--role", "analysts", "--permission", "repository:Samples:read", "--permission", "jobs:access"},
+			wantOutputRegex: "^Permissions successfully granted to role analysts.\n$",
+		},
+		{
+			name:        "revoke permission the role does not have",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"roles", "revoke", "--role", "analysts", "--permission", "repository:Samples:write"},
+			wantErrText: "role analysts does not have the permission repository:Samples:write",
+		},
+		{
+			name:           "revoke permission",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandlerRevoke)),
+			args:           []string{"roles", "revoke", "--role", "analysts", "--permission", "repository:Samples:run", "--json"},
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoles(t *testing.T) {
+	rolesListBody := `{
+	  "items": [
+	    {
+	      "name": "analysts",
+	      "description": "Run the sample workspaces",
+	      "permissions": [
+	        {
+	          "category": "repository",
+	          "item": "Samples",
+	          "action": "read"
+	        },
+	        {
+	          "category": "repository",
+	          "item": "Samples",
+	          "action": "run"
+	        }
+	      ]
+	    },
+	    {
+	      "name": "auditors",
+	      "description": "View jobs and repositories",
+	      "permissions": [
+	        {
+	          "category": "jobs",
+	          "action": "access"
+	        },
+	        {
+	          "category": "repository",
+	          "action": "read"
+	        }
+	      ]
+	    }
+	  ],
+	  "limit": 2,
+	  "offset": 0,
+	  "totalCount": 2
+	}`
+
+	roleAnalystsBody := `{
+	  "name": "analysts",
+	  "description": "Run the sample workspaces",
+	  "permissions": [
+	    {
+	      "category": "repository",
+	      "item": "Samples",
+	      "action": "read"
+	    },
+	    {
+	      "category": "repository",
+	      "item": "Samples",
+	      "action": "run"
+	    }
+	  ]
+	}`
+
+	roleAuditorsBody := `{
+	  "name": "auditors",
+	  "description": "View jobs and repositories",
+	  "permissions": [
+	    {
+	      "category": "jobs",
+	      "action": "access"
+	    },
+	    {
+	      "category": "repository",
+	      "action": "read"
+	    }
+	  ]
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/roles" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(rolesListBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/roles/analysts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(roleAnalystsBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/roles/auditors" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(roleAuditorsBody))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"roles", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"roles"},
+		},
+		{
+			name:            "list roles",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"roles"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*DESCRIPTION[\\s]*PERMISSIONS[\\s]*analysts[\\s]*Run the sample workspaces[\\s]*repository:Samples:read, repository:Samples:run[\\s]*auditors[\\s]*View jobs and repositories[\\s]*jobs:access, repository:read[\\s]*$",
+		},
+		{
+			name:           "list roles json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"roles", "list", "--json"},
+			wantOutputJson: rolesListBody,
+		},
+		{
+			name:           "list single role",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"roles", "--name", "auditors", "--json"},
+			wantOutputJson: roleAuditorsBody,
+		},
+		{
+			name:        "role does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"roles", "--name", "publishers"},
+			wantErrText: "404 Not Found: check that the role publishers exists",
+		},
+		{
+			name:            "list roles custom columns",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"roles", "list", "--output", "custom-columns=NAME:.name", "--no-headers"},
+			wantOutputRegex: "^[\\s]*analysts[\\s]*auditors[\\s]*$",
+		},
+	}
+
+	runTests(cases, t)
+}
+
+func TestParsePermission(t *testing.T) {
+	p, err := parsePermission("repository:Samples:read")
+	require.NoError(t, err)
+	require.Equal(t, PermissionV4{Category: "repository", Item: "Samples", Action: "read"}, p)
+	require.Equal(t, "repository:Samples:read", p.String())
+
+	p, err = parsePermission("jobs:access")
+	require.NoError(t, err)
+	require.Equal(t, PermissionV4{Category: "jobs", Action: "access"}, p)
+
+	for _, invalid := range []string{"repository", "repository::read", "a:b:c:d"} {
+		_, err = parsePermission(invalid)
+		require.EqualError(t, err, "invalid permission \""+invalid+"\". Must be in the form category:action or category:item:action")
+	}
+}
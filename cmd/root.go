@@ -57,6 +57,19 @@ fmeflow login https://my-fmeflow.internal`,
 	cmds.AddCommand(newProjectsCmd())
 	cmds.AddCommand(newDeploymentParametersCmd())
 	cmds.AddCommand(newConnectionsCmd())
+	cmds.AddCommand(newApplyCmd())
+	cmds.AddCommand(newDiffCmd())
+	cmds.AddCommand(newExportCmd())
+	cmds.AddCommand(newQueuesCmd())
+	cmds.AddCommand(newSchedulesCmd())
+	cmds.AddCommand(newAutomationsCmd())
+	cmds.AddCommand(newNotificationsCmd())
+	cmds.AddCommand(newResourcesCmd())
+	cmds.AddCommand(newUsersCmd())
+	cmds.AddCommand(newRolesCmd())
+	cmds.AddCommand(newPermissionsCmd())
+	cmds.AddCommand(newOwnershipCmd())
+	cmds.AddCommand(newPackageCmd())
 	cmds.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
 		cmd.PrintErrln(err)
 		cmd.PrintErrln(cmd.UsageString())
@@ -10,6 +10,8 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -17,6 +19,7 @@ import (
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 type PublishedParameter struct {
@@ -108,6 +111,7 @@ type runFlags struct {
 	failureTopics          []string
 	publishedParameter     []string
 	listPublishedParameter []string
+	parametersFile         string
 	nodeManagerDirective   []string
 	directive              []string
 	queue                  string
@@ -141,7 +145,11 @@ func newRunCmd() *cobra.Command {
   fmeflow run --repository Samples --workspace austinApartments.fmw --wait --output="custom-columns=Time Requested:.timeRequested,Time Started:.timeStarted,Time Finished:.timeFinished"
 	
   # Upload a local file to use as the source data for the translation
-  fmeflow run --repository Samples --workspace austinApartments.fmw --file Landmarks-edited.sqlite --wait`,
+  fmeflow run --repository Samples --workspace austinApartments.fmw --file Landmarks-edited.sqlite --wait
+
+  # Submit a job with published parameters read from a yaml file
+  fmeflow workspaces describe --repository Samples --name austinDownload.fmw --output yaml > params.yaml
+  fmeflow run --repository Samples --workspace austinDownload.fmw --parameters-file params.yaml`,
 		Args: NoArgs,
 		RunE: runRun(&f),
 	}
@@ -151,6 +159,7 @@ func newRunCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&f.wait, "wait", false, "Submit job and wait for it to finish.")
 	cmd.Flags().StringArrayVar(&f.publishedParameter, "published-parameter", []string{}, "Published parameters defined for this workspace. Specify as Key=Value. Can be passed in multiple times. For list parameters, use the --list-published-parameter flag.")
 	cmd.Flags().StringArrayVar(&f.listPublishedParameter, "published-parameter-list", []string{}, "A List-type published parameters defined for this workspace. Specify as Key=Value1,Value2. Can be passed in multiple times.")
+	cmd.Flags().StringVar(&f.parametersFile, "parameters-file", "", "A yaml file of published parameters for this workspace. Use \"fmeflow workspaces describe --show parameters --output yaml\" to create a template. Parameters passed in with --published-parameter or --published-parameter-list override those in the file.")
 	cmd.Flags().StringVar(&f.sourceData, "file", "", "Upload a local file Source dataset to use to run the workspace. Note this causes the translation to run in synchonous mode whether the --wait flag is passed in or not. For v3 API only.")
 	cmd.Flags().BoolVar(&f.rtc, "run-until-canceled", false, "Runs a job until it is explicitly canceled. The job will run again regardless of whether the job completed successfully, failed, or the server crashed or was shut down. For v3 API only.")
 	cmd.Flags().StringVar(&f.description, "description", "", "Description of the request. For v3 API only.")
@@ -197,6 +206,13 @@ func runRun(f *runFlags) func(cmd *cobra.Command, args []string) error {
 		if jsonOutput {
 			f.outputType = "json"
 		}
+
+		if f.parametersFile != "" {
+			if err := loadParametersFile(f.parametersFile, &f.publishedParameter, &f.listPublishedParameter); err != nil {
+				return err
+			}
+		}
+
 		// set up http
 		client := &http.Client{
 			// set a long timeout for jobs that are long running.
@@ -204,6 +220,16 @@ func runRun(f *runFlags) func(cmd *cobra.Command, args []string) error {
 			Timeout: 604800 * time.Second,
 		}
 
+		// catch misspelled topics before the job runs, as the job would otherwise succeed silently without notifying anyone
+		topics := append(slices.Clone(f.successTopics), f.failureTopics...)
+		if viper.GetInt("build") >= 26018 {
+			if err := validateTopics(client, topics); err != nil {
+				return err
+			}
+		} else if err := validateTopicsV3(client, topics); err != nil {
+			return err
+		}
+
 		if viper.GetInt("build") >= 26018 {
 			var result JobResultV4
 			var responseData []byte
@@ -620,6 +646,57 @@ func runRun(f *runFlags) func(cmd *cobra.Command, args []string) error {
 	}
 }
 
+// read published parameters from a yaml file into the published parameter flags. Scalar
+// values become published parameters and sequences become list published parameters.
+// Parameters already passed in on the command line take precedence.
+func loadParametersFile(path string, publishedParameters *[]string, listPublishedParameters *[]string) error {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	parameters := map[string]interface{}{}
+	if err := yaml.Unmarshal(contents, &parameters); err != nil {
+		return fmt.Errorf("could not parse parameters file %s: %w", path, err)
+	}
+
+	// keep track of the parameters passed in on the command line
+	overridden := map[string]bool{}
+	for _, parameter := range append(slices.Clone(*publishedParameters), *listPublishedParameters...) {
+		overridden[strings.SplitN(parameter, "=", 2)[0]] = true
+	}
+
+	// sort the names so that parameters are always submitted in the same order
+	names := []string{}
+	for name := range parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if overridden[name] {
+			continue
+		}
+		switch value := parameters[name].(type) {
+		case []interface{}:
+			// escape any delimiters in the values so that they are not split
+			values := []string{}
+			for _, item := range value {
+				item := strings.ReplaceAll(fmt.Sprint(item), "\\", "\\\\")
+				values = append(values, strings.ReplaceAll(item, ",", "\\,"))
+			}
+			*listPublishedParameters = append(*listPublishedParameters, name+"="+strings.Join(values, ","))
+		case map[string]interface{}:
+			return fmt.Errorf("invalid value for parameter %s in parameters file %s. Must be a single value or a list", name, path)
+		case nil:
+			*publishedParameters = append(*publishedParameters, name+"=")
+		default:
+			*publishedParameters = append(*publishedParameters, name+"="+fmt.Sprint(value))
+		}
+	}
+	return nil
+}
+
 // split a string on delimiter, unless it is escaped
 func splitEscapedString(s string, delimiter rune) []string {
 	var result []string
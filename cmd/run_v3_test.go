@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -35,6 +38,55 @@ func TestRun(t *testing.T) {
 	err = os.WriteFile(f.Name(), []byte(dataFileContents), 0644)
 	require.NoError(t, err)
 
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmerest/v3/notifications/topics" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`[{"name":"SUCCESS_TOPIC","description":""},{"name":"FAILURE_TOPIC","description":""}]`))
+			require.NoError(t, err)
+		} else if r.Method == "POST" && r.URL.Path == "/fmerest/v3/transformations/submit/Samples/austinApartments.fmw" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(responseV3ASync))
+			require.NoError(t, err)
+		} else if r.Method == "POST" && r.URL.Path == "/fmerest/v3/transformations/transactdata/Samples/austinApartments.fmw" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(responseV3Sync))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// the job is submitted with the failure topic
+	customHttpServerHandlerFailureTopic := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmerest/v3/transformations/submit/Samples/austinApartments.fmw" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Regexp(t, regexp.MustCompile(".*\"NMDirectives\".*:[\\s]*{.*\"failureTopics\":\\[\"FAILURE_TOPIC\"\\].*"), string(body))
+		} else if r.Method == "POST" && r.URL.Path == "/fmerest/v3/transformations/transactdata/Samples/austinApartments.fmw" {
+			require.Equal(t, "FAILURE_TOPIC", r.URL.Query().Get("opt_failuretopics"))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// the job is submitted with the success topic
+	customHttpServerHandlerSuccessTopic := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmerest/v3/transformations/submit/Samples/austinApartments.fmw" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Regexp(t, regexp.MustCompile(".*\"NMDirectives\".*:[\\s]*{.*\"successTopics\":\\[\"SUCCESS_TOPIC\"\\].*"), string(body))
+		} else if r.Method == "POST" && r.URL.Path == "/fmerest/v3/transformations/transactdata/Samples/austinApartments.fmw" {
+			require.Equal(t, "SUCCESS_TOPIC", r.URL.Query().Get("opt_successtopics"))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// the job should not be submitted when one of its topics does not exist
+	customHttpServerHandlerMissingTopic := func(w http.ResponseWriter, r *http.Request) {
+		require.NotEqual(t, "POST", r.Method, "the job should not have been submitted")
+		customHttpServerHandler(w, r)
+	}
+
 	cases := []testCase{
 		{
 			name:               "unknown flag",
@@ -96,19 +148,21 @@ func TestRun(t *testing.T) {
 		},
 		{
 			name:            "failure topic flag async",
-			statusCode:      http.StatusOK,
-			body:            responseV3ASync,
 			args:            []string{"run", "--repository", "Samples", "--workspace", "austinApartments.fmw", "--failure-topic", "FAILURE_TOPIC"},
 			wantOutputRegex: "^[\\s]*Job submitted with id: 1[\\s]*$",
-			wantBodyRegEx:   ".*\"NMDirectives\".*:[\\s]*{.*\"failureTopics\":\\[\"FAILURE_TOPIC\"\\].*",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerFailureTopic)),
 		},
 		{
 			name:            "success topic flag async",
-			statusCode:      http.StatusOK,
-			body:            responseV3ASync,
 			args:            []string{"run", "--repository", "Samples", "--workspace", "austinApartments.fmw", "--success-topic", "SUCCESS_TOPIC"},
 			wantOutputRegex: "^[\\s]*Job submitted with id: 1[\\s]*$",
-			wantBodyRegEx:   ".*\"NMDirectives\".*:[\\s]*{.*\"successTopics\":\\[\"SUCCESS_TOPIC\"\\].*",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerSuccessTopic)),
+		},
+		{
+			name:        "topic that does not exist",
+			args:        []string{"run", "--repository", "Samples", "--workspace", "austinApartments.fmw", "--success-topic", "SUCCESS_TOPIC", "--failure-topic", "MISSING_TOPIC"},
+			wantErrText: "topic MISSING_TOPIC does not exist. Use \"fmeflow notifications topics create\" to create it",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandlerMissingTopic)),
 		},
 		{
 			name:            "node manager directive flag async",
@@ -204,19 +258,15 @@ func TestRun(t *testing.T) {
 		},
 		{
 			name:            "failure topic flag transact data",
-			statusCode:      http.StatusOK,
-			body:            responseV3Sync,
 			args:            []string{"run", "--repository", "Samples", "--workspace", "austinApartments.fmw", "--failure-topic", "FAILURE_TOPIC", "--file", f.Name()},
 			wantOutputRegex: "^[\\s]*ID[\\s]*STATUS[\\s]*STATUS MESSAGE[\\s]*FEATURES OUTPUT[\\s]*1[\\s]*SUCCESS[\\s]*Translation Successful[\\s]*1539[\\s]*$",
-			wantFormParams:  map[string]string{"opt_failuretopics": "FAILURE_TOPIC"},
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerFailureTopic)),
 		},
 		{
 			name:            "success topic flag transact data",
-			statusCode:      http.StatusOK,
-			body:            responseV3Sync,
 			args:            []string{"run", "--repository", "Samples", "--workspace", "austinApartments.fmw", "--success-topic", "SUCCESS_TOPIC", "--file", f.Name()},
 			wantOutputRegex: "^[\\s]*ID[\\s]*STATUS[\\s]*STATUS MESSAGE[\\s]*FEATURES OUTPUT[\\s]*1[\\s]*SUCCESS[\\s]*Translation Successful[\\s]*1539[\\s]*$",
-			wantFormParams:  map[string]string{"opt_successtopics": "SUCCESS_TOPIC"},
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerSuccessTopic)),
 		},
 		{
 			name:            "tag flag transact data",
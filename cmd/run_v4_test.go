@@ -1,11 +1,25 @@
 package cmd
 
 import (
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestRunV4(t *testing.T) {
+	// create a parameters file
+	dir := t.TempDir()
+	parametersFile := filepath.Join(dir, "params.yaml")
+	require.NoError(t, os.WriteFile(parametersFile, []byte("COORDSYS: TX83-CF\nTHEMES:\n  - railroad\n  - airports\n"), 0644))
+	badParametersFile := filepath.Join(dir, "bad.yaml")
+	require.NoError(t, os.WriteFile(badParametersFile, []byte("COORDSYS:\n  nested: value\n"), 0644))
+
 	responseV4ASync := `{
 		"id": 1
 	}`
@@ -22,6 +36,47 @@ func TestRunV4(t *testing.T) {
 		"timeStarted": "2023-02-04T00:16:28Z"
 	}`
 
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/topics" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items":[{"name":"SUCCESS_TOPIC","description":""},{"name":"FAILURE_TOPIC","description":""}],"totalCount":2,"limit":100,"offset":0}`))
+			require.NoError(t, err)
+		} else if r.Method == "POST" && r.URL.Path == "/fmeapiv4/jobs" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(responseV4ASync))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// the job is submitted with the failure topic
+	customHttpServerHandlerFailureTopic := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/jobs" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Regexp(t, regexp.MustCompile(".*\"failureTopics\":\\[\"FAILURE_TOPIC\"\\].*"), string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// the job is submitted with the success topic
+	customHttpServerHandlerSuccessTopic := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/jobs" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Regexp(t, regexp.MustCompile(".*\"successTopics\":\\[\"SUCCESS_TOPIC\"\\].*"), string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// the job should not be submitted when one of its topics does not exist
+	customHttpServerHandlerMissingTopic := func(w http.ResponseWriter, r *http.Request) {
+		require.NotEqual(t, "/fmeapiv4/jobs", r.URL.Path, "the job should not have been submitted")
+		customHttpServerHandler(w, r)
+	}
+
 	cases := []testCase{
 		{
 			name:               "unknown flag",
@@ -80,22 +135,25 @@ func TestRunV4(t *testing.T) {
 		},
 		{
 			name:            "failure topic flag async",
-			statusCode:      http.StatusOK,
-			body:            responseV4ASync,
 			args:            []string{"run", "--repository", "Samples", "--workspace", "austinApartments.fmw", "--failure-topic", "FAILURE_TOPIC"},
 			wantOutputRegex: "^[\\s]*Job submitted with id: 1[\\s]*$",
-			wantBodyRegEx:   ".*\"failureTopics\":\\[\"FAILURE_TOPIC\"\\].*",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerFailureTopic)),
 			fmeflowBuild:    26018,
 		},
 		{
 			name:            "success topic flag async",
-			statusCode:      http.StatusOK,
-			body:            responseV4ASync,
 			args:            []string{"run", "--repository", "Samples", "--workspace", "austinApartments.fmw", "--success-topic", "SUCCESS_TOPIC"},
 			wantOutputRegex: "^[\\s]*Job submitted with id: 1[\\s]*$",
-			wantBodyRegEx:   ".*\"successTopics\":\\[\"SUCCESS_TOPIC\"\\].*",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerSuccessTopic)),
 			fmeflowBuild:    26018,
 		},
+		{
+			name:         "topic that does not exist",
+			args:         []string{"run", "--repository", "Samples", "--workspace", "austinApartments.fmw", "--success-topic", "SUCCESS_TOPIC", "--failure-topic", "MISSING_TOPIC"},
+			wantErrText:  "topic MISSING_TOPIC does not exist. Use \"fmeflow notifications topics create\" to create it",
+			httpServer:   httptest.NewServer(http.HandlerFunc(customHttpServerHandlerMissingTopic)),
+			fmeflowBuild: 26018,
+		},
 		{
 			name:            "directive flag async",
 			statusCode:      http.StatusOK,
@@ -219,6 +277,30 @@ func TestRunV4(t *testing.T) {
 			wantBodyRegEx:   ".*\"publishedParameters\":{.*\"COORDSYS\":\"TX83-CF\".*\"THEMES\":\\[\"railroad\",\"airports\"\\].*}.*",
 			fmeflowBuild:    26018,
 		},
+		{
+			name:            "parameters file",
+			statusCode:      http.StatusOK,
+			body:            responseV4ASync,
+			args:            []string{"run", "--repository", "Samples", "--workspace", "austinApartments.fmw", "--parameters-file", parametersFile},
+			wantOutputRegex: "^[\\s]*Job submitted with id: 1[\\s]*$",
+			wantBodyRegEx:   ".*\"publishedParameters\":{.*\"COORDSYS\":\"TX83-CF\".*\"THEMES\":\\[\"railroad\",\"airports\"\\].*}.*",
+			fmeflowBuild:    26018,
+		},
+		{
+			name:            "parameters file overridden by flag",
+			statusCode:      http.StatusOK,
+			body:            responseV4ASync,
+			args:            []string{"run", "--repository", "Samples", "--workspace", "austinApartments.fmw", "--parameters-file", parametersFile, "--published-parameter", "COORDSYS=UTM83-14"},
+			wantOutputRegex: "^[\\s]*Job submitted with id: 1[\\s]*$",
+			wantBodyRegEx:   ".*\"publishedParameters\":{.*\"COORDSYS\":\"UTM83-14\".*}.*",
+			fmeflowBuild:    26018,
+		},
+		{
+			name:         "invalid parameters file",
+			args:         []string{"run", "--repository", "Samples", "--workspace", "austinApartments.fmw", "--parameters-file", badParametersFile},
+			wantErrText:  "invalid value for parameter COORDSYS in parameters file " + badParametersFile + ". Must be a single value or a list",
+			fmeflowBuild: 26018,
+		},
 	}
 	runTests(cases, t)
 }
@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type ScheduleV4 struct {
+	Name                string                 `json:"name"`
+	Category            string                 `json:"category"`
+	Description         string                 `json:"description"`
+	Enabled             bool                   `json:"enabled"`
+	Owner               string                 `json:"owner"`
+	Recurrence          ScheduleRecurrenceV4   `json:"recurrence"`
+	Repository          string                 `json:"repository"`
+	Workspace           string                 `json:"workspace"`
+	PublishedParameters map[string]interface{} `json:"publishedParameters,omitempty"`
+	NextRun             *time.Time             `json:"nextRun,omitempty"`
+}
+
+// when a schedule runs. Either a cron expression or an interval in seconds is set
+type ScheduleRecurrenceV4 struct {
+	Cron     string     `json:"cron,omitempty"`
+	Interval int        `json:"interval,omitempty"`
+	Start    *time.Time `json:"start,omitempty"`
+	End      *time.Time `json:"end,omitempty"`
+}
+
+type SchedulesV4 struct {
+	Offset     int          `json:"offset"`
+	Limit      int          `json:"limit"`
+	TotalCount int          `json:"totalCount"`
+	Items      []ScheduleV4 `json:"items"`
+}
+
+type NewScheduleV4 struct {
+	Name                string                 `json:"name"`
+	Category            string                 `json:"category"`
+	Description         string                 `json:"description"`
+	Enabled             bool                   `json:"enabled"`
+	Recurrence          ScheduleRecurrenceV4   `json:"recurrence"`
+	Repository          string                 `json:"repository"`
+	Workspace           string                 `json:"workspace"`
+	PublishedParameters map[string]interface{} `json:"publishedParameters,omitempty"`
+}
+
+type schedulesFlags struct {
+	category   string
+	outputType string
+	noHeaders  bool
+}
+
+func newSchedulesCmd() *cobra.Command {
+	f := schedulesFlags{}
+	cmd := &cobra.Command{
+		Use:   "schedules",
+		Short: "List and manage schedules",
+		Long: `Lists the schedules on FME Flow. Schedules run a workspace on a recurring basis, either from a cron expression or at a fixed interval.
+Use the subcommands to describe, create, update, delete, enable, disable and run schedules, and to export and import them as yaml to promote them between environments.`,
+		Example: `
+  # List all schedules
+  fmeflow schedules
+
+  # List the schedules in the category "Nightly"
+  fmeflow schedules --category Nightly
+
+  # Output the names of schedules that are enabled
+  fmeflow schedules --output=custom-columns=NAME:.name,ENABLED:.enabled`,
+		Args: NoArgs,
+		RunE: schedulesRun(&f),
+	}
+
+	addSchedulesListFlags(cmd, &f)
+	cmd.AddCommand(newScheduleListCmd())
+	cmd.AddCommand(newScheduleDescribeCmd())
+	cmd.AddCommand(newScheduleCreateCmd())
+	cmd.AddCommand(newScheduleUpdateCmd())
+	cmd.AddCommand(newScheduleDeleteCmd())
+	cmd.AddCommand(newScheduleEnableCmd())
+	cmd.AddCommand(newScheduleDisableCmd())
+	cmd.AddCommand(newScheduleRunNowCmd())
+	cmd.AddCommand(newScheduleExportCmd())
+	cmd.AddCommand(newScheduleImportCmd())
+	return cmd
+}
+
+func newScheduleListCmd() *cobra.Command {
+	f := schedulesFlags{}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List schedules",
+		Long:  `Lists the schedules on FME Flow. This is the same as running "fmeflow schedules".`,
+		Example: `
+  # List all schedules
+  fmeflow schedules list
+
+  # List the schedules in the category "Nightly" in json format
+  fmeflow schedules list --category Nightly --json`,
+		Args: NoArgs,
+		RunE: schedulesRun(&f),
+	}
+
+	addSchedulesListFlags(cmd, &f)
+	return cmd
+}
+
+func addSchedulesListFlags(cmd *cobra.Command, f *schedulesFlags) {
+	cmd.Flags().StringVar(&f.category, "category", "", "If specified, only schedules in this category will be returned.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+}
+
+func schedulesRun(f *schedulesFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		schedules, err := getSchedulesV4(client, f.category)
+		if err != nil {
+			return err
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Name", "Category", "Enabled", "Recurrence", "Workspace"})
+
+			for _, element := range schedules {
+				t.AppendRow(table.Row{element.Name, element.Category, element.Enabled, element.Recurrence.String(), element.Repository + "/" + element.Workspace})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			responseData, err := json.Marshal(SchedulesV4{Items: schedules, TotalCount: len(schedules), Limit: len(schedules)})
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			// we have to marshal the Items array, then create an array of marshalled items
+			// to pass to the creation of the table.
+			marshalledItems := [][]byte{}
+			for _, element := range schedules {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// get every schedule, or only those in a category if one is given
+func getSchedulesV4(client *http.Client, category string) ([]ScheduleV4, error) {
+	endpoint := "/fmeapiv4/schedules"
+	if category != "" {
+		endpoint += "?category=" + url.QueryEscape(category)
+	}
+	return getAllItemsV4[ScheduleV4](client, endpoint)
+}
+
+// get a single schedule, along with the raw response
+func getScheduleV4(client *http.Client, category string, name string) (ScheduleV4, []byte, error) {
+	var result ScheduleV4
+
+	request, err := buildFmeFlowRequest(scheduleEndpoint(category, name), "GET", nil)
+	if err != nil {
+		return result, nil, err
+	}
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return result, nil, err
+	} else if response.StatusCode != http.StatusOK {
+		if response.StatusCode == http.StatusNotFound {
+			return result, nil, fmt.Errorf("%w: check that the specified schedule and category exist", errors.New(response.Status))
+		}
+		return result, nil, parseResponseMessage(response)
+	}
+
+	responseData, err := io.ReadAll(response.Body)
+	if err != nil {
+		return result, nil, err
+	}
+
+	err = json.Unmarshal(responseData, &result)
+	return result, responseData, err
+}
+
+func scheduleEndpoint(category string, name string) string {
+	return "/fmeapiv4/schedules/" + url.PathEscape(category) + "/" + url.PathEscape(name)
+}
+
+func (r ScheduleRecurrenceV4) String() string {
+	if r.Cron != "" {
+		return "cron: " + r.Cron
+	}
+	if r.Interval != 0 {
+		return "every " + (time.Duration(r.Interval) * time.Second).String()
+	}
+	return ""
+}
+
+// check a cron expression has the 6 or 7 fields FME Flow expects: seconds, minutes, hours,
+// day of month, month, day of week and an optional year
+func validateCronExpression(expression string) error {
+	fields := strings.Fields(expression)
+	if len(fields) != 6 && len(fields) != 7 {
+		return fmt.Errorf("invalid cron expression %q. Must have 6 or 7 fields, such as \"0 0 2 * * ?\" to run at 2am every day", expression)
+	}
+	return nil
+}
+
+// build the published parameters of a job from Key=Value and Key=Value1,Value2 flags
+func publishedParametersMap(publishedParameters []string, listPublishedParameters []string) (map[string]interface{}, error) {
+	parameters := map[string]interface{}{}
+	for _, parameter := range publishedParameters {
+		thisParameter := strings.SplitN(parameter, "=", 2)
+		if len(thisParameter) != 2 {
+			return nil, fmt.Errorf("invalid published parameter %q. Must be in the form Key=Value", parameter)
+		}
+		parameters[thisParameter[0]] = thisParameter[1]
+	}
+	for _, parameter := range listPublishedParameters {
+		thisParameter := strings.SplitN(parameter, "=", 2)
+		if len(thisParameter) != 2 {
+			return nil, fmt.Errorf("invalid published parameter %q. Must be in the form Key=Value1,Value2", parameter)
+		}
+		// split on commas, unless they are escaped
+		parameters[thisParameter[0]] = splitEscapedString(thisParameter[1], ',')
+	}
+	return parameters, nil
+}
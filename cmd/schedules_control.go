@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+type scheduleControlFlags struct {
+	name     string
+	category string
+}
+
+const (
+	scheduleActionEnable  = "enable"
+	scheduleActionDisable = "disable"
+	scheduleActionRun     = "run"
+)
+
+func newScheduleEnableCmd() *cobra.Command {
+	f := scheduleControlFlags{}
+	cmd := &cobra.Command{
+		Use:   "enable",
+		Short: "Enable a schedule",
+		Long:  `Enable a schedule so that it runs at its next scheduled time.`,
+		Example: `
+  # Enable the schedule "Refresh" in the category "Nightly"
+  fmeflow schedules enable --category Nightly --name Refresh`,
+		Args: NoArgs,
+		RunE: scheduleControlRun(scheduleActionEnable, &f),
+	}
+	addScheduleControlFlags(cmd, &f, "enable")
+	return cmd
+}
+
+func newScheduleDisableCmd() *cobra.Command {
+	f := scheduleControlFlags{}
+	cmd := &cobra.Command{
+		Use:   "disable",
+		Short: "Disable a schedule",
+		Long:  `Disable a schedule so that it doesn't run until it is enabled again.`,
+		Example: `
+  # Disable the schedule "Refresh" in the category "Nightly"
+  fmeflow schedules disable --category Nightly --name Refresh`,
+		Args: NoArgs,
+		RunE: scheduleControlRun(scheduleActionDisable, &f),
+	}
+	addScheduleControlFlags(cmd, &f, "disable")
+	return cmd
+}
+
+func newScheduleRunNowCmd() *cobra.Command {
+	f := scheduleControlFlags{}
+	cmd := &cobra.Command{
+		Use:   "run-now",
+		Short: "Run a schedule immediately",
+		Long:  `Submit the job for a schedule immediately, with the same workspace and published parameters it runs with on schedule. The schedule's next scheduled run is not affected. Use "fmeflow jobs --id" to check on the job.`,
+		Example: `
+  # Run the schedule "Refresh" in the category "Nightly" now
+  fmeflow schedules run-now --category Nightly --name Refresh`,
+		Args: NoArgs,
+		RunE: scheduleControlRun(scheduleActionRun, &f),
+	}
+	addScheduleControlFlags(cmd, &f, "run")
+	return cmd
+}
+
+func addScheduleControlFlags(cmd *cobra.Command, f *scheduleControlFlags, action string) {
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the schedule to "+action+".")
+	cmd.Flags().StringVar(&f.category, "category", "", "Category of the schedule to "+action+".")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("category")
+}
+
+func scheduleControlRun(action string, f *scheduleControlFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		// check the schedule exists first so that a missing schedule gives a helpful error
+		if _, _, err := getScheduleV4(client, f.category, f.name); err != nil {
+			return err
+		}
+
+		responseData, err := sendFmeFlowJSON(client, scheduleEndpoint(f.category, f.name)+"/"+action, "POST", nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+		if err != nil {
+			return err
+		}
+
+		if action == scheduleActionRun {
+			var job JobId
+			if err := json.Unmarshal(responseData, &job); err != nil {
+				return err
+			}
+			if !jsonOutput {
+				fmt.Fprintf(cmd.OutOrStdout(), "Schedule %s successfully submitted as job %d.\n", f.name, job.Id)
+			} else {
+				prettyJSON, err := prettyPrintJSON(responseData)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+			}
+			return nil
+		}
+
+		if !jsonOutput {
+			fmt.Fprintf(cmd.OutOrStdout(), "Schedule %s successfully %sd.\n", f.name, action)
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulesControl(t *testing.T) {
+	scheduleRefreshBody := `{
+		"name": "Refresh",
+		"category": "Nightly",
+		"description": "Refresh the apartments",
+		"enabled": true,
+		"owner": "admin",
+		"recurrence": {
+		  "cron": "0 0 2 * * ?",
+		  "start": "2026-01-01T00:00:00Z"
+		},
+		"repository": "Samples",
+		"workspace": "austinApartments.fmw",
+		"publishedParameters": {
+		  "COORDSYS": "TX83-CF",
+		  "THEMES": ["railroad", "airports"]
+		},
+		"nextRun": "2026-10-20T02:00:00Z"
+	  }`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/schedules/Nightly/Refresh" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(scheduleRefreshBody))
+			require.NoError(t, err)
+		} else if r.Method == "POST" && r.URL.Path == "/fmeapiv4/schedules/Nightly/Refresh/run" {
+			w.WriteHeader(http.StatusAccepted)
+			_, err := w.Write([]byte(`{"id": 42}`))
+			require.NoError(t, err)
+		} else if r.Method == "POST" && (r.URL.Path == "/fmeapiv4/schedules/Nightly/Refresh/enable" || r.URL.Path == "/fmeapiv4/schedules/Nightly/Refresh/disable") {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"schedules", "enable", "--category", "Nightly", "--name", "Refresh", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing category",
+			args:        []string{"schedules", "disable", "--name", "Refresh"},
+			wantErrText: "required flag(s) \"category\" not set",
+		},
+		{
+			name:        "schedule not found",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"schedules", "enable", "--category", "Nightly", "--name", "Missing"},
+			wantErrText: "404 Not Found: check that the specified schedule and category exist",
+		},
+		{
+			name:            "enable schedule",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"schedules", "enable", "--category", "Nightly", "--name", "Refresh"},
+			wantOutputRegex: "^Schedule Refresh successfully enabled.\n$",
+		},
+		{
+			name:            "disable schedule",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"schedules", "disable", "--category", "Nightly", "--name", "Refresh"},
+			wantOutputRegex: "^Schedule Refresh successfully disabled.\n$",
+		},
+		{
+			name:           "disable schedule json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"schedules", "disable", "--category", "Nightly", "--name", "Refresh", "--json"},
+			wantOutputJson: "{}",
+		},
+		{
+			name:            "run schedule now",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"schedules", "run-now", "--category", "Nightly", "--name", "Refresh"},
+			wantOutputRegex: "^Schedule Refresh successfully submitted as job 42.\n$",
+		},
+		{
+			name:           "run schedule now json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"schedules", "run-now", "--category", "Nightly", "--name", "Refresh", "--json"},
+			wantOutputJson: `{"id": 42}`,
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type scheduleCreateFlags struct {
+	name                   string
+	category               string
+	description            string
+	cron                   string
+	interval               time.Duration
+	start                  string
+	end                    string
+	repository             string
+	workspace              string
+	publishedParameter     []string
+	listPublishedParameter []string
+	parametersFile         string
+	disabled               bool
+}
+
+func newScheduleCreateCmd() *cobra.Command {
+	f := scheduleCreateFlags{}
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a schedule",
+		Long: `Create a schedule that runs a workspace on a recurring basis. Use --cron to run on a cron expression or --interval to run at a fixed interval.
+Cron expressions have 6 or 7 fields: seconds, minutes, hours, day of month, month, day of week and an optional year. For example, "0 0 2 * * ?" runs at 2am every day.`,
+		Example: `
+  # Run austinApartments.fmw at 2am every day
+  fmeflow schedules create --name Refresh --category Nightly --cron "0 0 2 * * ?" --repository Samples --workspace austinApartments.fmw
+
+  # Run a workspace every 15 minutes with a published parameter, starting at a given time
+  fmeflow schedules create --name Poll --category Sync --interval 15m --start 2026-01-01T00:00:00Z --repository Samples --workspace austinDownload.fmw --published-parameter COORDSYS=TX83-CF
+
+  # Create a disabled schedule with the published parameters in a file
+  fmeflow schedules create --name Refresh --category Nightly --cron "0 0 2 * * ?" --repository Samples --workspace austinDownload.fmw --parameters-file params.yaml --disabled`,
+		Args: NoArgs,
+		RunE: scheduleCreateRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the schedule to create.")
+	cmd.Flags().StringVar(&f.category, "category", "", "Category of the schedule.")
+	cmd.Flags().StringVar(&f.description, "description", "", "Description of the schedule.")
+	cmd.Flags().StringVar(&f.cron, "cron", "", "Cron expression for when the schedule runs, such as \"0 0 2 * * ?\".")
+	cmd.Flags().DurationVar(&f.interval, "interval", 0, "How often the schedule runs, such as 15m or 24h.")
+	cmd.Flags().StringVar(&f.start, "start", "", "When the schedule starts, in RFC3339 format such as 2026-01-01T00:00:00Z. Defaults to now.")
+	cmd.Flags().StringVar(&f.end, "end", "", "When the schedule ends, in RFC3339 format. Defaults to never.")
+	cmd.Flags().StringVar(&f.repository, "repository", "", "The name of the repository containing the workspace to run.")
+	cmd.Flags().StringVar(&f.workspace, "workspace", "", "The name of the workspace to run.")
+	cmd.Flags().StringArrayVar(&f.publishedParameter, "published-parameter", []string{}, "Published parameters to pass to the workspace. Specify as Key=Value. Can be passed in multiple times. For list parameters, use the --published-parameter-list flag.")
+	cmd.Flags().StringArrayVar(&f.listPublishedParameter, "published-parameter-list", []string{}, "A List-type published parameter to pass to the workspace. Specify as Key=Value1,Value2. Can be passed in multiple times.")
+	cmd.Flags().StringVar(&f.parametersFile, "parameters-file", "", "A yaml file of published parameters to pass to the workspace. Use \"fmeflow workspaces describe --show parameters --output yaml\" to create a template.")
+	cmd.Flags().BoolVar(&f.disabled, "disabled", false, "Create the schedule disabled so that it doesn't run until it is enabled.")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("category")
+	cmd.MarkFlagRequired("repository")
+	cmd.MarkFlagRequired("workspace")
+	cmd.MarkFlagsOneRequired("cron", "interval")
+	cmd.MarkFlagsMutuallyExclusive("cron", "interval")
+	return cmd
+}
+
+func scheduleCreateRun(f *scheduleCreateFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		recurrence, err := scheduleRecurrence(f.cron, f.interval, f.start, f.end)
+		if err != nil {
+			return err
+		}
+
+		if f.parametersFile != "" {
+			if err := loadParametersFile(f.parametersFile, &f.publishedParameter, &f.listPublishedParameter); err != nil {
+				return err
+			}
+		}
+		parameters, err := publishedParametersMap(f.publishedParameter, f.listPublishedParameter)
+		if err != nil {
+			return err
+		}
+
+		newSchedule := NewScheduleV4{
+			Name:                f.name,
+			Category:            f.category,
+			Description:         f.description,
+			Enabled:             !f.disabled,
+			Recurrence:          recurrence,
+			Repository:          f.repository,
+			Workspace:           f.workspace,
+			PublishedParameters: parameters,
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		if _, err := sendFmeFlowJSON(client, "/fmeapiv4/schedules", "POST", newSchedule, http.StatusCreated); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Schedule successfully created.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
+
+// build the recurrence of a schedule from the flags, checking that they are valid
+func scheduleRecurrence(cron string, interval time.Duration, start string, end string) (ScheduleRecurrenceV4, error) {
+	var recurrence ScheduleRecurrenceV4
+	if cron != "" {
+		if err := validateCronExpression(cron); err != nil {
+			return recurrence, err
+		}
+		recurrence.Cron = cron
+	} else {
+		if interval < time.Second || interval%time.Second != 0 {
+			return recurrence, fmt.Errorf("invalid interval %s. Must be a whole number of seconds", interval)
+		}
+		recurrence.Interval = int(interval / time.Second)
+	}
+
+	var err error
+	if recurrence.Start, err = parseScheduleTime("start", start); err != nil {
+		return recurrence, err
+	}
+	if recurrence.End, err = parseScheduleTime("end", end); err != nil {
+		return recurrence, err
+	}
+	if recurrence.Start != nil && recurrence.End != nil && !recurrence.End.After(*recurrence.Start) {
+		return recurrence, fmt.Errorf("the end of the schedule must be after the start")
+	}
+	return recurrence, nil
+}
+
+func parseScheduleTime(flag string, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q for --%s. Must be in RFC3339 format such as 2026-01-01T00:00:00Z", value, flag)
+	}
+	return &t, nil
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSchedulesCreate(t *testing.T) {
+	parametersFile := filepath.Join(t.TempDir(), "params.yaml")
+	os.WriteFile(parametersFile, []byte("COORDSYS: TX83-CF\nTHEMES:\n  - railroad\n  - airports\n"), 0644)
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusCreated,
+			args:               []string{"schedules", "create", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing recurrence",
+			args:        []string{"schedules", "create", "--name", "Refresh", "--category", "Nightly", "--repository", "Samples", "--workspace", "austinApartments.fmw"},
+			wantErrText: "at least one of the flags in the group [cron interval] is required",
+		},
+		{
+			name:        "cron and interval",
+			args:        []string{"schedules", "create", "--name", "Refresh", "--category", "Nightly", "--repository", "Samples", "--workspace", "austinApartments.fmw", "--cron", "0 0 2 * * ?", "--interval", "1h"},
+			wantErrText: "if any flags in the group [cron interval] are set none of the others can be; [cron interval] were all set",
+		},
+		{
+			name:        "invalid cron",
+			args:        []string{"schedules", "create", "--name", "Refresh", "--category", "Nightly", "--repository", "Samples", "--workspace", "austinApartments.fmw", "--cron", "0 2 * * *"},
+			wantErrText: "invalid cron expression \"0 2 * * *\". Must have 6 or 7 fields, such as \"0 0 2 * * ?\" to run at 2am every day",
+		},
+		{
+			name:        "invalid interval",
+			args:        []string{"schedules", "create", "--name", "Poll", "--category", "Sync", "--repository", "Samples", "--workspace", "austinDownload.fmw", "--interval", "1500ms"},
+			wantErrText: "invalid interval 1.5s. Must be a whole number of seconds",
+		},
+		{
+			name:        "invalid start",
+			args:        []string{"schedules", "create", "--name", "Poll", "--category", "Sync", "--repository", "Samples", "--workspace", "austinDownload.fmw", "--interval", "15m", "--start", "tomorrow"},
+			wantErrText: "invalid value \"tomorrow\" for --start. Must be in RFC3339 format such as 2026-01-01T00:00:00Z",
+		},
+		{
+			name:        "end before start",
+			args:        []string{"schedules", "create", "--name", "Poll", "--category", "Sync", "--repository", "Samples", "--workspace", "austinDownload.fmw", "--interval", "15m", "--start", "2026-02-01T00:00:00Z", "--end", "2026-01-01T00:00:00Z"},
+			wantErrText: "the end of the schedule must be after the start",
+		},
+		{
+			name:            "create cron schedule",
+			statusCode:      http.StatusCreated,
+			args:            []string{"schedules", "create", "--name", "Refresh", "--category", "Nightly", "--repository", "Samples", "--workspace", "austinApartments.fmw", "--cron", "0 0 2 * * ?", "--published-parameter", "COORDSYS=TX83-CF", "--published-parameter-list", "THEMES=railroad,airports"},
+			wantOutputRegex: "^Schedule successfully created.\n$",
+			wantBodyJson:    `{"name":"Refresh","category":"Nightly","description":"","enabled":true,"recurrence":{"cron":"0 0 2 * * ?"},"repository":"Samples","workspace":"austinApartments.fmw","publishedParameters":{"COORDSYS":"TX83-CF","THEMES":["railroad","airports"]}}`,
+		},
+		{
+			name:            "create interval schedule disabled",
+			statusCode:      http.StatusCreated,
+			args:            []string{"schedules", "create", "--name", "Poll", "--category", "Sync", "--repository", "Samples", "--workspace", "austinDownload.fmw", "--interval", "15m", "--start", "2026-01-01T00:00:00Z", "--disabled"},
+			wantOutputRegex: "^Schedule successfully created.\n$",
+			wantBodyJson:    `{"name":"Poll","category":"Sync","description":"","enabled":false,"recurrence":{"interval":900,"start":"2026-01-01T00:00:00Z"},"repository":"Samples","workspace":"austinDownload.fmw"}`,
+		},
+		{
+			name:           "create schedule with parameters file",
+			statusCode:     http.StatusCreated,
+			args:           []string{"schedules", "create", "--name", "Refresh", "--category", "Nightly", "--repository", "Samples", "--workspace", "austinApartments.fmw", "--cron", "0 0 2 * * ?", "--parameters-file", parametersFile, "--published-parameter", "COORDSYS=UTM83-10", "--json"},
+			wantOutputJson: "{}",
+			wantBodyJson:   `{"name":"Refresh","category":"Nightly","description":"","enabled":true,"recurrence":{"cron":"0 0 2 * * ?"},"repository":"Samples","workspace":"austinApartments.fmw","publishedParameters":{"COORDSYS":"UTM83-10","THEMES":["railroad","airports"]}}`,
+		},
+		{
+			name:        "schedule already exists",
+			statusCode:  http.StatusConflict,
+			body:        `{"message": "A schedule with the name Refresh already exists in the category Nightly."}`,
+			args:        []string{"schedules", "create", "--name", "Refresh", "--category", "Nightly", "--repository", "Samples", "--workspace", "austinApartments.fmw", "--cron", "0 0 2 * * ?"},
+			wantErrText: "A schedule with the name Refresh already exists in the category Nightly.",
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+type scheduleDeleteFlags struct {
+	name     string
+	category string
+	noprompt bool
+}
+
+func newScheduleDeleteCmd() *cobra.Command {
+	f := scheduleDeleteFlags{}
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a schedule",
+		Long:  `Delete a schedule. Jobs that the schedule has already submitted are not affected.`,
+		Example: `
+  # Delete the schedule "Refresh" in the category "Nightly"
+  fmeflow schedules delete --category Nightly --name Refresh
+
+  # Delete the schedule with no confirmation
+  fmeflow schedules delete --category Nightly --name Refresh --no-prompt`,
+		Args: NoArgs,
+		RunE: scheduleDeleteRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the schedule to delete.")
+	cmd.Flags().StringVar(&f.category, "category", "", "Category of the schedule to delete.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("category")
+	return cmd
+}
+
+func scheduleDeleteRun(f *scheduleDeleteFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		// check the schedule exists first
+		if _, _, err := getScheduleV4(client, f.category, f.name); err != nil {
+			return err
+		}
+
+		if !f.noprompt {
+			// prompt to confirm deletion
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: "Are you sure you want to delete the schedule " + f.category + "/" + f.name + "?",
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		if _, err := sendFmeFlowJSON(client, scheduleEndpoint(f.category, f.name), "DELETE", nil, http.StatusNoContent, http.StatusOK); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Schedule successfully deleted.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulesDelete(t *testing.T) {
+	scheduleRefreshBody := `{
+		"name": "Refresh",
+		"category": "Nightly",
+		"description": "Refresh the apartments",
+		"enabled": true,
+		"owner": "admin",
+		"recurrence": {
+		  "cron": "0 0 2 * * ?",
+		  "start": "2026-01-01T00:00:00Z"
+		},
+		"repository": "Samples",
+		"workspace": "austinApartments.fmw",
+		"publishedParameters": {
+		  "COORDSYS": "TX83-CF",
+		  "THEMES": ["railroad", "airports"]
+		},
+		"nextRun": "2026-10-20T02:00:00Z"
+	  }`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/schedules/Nightly/Refresh" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(scheduleRefreshBody))
+			require.NoError(t, err)
+		} else if r.Method == "DELETE" && r.URL.Path == "/fmeapiv4/schedules/Nightly/Refresh" {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"schedules", "delete", "--category", "Nightly", "--name", "Refresh", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flag",
+			args:        []string{"schedules", "delete", "--category", "Nightly"},
+			wantErrText: "required flag(s) \"name\" not set",
+		},
+		{
+			name:        "schedule not found",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"schedules", "delete", "--category", "Nightly", "--name", "Missing", "-y"},
+			wantErrText: "404 Not Found: check that the specified schedule and category exist",
+		},
+		{
+			name:            "delete schedule",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"schedules", "delete", "--category", "Nightly", "--name", "Refresh", "-y"},
+			wantOutputRegex: "^Schedule successfully deleted.\n$",
+		},
+		{
+			name:           "delete schedule json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"schedules", "delete", "--category", "Nightly", "--name", "Refresh", "-y", "--json"},
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+}
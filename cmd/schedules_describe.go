@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type scheduleDescribeFlags struct {
+	name       string
+	category   string
+	outputType string
+	noHeaders  bool
+}
+
+func newScheduleDescribeCmd() *cobra.Command {
+	f := scheduleDescribeFlags{}
+	cmd := &cobra.Command{
+		Use:   "describe",
+		Short: "Show the details of a schedule",
+		Long:  `Show the details of a schedule, including when it runs, the workspace it runs and the published parameters it passes to the workspace.`,
+		Example: `
+  # Describe the schedule "Refresh" in the category "Nightly"
+  fmeflow schedules describe --category Nightly --name Refresh
+
+  # Output the schedule in json format
+  fmeflow schedules describe --category Nightly --name Refresh --json`,
+		Args: NoArgs,
+		RunE: scheduleDescribeRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the schedule.")
+	cmd.Flags().StringVar(&f.category, "category", "", "Category of the schedule.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("category")
+	return cmd
+}
+
+func scheduleDescribeRun(f *scheduleDescribeFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		schedule, responseData, err := getScheduleV4(client, f.category, f.name)
+		if err != nil {
+			return err
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Property", "Value"})
+			t.AppendRows([]table.Row{
+				{"Name", schedule.Name},
+				{"Category", schedule.Category},
+				{"Description", schedule.Description},
+				{"Enabled", schedule.Enabled},
+				{"Owner", schedule.Owner},
+				{"Recurrence", schedule.Recurrence.String()},
+				{"Start", formatScheduleTime(schedule.Recurrence.Start)},
+				{"End", formatScheduleTime(schedule.Recurrence.End)},
+				{"Next Run", formatScheduleTime(schedule.NextRun)},
+				{"Repository", schedule.Repository},
+				{"Workspace", schedule.Workspace},
+			})
+			for _, name := range sortedKeys(schedule.PublishedParameters) {
+				t.AppendRow(table.Row{"Parameter " + name, formatParameterValue(schedule.PublishedParameters[name])})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns([][]byte{responseData}, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+func formatScheduleTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulesDescribe(t *testing.T) {
+	scheduleRefreshBody := `{
+		"name": "Refresh",
+		"category": "Nightly",
+		"description": "Refresh the apartments",
+		"enabled": true,
+		"owner": "admin",
+		"recurrence": {
+		  "cron": "0 0 2 * * ?",
+		  "start": "2026-01-01T00:00:00Z"
+		},
+		"repository": "Samples",
+		"workspace": "austinApartments.fmw",
+		"publishedParameters": {
+		  "COORDSYS": "TX83-CF",
+		  "THEMES": ["railroad", "airports"]
+		},
+		"nextRun": "2026-10-20T02:00:00Z"
+	  }`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/schedules/Nightly/Refresh" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(scheduleRefreshBody))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"schedules", "describe", "--category", "Nightly", "--name", "Refresh", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing category",
+			args:        []string{"schedules", "describe", "--name", "Refresh"},
+			wantErrText: "required flag(s) \"category\" not set",
+		},
+		{
+			name:        "schedule not found",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"schedules", "describe", "--category", "Nightly", "--name", "Missing"},
+			wantErrText: "404 Not Found: check that the specified schedule and category exist",
+		},
+		{
+			name:            "describe schedule",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"schedules", "describe", "--category", "Nightly", "--name", "Refresh"},
+			wantOutputRegex: "^[\\s]*PROPERTY[\\s]*VALUE[\\s]*Name[\\s]*Refresh[\\s]*Category[\\s]*Nightly[\\s]*Description[\\s]*Refresh the apartments[\\s]*Enabled[\\s]*true[\\s]*Owner[\\s]*admin[\\s]*Recurrence[\\s]*cron: 0 0 2 \\* \\* \\?[\\s]*Start[\\s]*2026-01-01T00:00:00Z[\\s]*End[\\s]*Next Run[\\s]*2026-10-20T02:00:00Z[\\s]*Repository[\\s]*Samples[\\s]*Workspace[\\s]*austinApartments.fmw[\\s]*Parameter COORDSYS[\\s]*TX83-CF[\\s]*Parameter THEMES[\\s]*railroad, airports[\\s]*$",
+		},
+		{
+			name:            "describe schedule custom columns",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"schedules", "describe", "--category", "Nightly", "--name", "Refresh", "--output=custom-columns=CRON:.recurrence.cron", "--no-headers"},
+			wantOutputRegex: "^[\\s]*0 0 2 \\* \\* \\?[\\s]*$",
+		},
+		{
+			name:           "describe schedule json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"schedules", "describe", "--category", "Nightly", "--name", "Refresh", "--json"},
+			wantOutputJson: scheduleRefreshBody,
+		},
+	}
+
+	runTests(cases, t)
+}
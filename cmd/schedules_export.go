@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// a file of schedules written by "fmeflow schedules export" and read by "fmeflow schedules import"
+type ScheduleFile struct {
+	Schedules []ManifestSchedule `yaml:"schedules" json:"schedules"`
+}
+
+type ManifestSchedule struct {
+	Name                string                 `yaml:"name" json:"name"`
+	Category            string                 `yaml:"category" json:"category"`
+	Description         string                 `yaml:"description,omitempty" json:"description,omitempty"`
+	Enabled             *bool                  `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	Cron                string                 `yaml:"cron,omitempty" json:"cron,omitempty"`
+	Interval            string                 `yaml:"interval,omitempty" json:"interval,omitempty"`
+	Start               *time.Time             `yaml:"start,omitempty" json:"start,omitempty"`
+	End                 *time.Time             `yaml:"end,omitempty" json:"end,omitempty"`
+	Repository          string                 `yaml:"repository" json:"repository"`
+	Workspace           string                 `yaml:"workspace" json:"workspace"`
+	PublishedParameters map[string]interface{} `yaml:"publishedParameters,omitempty" json:"publishedParameters,omitempty"`
+}
+
+type schedulesExportFlags struct {
+	category   string
+	names      []string
+	outputType string
+}
+
+func newScheduleExportCmd() *cobra.Command {
+	f := schedulesExportFlags{}
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export schedules to a file",
+		Long: `Export the definitions of schedules on FME Flow so they can be imported into another FME Flow with "fmeflow schedules import".
+Fields generated by FME Flow such as the owner and next run time are left out.`,
+		Example: `
+  # Export all schedules to a file
+  fmeflow schedules export > schedules.yaml
+
+  # Export the schedules in the category "Nightly"
+  fmeflow schedules export --category Nightly > nightly.yaml
+
+  # Promote a schedule from one FME Flow to another
+  fmeflow schedules export --category Nightly --name Refresh | fmeflow schedules import -f - --config prod-config.yaml`,
+		Args: NoArgs,
+		RunE: schedulesExportRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.category, "category", "", "If specified, only schedules in this category will be exported.")
+	cmd.Flags().StringArrayVar(&f.names, "name", []string{}, "Name of a schedule to export. Can be passed in multiple times. Defaults to all schedules.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "yaml", "Specify the output type. Should be one of yaml or json")
+	return cmd
+}
+
+func schedulesExportRun(f *schedulesExportFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+		if f.outputType != "yaml" && f.outputType != "json" {
+			return errors.New("invalid output format specified")
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		items, err := getSchedulesV4(client, f.category)
+		if err != nil {
+			return err
+		}
+
+		for _, name := range f.names {
+			if !slices.ContainsFunc(items, func(s ScheduleV4) bool { return s.Name == name }) {
+				return fmt.Errorf("schedule %s does not exist", name)
+			}
+		}
+
+		file := ScheduleFile{Schedules: []ManifestSchedule{}}
+		for _, item := range sortedByName(items, func(s ScheduleV4) string { return s.Category + "/" + s.Name }) {
+			if len(f.names) != 0 && !slices.Contains(f.names, item.Name) {
+				continue
+			}
+			file.Schedules = append(file.Schedules, exportSchedule(item))
+		}
+
+		return writeManifest(cmd, file, f.outputType)
+	}
+}
+
+func exportSchedule(schedule ScheduleV4) ManifestSchedule {
+	enabled := schedule.Enabled
+	exported := ManifestSchedule{
+		Name:                schedule.Name,
+		Category:            schedule.Category,
+		Description:         schedule.Description,
+		Enabled:             &enabled,
+		Cron:                schedule.Recurrence.Cron,
+		Start:               schedule.Recurrence.Start,
+		End:                 schedule.Recurrence.End,
+		Repository:          schedule.Repository,
+		Workspace:           schedule.Workspace,
+		PublishedParameters: schedule.PublishedParameters,
+	}
+	if schedule.Recurrence.Interval != 0 {
+		exported.Interval = (time.Duration(schedule.Recurrence.Interval) * time.Second).String()
+	}
+	return exported
+}
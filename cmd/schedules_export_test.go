@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulesExport(t *testing.T) {
+	wantYaml := `schedules:
+  - name: Refresh
+    category: Nightly
+    description: Refresh the apartments
+    enabled: true
+    cron: 0 0 2 \* \* \?
+    start: 2026-01-01T00:00:00Z
+    repository: Samples
+    workspace: austinApartments.fmw
+    publishedParameters:
+      COORDSYS: TX83-CF
+      THEMES:
+        - railroad
+        - airports
+  - name: Poll
+    category: Sync
+    enabled: false
+    interval: 15m0s
+    repository: Samples
+    workspace: austinDownload.fmw
+`
+
+	schedulesListBody := `{
+		"items": [
+		  {
+			"name": "Refresh",
+			"category": "Nightly",
+			"description": "Refresh the apartments",
+			"enabled": true,
+			"owner": "admin",
+			"recurrence": {
+			  "cron": "0 0 2 * * ?",
+			  "start": "2026-01-01T00:00:00Z"
+			},
+			"repository": "Samples",
+			"workspace": "austinApartments.fmw",
+			"publishedParameters": {
+			  "COORDSYS": "TX83-CF",
+			  "THEMES": ["railroad", "airports"]
+			},
+			"nextRun": "2026-10-20T02:00:00Z"
+		  },
+		  {
+			"name": "Poll",
+			"category": "Sync",
+			"description": "",
+			"enabled": false,
+			"owner": "admin",
+			"recurrence": {
+			  "interval": 900
+			},
+			"repository": "Samples",
+			"workspace": "austinDownload.fmw"
+		  }
+		],
+		"limit": 2,
+		"offset": 0,
+		"totalCount": 2
+	  }`
+	syncListBody := `{"items": [{"name": "Poll", "category": "Sync", "enabled": false, "recurrence": {"interval": 900}, "repository": "Samples", "workspace": "austinDownload.fmw"}], "limit": 1, "offset": 0, "totalCount": 1}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/schedules" && r.URL.Query().Get("category") == "Sync" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(syncListBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/schedules" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(schedulesListBody))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"schedules", "export", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "invalid output",
+			args:        []string{"schedules", "export", "-o", "table"},
+			wantErrText: "invalid output format specified",
+		},
+		{
+			name:            "export all schedules",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"schedules", "export"},
+			wantOutputRegex: "^" + wantYaml + "$",
+		},
+		{
+			name:            "export category",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"schedules", "export", "--category", "Sync"},
+			wantOutputRegex: "^schedules:\n  - name: Poll\n    category: Sync\n    enabled: false\n    interval: 15m0s\n    repository: Samples\n    workspace: austinDownload.fmw\n$",
+		},
+		{
+			name:           "export named schedule json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"schedules", "export", "--name", "Poll", "--json"},
+			wantOutputJson: `{"schedules": [{"name": "Poll", "category": "Sync", "enabled": false, "interval": "15m0s", "repository": "Samples", "workspace": "austinDownload.fmw"}]}`,
+		},
+		{
+			name:        "export missing schedule",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"schedules", "export", "--name", "Missing"},
+			wantErrText: "schedule Missing does not exist",
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type ScheduleImportResult struct {
+	Name       string `json:"name"`
+	Category   string `json:"category"`
+	Action     string `json:"action"`
+	ImportedAs string `json:"importedAs"`
+}
+
+type schedulesImportFlags struct {
+	file       string
+	onConflict string
+	disabled   bool
+	outputType string
+	noHeaders  bool
+}
+
+func newScheduleImportCmd() *cobra.Command {
+	f := schedulesImportFlags{}
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import schedules from a file",
+		Long: `Import schedules from a file created by "fmeflow schedules export". Values in the file can reference environment variables as ${env:NAME}, which is useful for published parameters that differ between environments.
+Use --on-conflict to choose what happens when a schedule with the same name already exists in the category:
+  skip: leave the existing schedule unchanged
+  overwrite: replace the existing schedule with the imported one
+  rename: import the schedule with a new name, such as Refresh_2
+Use --disabled to import every schedule disabled, so that they can be checked before they start running.`,
+		Example: `
+  # Import the schedules in schedules.yaml, skipping any that already exist
+  fmeflow schedules import -f schedules.yaml
+
+  # Import the schedules disabled, overwriting any that already exist
+  fmeflow schedules import -f schedules.yaml --on-conflict overwrite --disabled
+
+  # Promote schedules from one FME Flow to another
+  fmeflow schedules export --category Nightly | fmeflow schedules import -f - --config prod-config.yaml`,
+		Args: NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains(importConflictModes, f.onConflict) {
+				return fmt.Errorf("invalid value %q for --on-conflict. Must be one of %s", f.onConflict, strings.Join(importConflictModes, ", "))
+			}
+			return nil
+		},
+		RunE: schedulesImportRun(&f),
+	}
+
+	cmd.Flags().StringVarP(&f.file, "file", "f", "", "Path to the file of schedules to import. Use - to read from stdin.")
+	cmd.Flags().StringVar(&f.onConflict, "on-conflict", "skip", "What to do when a schedule already exists. Should be one of skip, overwrite or rename.")
+	cmd.Flags().BoolVar(&f.disabled, "disabled", false, "Import every schedule disabled, regardless of the file.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.RegisterFlagCompletionFunc("on-conflict", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return importConflictModes, cobra.ShellCompDirectiveDefault
+	})
+	cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func schedulesImportRun(f *schedulesImportFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		document, err := readManifestDocument(cmd, f.file)
+		if err != nil {
+			return err
+		}
+		var file ScheduleFile
+		if err := document.Decode(&file); err != nil {
+			return fmt.Errorf("could not parse schedules file %s: %w", f.file, err)
+		}
+		if len(file.Schedules) == 0 {
+			return fmt.Errorf("no schedules found in %s", f.file)
+		}
+
+		// check the whole file before importing anything
+		newSchedules := []NewScheduleV4{}
+		for _, schedule := range file.Schedules {
+			newSchedule, err := importedSchedule(schedule)
+			if err != nil {
+				return err
+			}
+			if f.disabled {
+				newSchedule.Enabled = false
+			}
+			newSchedules = append(newSchedules, newSchedule)
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		items, err := getSchedulesV4(client, "")
+		if err != nil {
+			return err
+		}
+		existing := map[string]bool{}
+		for _, item := range items {
+			existing[item.Category+"/"+item.Name] = true
+		}
+
+		results := []ScheduleImportResult{}
+		for _, schedule := range newSchedules {
+			result := ScheduleImportResult{Name: schedule.Name, Category: schedule.Category, ImportedAs: schedule.Name}
+			if !existing[schedule.Category+"/"+schedule.Name] {
+				result.Action = "created"
+			} else {
+				switch f.onConflict {
+				case "skip":
+					result.Action = "skipped"
+					result.ImportedAs = ""
+				case "overwrite":
+					result.Action = "overwritten"
+				case "rename":
+					result.Action = "renamed"
+					for i := 2; ; i++ {
+						name := schedule.Name + "_" + strconv.Itoa(i)
+						if !existing[schedule.Category+"/"+name] {
+							result.ImportedAs = name
+							break
+						}
+					}
+				}
+			}
+
+			switch result.Action {
+			case "created", "renamed":
+				schedule.Name = result.ImportedAs
+				_, err = sendFmeFlowJSON(client, "/fmeapiv4/schedules", "POST", schedule, http.StatusCreated)
+			case "overwritten":
+				_, err = sendFmeFlowJSON(client, scheduleEndpoint(schedule.Category, schedule.Name), "PUT", schedule, http.StatusOK, http.StatusNoContent)
+			}
+			if err != nil {
+				return fmt.Errorf("could not import schedule %s/%s: %w", result.Category, result.Name, err)
+			}
+			if result.ImportedAs != "" {
+				// later schedules in the file can't be renamed to this name
+				existing[schedule.Category+"/"+result.ImportedAs] = true
+			}
+			results = append(results, result)
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Name", "Category", "Action", "Imported As"})
+
+			for _, element := range results {
+				t.AppendRow(table.Row{element.Name, element.Category, element.Action, element.ImportedAs})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			jsonData, err := json.Marshal(results)
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(jsonData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			// we have to marshal the Items array, then create an array of marshalled items
+			// to pass to the creation of the table.
+			marshalledItems := [][]byte{}
+			for _, element := range results {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// check a schedule from a file and convert it to the form FME Flow expects
+func importedSchedule(schedule ManifestSchedule) (NewScheduleV4, error) {
+	newSchedule := NewScheduleV4{
+		Name:                schedule.Name,
+		Category:            schedule.Category,
+		Description:         schedule.Description,
+		Enabled:             schedule.Enabled == nil || *schedule.Enabled,
+		Repository:          schedule.Repository,
+		Workspace:           schedule.Workspace,
+		PublishedParameters: schedule.PublishedParameters,
+	}
+	if schedule.Name == "" || schedule.Category == "" {
+		return newSchedule, errors.New("every schedule must have a name and a category")
+	}
+	if schedule.Repository == "" || schedule.Workspace == "" {
+		return newSchedule, fmt.Errorf("schedule %s/%s must have a repository and a workspace", schedule.Category, schedule.Name)
+	}
+	if (schedule.Cron == "") == (schedule.Interval == "") {
+		return newSchedule, fmt.Errorf("schedule %s/%s must have either a cron expression or an interval", schedule.Category, schedule.Name)
+	}
+
+	var interval time.Duration
+	if schedule.Interval != "" {
+		var err error
+		interval, err = time.ParseDuration(schedule.Interval)
+		if err != nil {
+			return newSchedule, fmt.Errorf("invalid interval %q for schedule %s/%s", schedule.Interval, schedule.Category, schedule.Name)
+		}
+	}
+	recurrence, err := scheduleRecurrence(schedule.Cron, interval, formatScheduleTime(schedule.Start), formatScheduleTime(schedule.End))
+	if err != nil {
+		return newSchedule, fmt.Errorf("schedule %s/%s: %w", schedule.Category, schedule.Name, err)
+	}
+	newSchedule.Recurrence = recurrence
+	return newSchedule, nil
+}
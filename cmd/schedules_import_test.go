@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulesImport(t *testing.T) {
+	t.Setenv("SCHEDULE_COORDSYS", "UTM83-10")
+	dir := t.TempDir()
+	schedulesFile := filepath.Join(dir, "schedules.yaml")
+	os.WriteFile(schedulesFile, []byte(`schedules:
+  - name: Refresh
+    category: Nightly
+    cron: 0 0 2 * * ?
+    repository: Samples
+    workspace: austinApartments.fmw
+    publishedParameters:
+      COORDSYS: ${env:SCHEDULE_COORDSYS}
+`), 0644)
+	newFile := filepath.Join(dir, "new.yaml")
+	os.WriteFile(newFile, []byte(`schedules:
+  - name: Hourly
+    category: Sync
+    enabled: false
+    interval: 1h
+    repository: Samples
+    workspace: austinDownload.fmw
+`), 0644)
+	invalidFile := filepath.Join(dir, "invalid.yaml")
+	os.WriteFile(invalidFile, []byte(`schedules:
+  - name: Both
+    category: Sync
+    cron: 0 0 2 * * ?
+    interval: 1h
+    repository: Samples
+    workspace: austinDownload.fmw
+`), 0644)
+
+	schedulesListBody := `{
+		"items": [
+		  {
+			"name": "Refresh",
+			"category": "Nightly",
+			"description": "Refresh the apartments",
+			"enabled": true,
+			"owner": "admin",
+			"recurrence": {
+			  "cron": "0 0 2 * * ?",
+			  "start": "2026-01-01T00:00:00Z"
+			},
+			"repository": "Samples",
+			"workspace": "austinApartments.fmw",
+			"publishedParameters": {
+			  "COORDSYS": "TX83-CF",
+			  "THEMES": ["railroad", "airports"]
+			},
+			"nextRun": "2026-10-20T02:00:00Z"
+		  },
+		  {
+			"name": "Poll",
+			"category": "Sync",
+			"description": "",
+			"enabled": false,
+			"owner": "admin",
+			"recurrence": {
+			  "interval": 900
+			},
+			"repository": "Samples",
+			"workspace": "austinDownload.fmw"
+		  }
+		],
+		"limit": 2,
+		"offset": 0,
+		"totalCount": 2
+	  }`
+	syncListBody := `{"items": [{"name": "Poll", "category": "Sync", "enabled": false, "recurrence": {"interval": 900}, "repository": "Samples", "workspace": "austinDownload.fmw"}], "limit": 1, "offset": 0, "totalCount": 1}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/schedules" && r.URL.Query().Get("category") == "Sync" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(syncListBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/schedules" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(schedulesListBody))
+			require.NoError(t, err)
+		} else if r.Method == "POST" && r.URL.Path == "/fmeapiv4/schedules" {
+			w.WriteHeader(http.StatusCreated)
+		} else if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/schedules/Nightly/Refresh" {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// creates the new schedule
+	customHttpServerHandlerCreate := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/schedules" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"Hourly","category":"Sync","description":"","enabled":false,"recurrence":{"interval":3600},"repository":"Samples","workspace":"austinDownload.fmw"}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// overwrites the existing schedule, disabled
+	customHttpServerHandlerOverwrite := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/schedules/Nightly/Refresh" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"Refresh","category":"Nightly","description":"","enabled":false,"recurrence":{"cron":"0 0 2 * * ?"},"repository":"Samples","workspace":"austinApartments.fmw","publishedParameters":{"COORDSYS":"UTM83-10"}}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// creates a copy of the existing schedule under a new name
+	customHttpServerHandlerRename := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/schedules" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"Refresh_2","category":"Nightly","description":"","enabled":true,"recurrence":{"cron":"0 0 2 * * ?"},"repository":"Samples","workspace":"austinApartments.fmw","publishedParameters":{"COORDSYS":"UTM83-10"}}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"schedules", "import", "-f", schedulesFile, "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "invalid conflict mode",
+			args:        []string{"schedules", "import", "-f", schedulesFile, "--on-conflict", "merge"},
+			wantErrText: "invalid value \"merge\" for --on-conflict. Must be one of skip, overwrite, rename",
+		},
+		{
+			name:        "cron and interval",
+			args:        []string{"schedules", "import", "-f", invalidFile},
+			wantErrText: "schedule Sync/Both must have either a cron expression or an interval",
+		},
+		{
+			name:            "import new schedule",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerCreate)),
+			args:            []string{"schedules", "import", "-f", newFile},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*CATEGORY[\\s]*ACTION[\\s]*IMPORTED AS[\\s]*Hourly[\\s]*Sync[\\s]*created[\\s]*Hourly[\\s]*$",
+		},
+		{
+			name:            "skip existing schedule",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"schedules", "import", "-f", schedulesFile, "--no-headers"},
+			wantOutputRegex: "^[\\s]*Refresh[\\s]*Nightly[\\s]*skipped[\\s]*$",
+		},
+		{
+			name:            "overwrite existing schedule disabled",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerOverwrite)),
+			args:            []string{"schedules", "import", "-f", schedulesFile, "--on-conflict", "overwrite", "--disabled", "--no-headers"},
+			wantOutputRegex: "^[\\s]*Refresh[\\s]*Nightly[\\s]*overwritten[\\s]*Refresh[\\s]*$",
+		},
+		{
+			name:           "rename existing schedule",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandlerRename)),
+			args:           []string{"schedules", "import", "-f", schedulesFile, "--on-conflict", "rename", "--json"},
+			wantOutputJson: `[{"name": "Refresh", "category": "Nightly", "action": "renamed", "importedAs": "Refresh_2"}]`,
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedules(t *testing.T) {
+	schedulesListBody := `{
+		"items": [
+		  {
+			"name": "Refresh",
+			"category": "Nightly",
+			"description": "Refresh the apartments",
+			"enabled": true,
+			"owner": "admin",
+			"recurrence": {
+			  "cron": "0 0 2 * * ?",
+			  "start": "2026-01-01T00:00:00Z"
+			},
+			"repository": "Samples",
+			"workspace": "austinApartments.fmw",
+			"publishedParameters": {
+			  "COORDSYS": "TX83-CF",
+			  "THEMES": ["railroad", "airports"]
+			},
+			"nextRun": "2026-10-20T02:00:00Z"
+		  },
+		  {
+			"name": "Poll",
+			"category": "Sync",
+			"description": "",
+			"enabled": false,
+			"owner": "admin",
+			"recurrence": {
+			  "interval": 900
+			},
+			"repository": "Samples",
+			"workspace": "austinDownload.fmw"
+		  }
+		],
+		"limit": 2,
+		"offset": 0,
+		"totalCount": 2
+	  }`
+	syncListBody := `{"items": [{"name": "Poll", "category": "Sync", "enabled": false, "recurrence": {"interval": 900}, "repository": "Samples", "workspace": "austinDownload.fmw"}], "limit": 1, "offset": 0, "totalCount": 1}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/schedules" && r.URL.Query().Get("category") == "Sync" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(syncListBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/schedules" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(schedulesListBody))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"schedules", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"schedules"},
+		},
+		{
+			name:            "list schedules",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"schedules"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*CATEGORY[\\s]*ENABLED[\\s]*RECURRENCE[\\s]*WORKSPACE[\\s]*Refresh[\\s]*Nightly[\\s]*true[\\s]*cron: 0 0 2 \\* \\* \\?[\\s]*Samples/austinApartments.fmw[\\s]*Poll[\\s]*Sync[\\s]*false[\\s]*every 15m0s[\\s]*Samples/austinDownload.fmw[\\s]*$",
+		},
+		{
+			name:            "list schedules in category",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"schedules", "list", "--category", "Sync", "--no-headers"},
+			wantOutputRegex: "^[\\s]*Poll[\\s]*Sync[\\s]*false[\\s]*every 15m0s[\\s]*Samples/austinDownload.fmw[\\s]*$",
+		},
+		{
+			name:            "list schedules custom columns",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"schedules", "--output=custom-columns=NAME:.name,NEXT:.nextRun"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*NEXT[\\s]*Refresh[\\s]*2026-10-20T02:00:00Z[\\s]*Poll[\\s]*$",
+		},
+		{
+			name:           "list schedules json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"schedules", "--json"},
+			wantOutputJson: schedulesListBody,
+		},
+	}
+
+	runTests(cases, t)
+}
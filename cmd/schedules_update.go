@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type scheduleUpdateFlags struct {
+	name                     string
+	category                 string
+	description              string
+	cron                     string
+	interval                 time.Duration
+	start                    string
+	end                      string
+	repository               string
+	workspace                string
+	publishedParameter       []string
+	listPublishedParameter   []string
+	parametersFile           string
+	removePublishedParameter []string
+}
+
+func newScheduleUpdateCmd() *cobra.Command {
+	f := scheduleUpdateFlags{}
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update a schedule",
+		Long: `Update a schedule. Anything that isn't specified is left unchanged.
+Published parameters that are passed in are added to the schedule, replacing any with the same name. Use --remove-published-parameter to stop passing a published parameter to the workspace.
+Use "fmeflow schedules enable" and "fmeflow schedules disable" to turn a schedule on or off.`,
+		Example: `
+  # Change a schedule to run at 3am every day
+  fmeflow schedules update --name Refresh --category Nightly --cron "0 0 3 * * ?"
+
+  # Change a schedule to run every hour
+  fmeflow schedules update --name Refresh --category Nightly --interval 1h
+
+  # Change a published parameter and remove another
+  fmeflow schedules update --name Refresh --category Nightly --published-parameter COORDSYS=TX83-CF --remove-published-parameter THEMES`,
+		Args: NoArgs,
+		RunE: scheduleUpdateRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the schedule to update.")
+	cmd.Flags().StringVar(&f.category, "category", "", "Category of the schedule to update.")
+	cmd.Flags().StringVar(&f.description, "description", "", "Description of the schedule.")
+	cmd.Flags().StringVar(&f.cron, "cron", "", "Cron expression for when the schedule runs, such as \"0 0 2 * * ?\".")
+	cmd.Flags().DurationVar(&f.interval, "interval", 0, "How often the schedule runs, such as 15m or 24h.")
+	cmd.Flags().StringVar(&f.start, "start", "", "When the schedule starts, in RFC3339 format such as 2026-01-01T00:00:00Z.")
+	cmd.Flags().StringVar(&f.end, "end", "", "When the schedule ends, in RFC3339 format.")
+	cmd.Flags().StringVar(&f.repository, "repository", "", "The name of the repository containing the workspace to run.")
+	cmd.Flags().StringVar(&f.workspace, "workspace", "", "The name of the workspace to run.")
+	cmd.Flags().StringArrayVar(&f.publishedParameter, "published-parameter", []string{}, "Published parameters to pass to the workspace. Specify as Key=Value. Can be passed in multiple times. For list parameters, use the --published-parameter-list flag.")
+	cmd.Flags().StringArrayVar(&f.listPublishedParameter, "published-parameter-list", []string{}, "A List-type published parameter to pass to the workspace. Specify as Key=Value1,Value2. Can be passed in multiple times.")
+	cmd.Flags().StringVar(&f.parametersFile, "parameters-file", "", "A yaml file of published parameters to pass to the workspace.")
+	cmd.Flags().StringArrayVar(&f.removePublishedParameter, "remove-published-parameter", []string{}, "Name of a published parameter to stop passing to the workspace. Can be passed in multiple times.")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("category")
+	cmd.MarkFlagsMutuallyExclusive("cron", "interval")
+	cmd.MarkFlagsOneRequired("description", "cron", "interval", "start", "end", "repository", "workspace", "published-parameter", "published-parameter-list", "parameters-file", "remove-published-parameter")
+	return cmd
+}
+
+func scheduleUpdateRun(f *scheduleUpdateFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		schedule, _, err := getScheduleV4(client, f.category, f.name)
+		if err != nil {
+			return err
+		}
+
+		update := NewScheduleV4{
+			Name:                schedule.Name,
+			Category:            schedule.Category,
+			Description:         schedule.Description,
+			Enabled:             schedule.Enabled,
+			Recurrence:          schedule.Recurrence,
+			Repository:          schedule.Repository,
+			Workspace:           schedule.Workspace,
+			PublishedParameters: schedule.PublishedParameters,
+		}
+		if cmd.Flags().Changed("description") {
+			update.Description = f.description
+		}
+		if cmd.Flags().Changed("repository") {
+			update.Repository = f.repository
+		}
+		if cmd.Flags().Changed("workspace") {
+			update.Workspace = f.workspace
+		}
+
+		// the start and end are kept unless they are passed in
+		start := formatScheduleTime(schedule.Recurrence.Start)
+		if cmd.Flags().Changed("start") {
+			start = f.start
+		}
+		end := formatScheduleTime(schedule.Recurrence.End)
+		if cmd.Flags().Changed("end") {
+			end = f.end
+		}
+		cron := schedule.Recurrence.Cron
+		interval := time.Duration(schedule.Recurrence.Interval) * time.Second
+		if cmd.Flags().Changed("cron") {
+			cron = f.cron
+			interval = 0
+		} else if cmd.Flags().Changed("interval") {
+			cron = ""
+			interval = f.interval
+		}
+		update.Recurrence, err = scheduleRecurrence(cron, interval, start, end)
+		if err != nil {
+			return err
+		}
+
+		if f.parametersFile != "" {
+			if err := loadParametersFile(f.parametersFile, &f.publishedParameter, &f.listPublishedParameter); err != nil {
+				return err
+			}
+		}
+		parameters, err := publishedParametersMap(f.publishedParameter, f.listPublishedParameter)
+		if err != nil {
+			return err
+		}
+		if update.PublishedParameters == nil {
+			update.PublishedParameters = map[string]interface{}{}
+		}
+		for _, name := range f.removePublishedParameter {
+			if _, ok := update.PublishedParameters[name]; !ok {
+				return fmt.Errorf("schedule %s does not pass the published parameter %s", f.name, name)
+			}
+			delete(update.PublishedParameters, name)
+		}
+		for name, value := range parameters {
+			update.PublishedParameters[name] = value
+		}
+
+		if _, err := sendFmeFlowJSON(client, scheduleEndpoint(f.category, f.name), "PUT", update, http.StatusOK, http.StatusNoContent); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Schedule successfully updated.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulesUpdate(t *testing.T) {
+	scheduleRefreshBody := `{
+		"name": "Refresh",
+		"category": "Nightly",
+		"description": "Refresh the apartments",
+		"enabled": true,
+		"owner": "admin",
+		"recurrence": {
+		  "cron": "0 0 2 * * ?",
+		  "start": "2026-01-01T00:00:00Z"
+		},
+		"repository": "Samples",
+		"workspace": "austinApartments.fmw",
+		"publishedParameters": {
+		  "COORDSYS": "TX83-CF",
+		  "THEMES": ["railroad", "airports"]
+		},
+		"nextRun": "2026-10-20T02:00:00Z"
+	  }`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/schedules/Nightly/Refresh" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(scheduleRefreshBody))
+			require.NoError(t, err)
+		} else if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/schedules/Nightly/Refresh" {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// only the cron expression changes
+	customHttpServerHandlerCron := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/schedules/Nightly/Refresh" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"Refresh","category":"Nightly","description":"Refresh the apartments","enabled":true,"recurrence":{"cron":"0 0 3 * * ?","start":"2026-01-01T00:00:00Z"},"repository":"Samples","workspace":"austinApartments.fmw","publishedParameters":{"COORDSYS":"TX83-CF","THEMES":["railroad","airports"]}}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// the cron expression is replaced by an interval
+	customHttpServerHandlerInterval := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/schedules/Nightly/Refresh" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"Refresh","category":"Nightly","description":"Refresh the apartments","enabled":true,"recurrence":{"interval":3600,"start":"2026-01-01T00:00:00Z","end":"2027-01-01T00:00:00Z"},"repository":"Samples","workspace":"austinApartments.fmw","publishedParameters":{"COORDSYS":"TX83-CF","THEMES":["railroad","airports"]}}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// one published parameter is changed and the other removed
+	customHttpServerHandlerParameters := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/schedules/Nightly/Refresh" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"Refresh","category":"Nightly","description":"Refresh the apartments","enabled":true,"recurrence":{"cron":"0 0 2 * * ?","start":"2026-01-01T00:00:00Z"},"repository":"Samples","workspace":"austinApartments.fmw","publishedParameters":{"COORDSYS":"UTM83-10"}}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"schedules", "update", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "nothing to update",
+			args:        []string{"schedules", "update", "--category", "Nightly", "--name", "Refresh"},
+			wantErrText: "at least one of the flags in the group [description cron interval start end repository workspace published-parameter published-parameter-list parameters-file remove-published-parameter] is required",
+		},
+		{
+			name:        "schedule not found",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"schedules", "update", "--category", "Nightly", "--name", "Missing", "--cron", "0 0 3 * * ?"},
+			wantErrText: "404 Not Found: check that the specified schedule and category exist",
+		},
+		{
+			name:            "update cron",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerCron)),
+			args:            []string{"schedules", "update", "--category", "Nightly", "--name", "Refresh", "--cron", "0 0 3 * * ?"},
+			wantOutputRegex: "^Schedule successfully updated.\n$",
+		},
+		{
+			name:            "switch to interval",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerInterval)),
+			args:            []string{"schedules", "update", "--category", "Nightly", "--name", "Refresh", "--interval", "1h", "--end", "2027-01-01T00:00:00Z"},
+			wantOutputRegex: "^Schedule successfully updated.\n$",
+		},
+		{
+			name:            "update published parameters",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerParameters)),
+			args:            []string{"schedules", "update", "--category", "Nightly", "--name", "Refresh", "--published-parameter", "COORDSYS=UTM83-10", "--remove-published-parameter", "THEMES"},
+			wantOutputRegex: "^Schedule successfully updated.\n$",
+		},
+		{
+			name:        "remove missing published parameter",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"schedules", "update", "--category", "Nightly", "--name", "Refresh", "--remove-published-parameter", "DEST"},
+			wantErrText: "schedule Refresh does not pass the published parameter DEST",
+		},
+		{
+			name:           "update json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"schedules", "update", "--category", "Nightly", "--name", "Refresh", "--description", "New", "--json"},
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+}
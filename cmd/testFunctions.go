@@ -35,6 +35,7 @@ type testCase struct {
 	wantBodyJson       string              // check the JSON body sent
 	fmeflowBuild       int                 // build to pretend we are contacting
 	args               []string            // flags to pass into the command
+	stdin              string              // input to pass to the command on stdin
 	httpServer         *httptest.Server    // custom http test server if needed
 	omitConfig         bool                // set this to true if testing a command with no config file set up
 	omitConfigToken    bool                // set this to true if testing a command that reads from the config file but doesn't require a token
@@ -104,6 +105,7 @@ func runTests(tcs []testCase, t *testing.T) {
 			stdErr := bytes.NewBufferString("")
 			cmd.SetOut(stdOut)
 			cmd.SetErr(stdErr)
+			cmd.SetIn(strings.NewReader(tc.stdin))
 
 			// a bit of a hack to make login work as it needs the URL of the test server
 			for i, s := range tc.args {
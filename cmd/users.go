@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type usersFlags struct {
+	outputType string
+	noHeaders  bool
+}
+
+func newUsersCmd() *cobra.Command {
+	f := usersFlags{}
+	cmd := &cobra.Command{
+		Use:   "users",
+		Short: "List and manage user accounts",
+		Long: `Lists the user accounts on FME Flow. Use the subcommands to describe, create, update, delete, enable and disable accounts and to manage their roles.
+Accounts can be created in bulk from a csv file with "fmeflow users create --file".`,
+		Example: `
+  # List all users
+  fmeflow users
+
+  # Output just the names of the users with no column headers
+  fmeflow users --output=custom-columns=NAME:.name --no-headers
+
+  # Create a user, reading the password from a file
+  fmeflow users create --name jsmith --full-name "Jane Smith" --email jsmith@example.com --password-file password.txt
+
+  # Create the users in a csv file
+  fmeflow users create --file analysts.csv`,
+		Args: NoArgs,
+		RunE: usersRun(&f),
+	}
+
+	addUsersListFlags(cmd, &f)
+	cmd.AddCommand(newUsersListCmd())
+	cmd.AddCommand(newUserDescribeCmd())
+	cmd.AddCommand(newUserCreateCmd())
+	cmd.AddCommand(newUserUpdateCmd())
+	cmd.AddCommand(newUserEnableCmd())
+	cmd.AddCommand(newUserDisableCmd())
+	cmd.AddCommand(newUserDeleteCmd())
+	cmd.AddCommand(newUserRolesCmd())
+	return cmd
+}
+
+func newUsersListCmd() *cobra.Command {
+	f := usersFlags{}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List user accounts",
+		Long:  `Lists the user accounts on FME Flow. This is the same as running "fmeflow users".`,
+		Example: `
+  # List all users
+  fmeflow users list
+
+  # Output all users in json format
+  fmeflow users list --json`,
+		Args: NoArgs,
+		RunE: usersRun(&f),
+	}
+
+	addUsersListFlags(cmd, &f)
+	return cmd
+}
+
+func addUsersListFlags(cmd *cobra.Command, f *usersFlags) {
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+}
+
+func usersRun(f *usersFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		accounts, err := getAllItemsV4[account](client, "/fmeapiv4/accounts")
+		if err != nil {
+			return err
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Name", "Full Name", "Email", "Type", "Enabled", "Super User", "Sharing Enabled"})
+
+			for _, element := range accounts {
+				t.AppendRow(table.Row{element.Name, element.FullName, element.Email, element.Type, element.Enabled, element.IsSuperUser, element.SharingEnabled})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			responseData, err := json.Marshal(accountsResponse{Items: accounts, TotalCount: len(accounts), Limit: len(accounts)})
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			marshalledItems := [][]byte{}
+			for _, element := range accounts {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// get a single account by name, along with the raw json returned for it
+func getAccountV4(client *http.Client, name string) (account, []byte, error) {
+	var result account
+
+	id, err := GetAccountIDByName(name)
+	if err != nil {
+		return result, nil, err
+	}
+
+	request, err := buildFmeFlowRequest("/fmeapiv4/accounts/"+url.PathEscape(id), "GET", nil)
+	if err != nil {
+		return result, nil, err
+	}
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return result, nil, err
+	} else if response.StatusCode != http.StatusOK {
+		if response.StatusCode == http.StatusNotFound {
+			return result, nil, fmt.Errorf("%w: check that the user %s exists", errors.New(response.Status), name)
+		}
+		return result, nil, parseResponseMessage(response)
+	}
+
+	responseData, err := io.ReadAll(response.Body)
+	if err != nil {
+		return result, nil, err
+	}
+
+	err = json.Unmarshal(responseData, &result)
+	return result, responseData, err
+}
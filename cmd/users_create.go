@@ -0,0 +1,344 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type NewAccountV4 struct {
+	Name           string `json:"name"`
+	FullName       string `json:"fullName,omitempty"`
+	Email          string `json:"email,omitempty"`
+	Password       string `json:"password"`
+	IsSuperUser    bool   `json:"isSuperUser"`
+	SharingEnabled bool   `json:"sharingEnabled"`
+	Enabled        bool   `json:"enabled"`
+}
+
+type UserCreateResult struct {
+	Name   string `json:"name"`
+	Action string `json:"action"`
+}
+
+type userCreateFlags struct {
+	name           string
+	fullName       string
+	email          string
+	password       string
+	passwordFile   string
+	passwordStdin  bool
+	passwordEnv    string
+	superUser      bool
+	sharingEnabled bool
+	disabled       bool
+	file           string
+	outputType     string
+	noHeaders      bool
+}
+
+// the columns that can be used in a csv file of users. Column names are matched ignoring case, spaces, dashes and underscores
+var userCSVColumns = []string{"name", "fullName", "email", "password", "superUser", "sharingEnabled", "enabled"}
+
+func newUserCreateCmd() *cobra.Command {
+	f := userCreateFlags{}
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create user accounts",
+		Long: `Create a user account, or create many accounts from a csv file.
+To keep the password out of the shell history and process list, use --password-file, --password-stdin or --password-env instead of --password.
+
+The csv file must have a header row. The name column is required, and the other columns can be any of fullName, email, password, superUser, sharingEnabled and enabled. For example:
+
+  name,fullName,email,password
+  jsmith,Jane Smith,jsmith@example.com,${env:INITIAL_PASSWORD}
+  bjones,Bob Jones,bjones@example.com,
+
+The password, super user, sharing and disabled flags are used for any row that leaves the matching column empty. Accounts that already exist are skipped, so the same file can be used again after adding rows to it.`,
+		Example: `
+  # Create a user, reading the password from a file
+  fmeflow users create --name jsmith --full-name "Jane Smith" --email jsmith@example.com --password-file password.txt
+
+  # Create a super user, reading the password from stdin
+  cat password.txt | fmeflow users create --name admin2 --password-stdin --super-user
+
+  # Create the users in a csv file, giving any without a password the one in the INITIAL_PASSWORD environment variable
+  fmeflow users create --file analysts.csv --password-env INITIAL_PASSWORD`,
+		Args: NoArgs,
+		RunE: userCreateRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the user to create.")
+	cmd.Flags().StringVar(&f.fullName, "full-name", "", "Full name of the user.")
+	cmd.Flags().StringVar(&f.email, "email", "", "Email address of the user.")
+	cmd.Flags().StringVar(&f.password, "password", "", "Password of the user.")
+	cmd.Flags().StringVar(&f.passwordFile, "password-file", "", "Path to a file containing the password of the user.")
+	cmd.Flags().BoolVar(&f.passwordStdin, "password-stdin", false, "Read the password of the user from stdin.")
+	cmd.Flags().StringVar(&f.passwordEnv, "password-env", "", "Name of an environment variable containing the password of the user.")
+	cmd.Flags().BoolVar(&f.superUser, "super-user", false, "Give the user full access to everything on FME Flow.")
+	cmd.Flags().BoolVar(&f.sharingEnabled, "sharing-enabled", true, "Allow the user to share the items they own with other users.")
+	cmd.Flags().BoolVar(&f.disabled, "disabled", false, "Create the user disabled, so that they can't log in until they are enabled.")
+	cmd.Flags().StringVarP(&f.file, "file", "f", "", "Path to a csv file of users to create.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type when creating users from a file. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.MarkFlagsOneRequired("name", "file")
+	cmd.MarkFlagsMutuallyExclusive("name", "file")
+	cmd.MarkFlagsMutuallyExclusive("full-name", "file")
+	cmd.MarkFlagsMutuallyExclusive("email", "file")
+	cmd.MarkFlagsMutuallyExclusive("password", "password-file", "password-stdin", "password-env")
+	return cmd
+}
+
+func userCreateRun(f *userCreateFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		password, err := readPasswordFlags(cmd, f.password, f.passwordFile, f.passwordStdin, f.passwordEnv)
+		if err != nil {
+			return err
+		}
+
+		defaults := NewAccountV4{
+			Password:       password,
+			IsSuperUser:    f.superUser,
+			SharingEnabled: f.sharingEnabled,
+			Enabled:        !f.disabled,
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		if f.file == "" {
+			user := defaults
+			user.Name = f.name
+			user.FullName = f.fullName
+			user.Email = f.email
+			if user.Password == "" {
+				return errors.New("a password is required. Use --password-file, --password-stdin or --password-env to set it")
+			}
+			if _, err := sendFmeFlowJSON(client, "/fmeapiv4/accounts", "POST", user, http.StatusCreated); err != nil {
+				return err
+			}
+			if !jsonOutput {
+				fmt.Fprintln(cmd.OutOrStdout(), "User successfully created.")
+			} else {
+				fmt.Fprintln(cmd.OutOrStdout(), "{}")
+			}
+			return nil
+		}
+
+		if err := checkOutputType(f.outputType); err != nil {
+			return err
+		}
+
+		// read the whole file first so that a mistake on any row stops the command before users are created
+		users, err := readUsersCSV(f.file, defaults)
+		if err != nil {
+			return err
+		}
+
+		accounts, err := getAllItemsV4[account](client, "/fmeapiv4/accounts")
+		if err != nil {
+			return err
+		}
+		existing := map[string]bool{}
+		for _, element := range accounts {
+			existing[strings.ToLower(element.Name)] = true
+		}
+
+		results := []UserCreateResult{}
+		for _, user := range users {
+			result := UserCreateResult{Name: user.Name, Action: "created"}
+			if existing[strings.ToLower(user.Name)] {
+				result.Action = "skipped"
+			} else if _, err := sendFmeFlowJSON(client, "/fmeapiv4/accounts", "POST", user, http.StatusCreated); err != nil {
+				return fmt.Errorf("could not create user %s: %w", user.Name, err)
+			}
+			existing[strings.ToLower(user.Name)] = true
+			results = append(results, result)
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Name", "Action"})
+
+			for _, element := range results {
+				t.AppendRow(table.Row{element.Name, element.Action})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			jsonData, err := json.Marshal(results)
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(jsonData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			marshalledItems := [][]byte{}
+			for _, element := range results {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// read a csv file of users, using the defaults for any empty values
+func readUsersCSV(path string, defaults NewAccountV4) ([]NewAccountV4, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("no users found in %s", path)
+	} else if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", path, err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		column := ""
+		for _, known := range userCSVColumns {
+			if normalizeUserCSVColumn(name) == strings.ToLower(known) {
+				column = known
+			}
+		}
+		if column == "" {
+			return nil, fmt.Errorf("unknown column %q in %s. Must be one of %s", name, path, strings.Join(userCSVColumns, ", "))
+		}
+		columns[column] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("%s must have a name column", path)
+	}
+
+	users := []NewAccountV4{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("could not read %s: %w", path, err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		// expand ${env:NAME} references so that passwords don't have to be kept in the file
+		for i := range record {
+			var missing []string
+			record[i] = manifestEnvRegexp.ReplaceAllStringFunc(record[i], func(reference string) string {
+				name := manifestEnvRegexp.FindStringSubmatch(reference)[1]
+				value, found := os.LookupEnv(name)
+				if !found {
+					missing = append(missing, name)
+				}
+				return value
+			})
+			if len(missing) != 0 {
+				return nil, fmt.Errorf("environment variable %s referenced on line %d of %s is not set", missing[0], line, path)
+			}
+		}
+
+		value := func(column string) string {
+			if i, ok := columns[column]; ok {
+				return record[i]
+			}
+			return ""
+		}
+		flag := func(column string, defaultValue bool) (bool, error) {
+			if value(column) == "" {
+				return defaultValue, nil
+			}
+			result, err := strconv.ParseBool(value(column))
+			if err != nil {
+				return false, fmt.Errorf("invalid value %q for %s on line %d of %s. Must be true or false", value(column), column, line, path)
+			}
+			return result, nil
+		}
+
+		user := defaults
+		user.Name = value("name")
+		user.FullName = value("fullName")
+		user.Email = value("email")
+		if password := value("password"); password != "" {
+			user.Password = password
+		}
+		if user.Name == "" {
+			return nil, fmt.Errorf("missing name on line %d of %s", line, path)
+		}
+		if user.Password == "" {
+			return nil, fmt.Errorf("missing password for user %s on line %d of %s. Add a password column or use --password-file, --password-stdin or --password-env", user.Name, line, path)
+		}
+		if user.IsSuperUser, err = flag("superUser", defaults.IsSuperUser); err != nil {
+			return nil, err
+		}
+		if user.SharingEnabled, err = flag("sharingEnabled", defaults.SharingEnabled); err != nil {
+			return nil, err
+		}
+		if user.Enabled, err = flag("enabled", defaults.Enabled); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("no users found in %s", path)
+	}
+	return users, nil
+}
+
+func normalizeUserCSVColumn(name string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.TrimSpace(name)))
+}
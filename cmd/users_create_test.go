@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersCreate(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("s3cret\n"), 0644))
+	usersFile := filepath.Join(dir, "analysts.csv")
+	require.NoError(t, os.WriteFile(usersFile, []byte("name,Full Name,email,password,super_user\njsmith,Jane Smith,jsmith@example.com,,\nbjones,Bob Jones,bjones@example.com,${env:BJONES_PASSWORD},\nclee,Chris Lee,,,true\n"), 0644))
+	badColumnFile := filepath.Join(dir, "bad-column.csv")
+	require.NoError(t, os.WriteFile(badColumnFile, []byte("name,role\njsmith,analyst\n"), 0644))
+	badValueFile := filepath.Join(dir, "bad-value.csv")
+	require.NoError(t, os.WriteFile(badValueFile, []byte("name,password,enabled\njsmith,pw,yes please\n"), 0644))
+	t.Setenv("BJONES_PASSWORD", "b0b")
+
+	usersListBody := `{
+	  "items": [
+	    {
+	      "id": "7d3b9d43-3b8a-4b52-9a1f-0c4a1d2f6e01",
+	      "name": "admin",
+	      "fullName": "Administrator",
+	      "email": "admin@example.com",
+	      "isSuperUser": true,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    },
+	    {
+	      "id": "c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02",
+	      "name": "jsmith",
+	      "fullName": "Jane Smith",
+	      "email": "jsmith@example.com",
+	      "isSuperUser": false,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    }
+	  ],
+	  "totalCount": 2,
+	  "limit": 2,
+	  "offset": 0
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(usersListBody))
+			require.NoError(t, err)
+		} else if r.Method == "POST" && r.URL.Path == "/fmeapiv4/accounts" {
+			w.WriteHeader(http.StatusCreated)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// creates a super user with a password from a file
+	customHttpServerHandlerSuperUser := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/accounts" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"bjones","fullName":"Bob Jones","password":"s3cret","isSuperUser":true,"sharingEnabled":true,"enabled":true}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// creates a disabled user with a password from stdin
+	customHttpServerHandlerDisabled := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/accounts" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"name":"clee","password":"pa55","isSuperUser":false,"sharingEnabled":false,"enabled":false}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// every user created from the csv file is recorded in created
+	created := []string{}
+	customHttpServerHandlerBulk := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/accounts" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			created = append(created, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	cases := []testCase{
+		{
+			name:        "missing flags",
+			args:        []string{"users", "create"},
+			wantErrText: "at least one of the flags in the group [name file] is required",
+		},
+		{
+			name:        "name and file",
+			args:        []string{"users", "create", "--name", "jsmith", "--file", usersFile},
+			wantErrText: "if any flags in the group [name file] are set none of the others can be; [file name] were all set",
+		},
+		{
+			name:        "missing password",
+			args:        []string{"users", "create", "--name", "bjones"},
+			wantErrText: "a password is required. Use --password-file, --password-stdin or --password-env to set it",
+		},
+		{
+			name:            "create user",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerSuperUser)),
+			args:            []string{"users", "create", "--name", "bjones", "--full-name", "Bob Jones", "--password-file", passwordFile, "--super-user"},
+			wantOutputRegex: "^User successfully created.\n$",
+		},
+		{
+			name:           "create disabled user from stdin",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandlerDisabled)),
+			args:           []string{"users", "create", "--name", "clee", "--password-stdin", "--sharing-enabled=false", "--disabled", "--json"},
+			stdin:          "pa55\n",
+			wantOutputJson: "{}",
+		},
+		{
+			name:        "csv missing password",
+			args:        []string{"users", "create", "--file", usersFile},
+			wantErrText: "missing password for user jsmith on line 2 of " + usersFile + ". Add a password column or use --password-file, --password-stdin or --password-env",
+		},
+		{
+			name:        "csv unknown column",
+			args:        []string{"users", "create", "--file", badColumnFile},
+			wantErrText: "unknown column \"role\" in " + badColumnFile + ". Must be one of name, fullName, email, password, superUser, sharingEnabled, enabled",
+		},
+		{
+			name:        "csv invalid value",
+			args:        []string{"users", "create", "--file", badValueFile},
+			wantErrText: "invalid value \"yes please\" for enabled on line 2 of " + badValueFile + ". Must be true or false",
+		},
+		{
+			name:        "csv invalid output is caught before creating users",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandlerBulk)),
+			args:        []string{"users", "create", "--file", usersFile, "--password-file", passwordFile, "--output", "bogus"},
+			wantErrText: "invalid output format specified",
+		},
+		{
+			name:            "create users from csv",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerBulk)),
+			args:            []string{"users", "create", "--file", usersFile, "--password-file", passwordFile},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*ACTION[\\s]*jsmith[\\s]*skipped[\\s]*bjones[\\s]*created[\\s]*clee[\\s]*created[\\s]*$",
+		},
+	}
+
+	runTests(cases, t)
+
+	// only the users from the one successful csv run are created
+	require.Equal(t, []string{
+		`{"name":"bjones","fullName":"Bob Jones","email":"bjones@example.com","password":"b0b","isSuperUser":false,"sharingEnabled":true,"enabled":true}`,
+		`{"name":"clee","fullName":"Chris Lee","password":"s3cret","isSuperUser":true,"sharingEnabled":true,"enabled":true}`,
+	}, created)
+}
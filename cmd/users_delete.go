@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+type userDeleteFlags struct {
+	name     string
+	noprompt bool
+}
+
+func newUserDeleteCmd() *cobra.Command {
+	f := userDeleteFlags{}
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a user account",
+		Long:  `Delete a user account. To keep the user's account but stop them from logging in, use "fmeflow users disable" instead.`,
+		Example: `
+  # Delete the user "jsmith"
+  fmeflow users delete --name jsmith
+
+  # Delete the user "jsmith" with no confirmation
+  fmeflow users delete --name jsmith --no-prompt`,
+		Args: NoArgs,
+		RunE: userDeleteRun(&f),
+	}
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the user to delete.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func userDeleteRun(f *userDeleteFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		id, err := GetAccountIDByName(f.name)
+		if err != nil {
+			return err
+		}
+
+		if !f.noprompt {
+			// prompt to confirm deletion
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: "Are you sure you want to delete the user " + f.name + "?",
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		if _, err := sendFmeFlowJSON(client, "/fmeapiv4/accounts/"+url.PathEscape(id), "DELETE", nil, http.StatusNoContent, http.StatusOK); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "User successfully deleted.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
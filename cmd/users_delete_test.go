@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersDelete(t *testing.T) {
+	usersListBody := `{
+	  "items": [
+	    {
+	      "id": "7d3b9d43-3b8a-4b52-9a1f-0c4a1d2f6e01",
+	      "name": "admin",
+	      "fullName": "Administrator",
+	      "email": "admin@example.com",
+	      "isSuperUser": true,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    },
+	    {
+	      "id": "c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02",
+	      "name": "jsmith",
+	      "fullName": "Jane Smith",
+	      "email": "jsmith@example.com",
+	      "isSuperUser": false,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    }
+	  ],
+	  "totalCount": 2,
+	  "limit": 2,
+	  "offset": 0
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(usersListBody))
+			require.NoError(t, err)
+		} else if r.Method == "DELETE" && r.URL.Path == "/fmeapiv4/accounts/c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02" {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:        "missing flag",
+			args:        []string{"users", "delete"},
+			wantErrText: "required flag(s) \"name\" not set",
+		},
+		{
+			name:        "user does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"users", "delete", "--name", "bjones", "-y"},
+			wantErrText: "account name 'bjones' not found",
+		},
+		{
+			name:            "delete user",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"users", "delete", "--name", "jsmith", "-y"},
+			wantOutputRegex: "^User successfully deleted.\n$",
+		},
+	}
+
+	runTests(cases, t)
+}
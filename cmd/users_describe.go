@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type userDescribeFlags struct {
+	name       string
+	outputType string
+	noHeaders  bool
+}
+
+func newUserDescribeCmd() *cobra.Command {
+	f := userDescribeFlags{}
+	cmd := &cobra.Command{
+		Use:   "describe",
+		Short: "Show the details of a user account",
+		Long:  `Show the details of a user account.`,
+		Example: `
+  # Describe the user "jsmith"
+  fmeflow users describe --name jsmith
+
+  # Output the user in json format
+  fmeflow users describe --name jsmith --json`,
+		Args: NoArgs,
+		RunE: userDescribeRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the user.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func userDescribeRun(f *userDescribeFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		user, responseData, err := getAccountV4(client, f.name)
+		if err != nil {
+			return err
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Property", "Value"})
+			t.AppendRows([]table.Row{
+				{"ID", user.ID},
+				{"Name", user.Name},
+				{"Full Name", user.FullName},
+				{"Email", user.Email},
+				{"Type", user.Type},
+				{"Enabled", user.Enabled},
+				{"Super User", user.IsSuperUser},
+				{"Sharing Enabled", user.SharingEnabled},
+			})
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns([][]byte{responseData}, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersDescribe(t *testing.T) {
+	usersListBody := `{
+	  "items": [
+	    {
+	      "id": "7d3b9d43-3b8a-4b52-9a1f-0c4a1d2f6e01",
+	      "name": "admin",
+	      "fullName": "Administrator",
+	      "email": "admin@example.com",
+	      "isSuperUser": true,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    },
+	    {
+	      "id": "c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02",
+	      "name": "jsmith",
+	      "fullName": "Jane Smith",
+	      "email": "jsmith@example.com",
+	      "isSuperUser": false,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    }
+	  ],
+	  "totalCount": 2,
+	  "limit": 2,
+	  "offset": 0
+	}`
+
+	userJsmithBody := `{
+	  "id": "c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02",
+	  "name": "jsmith",
+	  "fullName": "Jane Smith",
+	  "email": "jsmith@example.com",
+	  "isSuperUser": false,
+	  "enabled": true,
+	  "sharingEnabled": true,
+	  "type": "fme"
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(usersListBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts/c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(userJsmithBody))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:        "missing flag",
+			args:        []string{"users", "describe"},
+			wantErrText: "required flag(s) \"name\" not set",
+		},
+		{
+			name:        "user does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"users", "describe", "--name", "bjones"},
+			wantErrText: "account name 'bjones' not found",
+		},
+		{
+			name:            "describe user",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"users", "describe", "--name", "jsmith"},
+			wantOutputRegex: "^[\\s]*PROPERTY[\\s]*VALUE[\\s]*ID[\\s]*c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02[\\s]*Name[\\s]*jsmith[\\s]*Full Name[\\s]*Jane Smith[\\s]*Email[\\s]*jsmith@example.com[\\s]*Type[\\s]*fme[\\s]*Enabled[\\s]*true[\\s]*Super User[\\s]*false[\\s]*Sharing Enabled[\\s]*true[\\s]*$",
+		},
+		{
+			name:           "describe user json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"users", "describe", "--name", "jsmith", "--json"},
+			wantOutputJson: userJsmithBody,
+		},
+		{
+			name:            "describe user custom columns",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"users", "describe", "--name", "jsmith", "--output", "custom-columns=EMAIL:.email", "--no-headers"},
+			wantOutputRegex: "^[\\s]*jsmith@example.com[\\s]*$",
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+type userRolesFlags struct {
+	user       string
+	add        []string
+	remove     []string
+	outputType string
+	noHeaders  bool
+}
+
+func newUserRolesCmd() *cobra.Command {
+	f := userRolesFlags{}
+	cmd := &cobra.Command{
+		Use:   "roles",
+		Short: "List, add and remove the roles of a user",
+		Long:  `Lists the roles of a user account. Use --add and --remove to change the roles of the user.`,
+		Example: `
+  # List the roles of the user "jsmith"
+  fmeflow users roles --user jsmith
+
+  # Give the user "jsmith" the role "analysts" and take away the role "publishers"
+  fmeflow users roles --user jsmith --add analysts --remove publishers`,
+		Args: NoArgs,
+		RunE: userRolesRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.user, "user", "", "Name of the user.")
+	cmd.Flags().StringArrayVar(&f.add, "add", []string{}, "Name of a role to give the user. Can be passed in multiple times.")
+	cmd.Flags().StringArrayVar(&f.remove, "remove", []string{}, "Name of a role to take away from the user. Can be passed in multiple times.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.MarkFlagRequired("user")
+	return cmd
+}
+
+func userRolesRun(f *userRolesFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		id, err := GetAccountIDByName(f.user)
+		if err != nil {
+			return err
+		}
+
+		roles, err := getAccountRolesV4(client, id)
+		if err != nil {
+			return err
+		}
+		names := []string{}
+		for _, role := range roles {
+			names = append(names, role.Name)
+		}
+
+		if len(f.add) != 0 || len(f.remove) != 0 {
+			// check everything before changing anything
+			for _, role := range f.add {
+				if _, _, err := getRoleV4(client, role); err != nil {
+					return err
+				}
+			}
+			for _, role := range f.remove {
+				if !slices.Contains(names, role) {
+					return fmt.Errorf("user %s does not have the role %s", f.user, role)
+				}
+			}
+
+			for _, role := range f.add {
+				if slices.Contains(names, role) {
+					continue
+				}
+				if _, err := sendFmeFlowJSON(client, accountRoleEndpoint(id, role), "PUT", nil, http.StatusOK, http.StatusNoContent); err != nil {
+					return err
+				}
+			}
+			for _, role := range f.remove {
+				if _, err := sendFmeFlowJSON(client, accountRoleEndpoint(id, role), "DELETE", nil, http.StatusOK, http.StatusNoContent); err != nil {
+					return err
+				}
+			}
+
+			if !jsonOutput {
+				fmt.Fprintln(cmd.OutOrStdout(), "Roles of user "+f.user+" successfully updated.")
+			} else {
+				fmt.Fprintln(cmd.OutOrStdout(), "{}")
+			}
+			return nil
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Name", "Description"})
+
+			for _, element := range roles {
+				t.AppendRow(table.Row{element.Name, element.Description})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			responseData, err := json.Marshal(RolesV4{Items: roles, TotalCount: len(roles), Limit: len(roles)})
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(responseData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			marshalledItems := [][]byte{}
+			for _, element := range roles {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// get the roles of an account. The roles only have their name and description
+func getAccountRolesV4(client *http.Client, id string) ([]RoleV4, error) {
+	return getAllItemsV4[RoleV4](client, "/fmeapiv4/accounts/"+url.PathEscape(id)+"/roles")
+}
+
+func accountRoleEndpoint(id string, role string) string {
+	return "/fmeapiv4/accounts/" + url.PathEscape(id) + "/roles/" + url.PathEscape(role)
+}
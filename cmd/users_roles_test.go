@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersRoles(t *testing.T) {
+	usersListBody := `{
+	  "items": [
+	    {
+	      "id": "7d3b9d43-3b8a-4b52-9a1f-0c4a1d2f6e01",
+	      "name": "admin",
+	      "fullName": "Administrator",
+	      "email": "admin@example.com",
+	      "isSuperUser": true,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    },
+	    {
+	      "id": "c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02",
+	      "name": "jsmith",
+	      "fullName": "Jane Smith",
+	      "email": "jsmith@example.com",
+	      "isSuperUser": false,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    }
+	  ],
+	  "totalCount": 2,
+	  "limit": 2,
+	  "offset": 0
+	}`
+
+	roleAnalystsBody := `{
+	  "name": "analysts",
+	  "description": "Run the sample workspaces",
+	  "permissions": [
+	    {
+	      "category": "repository",
+	      "item": "Samples",
+	      "action": "read"
+	    },
+	    {
+	      "category": "repository",
+	      "item": "Samples",
+	      "action": "run"
+	    }
+	  ]
+	}`
+
+	roleAuditorsBody := `{
+	  "name": "auditors",
+	  "description": "View jobs and repositories",
+	  "permissions": [
+	    {
+	      "category": "jobs",
+	      "action": "access"
+	    },
+	    {
+	      "category": "repository",
+	      "action": "read"
+	    }
+	  ]
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond.
+	// jsmith already has the role analysts, so only removing auditors is allowed
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(usersListBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts/c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02/roles" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"items": [{"name": "analysts", "description": "Run the sample workspaces"}, {"name": "auditors", "description": "View jobs and repositories"}], "totalCount": 2, "limit": 2, "offset": 0}`))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/roles/analysts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(roleAnalystsBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/roles/auditors" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(roleAuditorsBody))
+			require.NoError(t, err)
+		} else if r.Method == "DELETE" && r.URL.Path == "/fmeapiv4/accounts/c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02/roles/auditors" {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:        "missing flag",
+			args:        []string{"users", "roles"},
+			wantErrText: "required flag(s) \"user\" not set",
+		},
+		{
+			name:            "list roles of user",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"users", "roles", "--user", "jsmith"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*DESCRIPTION[\\s]*analysts[\\s]*Run the sample workspaces[\\s]*auditors[\\s]*View jobs and repositories[\\s]*$",
+		},
+		{
+			name:            "list roles of user custom columns",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"users", "roles", "--user", "jsmith", "--output", "custom-columns=NAME:.name", "--no-headers"},
+			wantOutputRegex: "^[\\s]*analysts[\\s]*auditors[\\s]*$",
+		},
+		{
+			name:        "add role that does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"users", "roles", "--user", "jsmith", "--add", "publishers"},
+			wantErrText: "404 Not Found: check that the role publishers exists",
+		},
+		{
+			name:        "remove role the user does not have",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"users", "roles", "--user", "jsmith", "--remove", "publishers"},
+			wantErrText: "user jsmith does not have the role publishers",
+		},
+		{
+			name:            "add and remove roles",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"users", "roles", "--user", "jsmith", "--add", "analysts", "--remove", "auditors"},
+			wantOutputRegex: "^Roles of user jsmith successfully updated.\n$",
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsers(t *testing.T) {
+	usersListBody := `{
+	  "items": [
+	    {
+	      "id": "7d3b9d43-3b8a-4b52-9a1f-0c4a1d2f6e01",
+	      "name": "admin",
+	      "fullName": "Administrator",
+	      "email": "admin@example.com",
+	      "isSuperUser": true,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    },
+	    {
+	      "id": "c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02",
+	      "name": "jsmith",
+	      "fullName": "Jane Smith",
+	      "email": "jsmith@example.com",
+	      "isSuperUser": false,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    }
+	  ],
+	  "totalCount": 2,
+	  "limit": 2,
+	  "offset": 0
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(usersListBody))
+			require.NoError(t, err)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"users", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"users"},
+		},
+		{
+			name:            "list users",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"users"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*FULL NAME[\\s]*EMAIL[\\s]*TYPE[\\s]*ENABLED[\\s]*SUPER USER[\\s]*SHARING ENABLED[\\s]*admin[\\s]*Administrator[\\s]*admin@example.com[\\s]*fme[\\s]*true[\\s]*true[\\s]*true[\\s]*jsmith[\\s]*Jane Smith[\\s]*jsmith@example.com[\\s]*fme[\\s]*true[\\s]*false[\\s]*true[\\s]*$",
+		},
+		{
+			name:           "list users json",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:           []string{"users", "list", "--json"},
+			wantOutputJson: usersListBody,
+		},
+		{
+			name:            "list users custom columns",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:            []string{"users", "list", "--output", "custom-columns=NAME:.name,EMAIL:.email", "--no-headers"},
+			wantOutputRegex: "^[\\s]*admin[\\s]*admin@example.com[\\s]*jsmith[\\s]*jsmith@example.com[\\s]*$",
+		},
+		{
+			name:        "invalid output",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"users", "--output", "xml"},
+			wantErrText: "invalid output format specified",
+		},
+	}
+
+	runTests(cases, t)
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/spf13/cobra"
+)
+
+type UpdateAccountV4 struct {
+	FullName       string `json:"fullName"`
+	Email          string `json:"email"`
+	Password       string `json:"password,omitempty"`
+	IsSuperUser    bool   `json:"isSuperUser"`
+	SharingEnabled bool   `json:"sharingEnabled"`
+	Enabled        bool   `json:"enabled"`
+}
+
+type userUpdateFlags struct {
+	name           string
+	fullName       string
+	email          string
+	password       string
+	passwordFile   string
+	passwordStdin  bool
+	passwordEnv    string
+	superUser      bool
+	sharingEnabled bool
+}
+
+func newUserUpdateCmd() *cobra.Command {
+	f := userUpdateFlags{}
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update a user account",
+		Long: `Update the details, password or permissions of a user account. Anything that isn't specified is left unchanged.
+To keep the password out of the shell history and process list, use --password-file, --password-stdin or --password-env instead of --password.`,
+		Example: `
+  # Change the email address of the user "jsmith"
+  fmeflow users update --name jsmith --email jane.smith@example.com
+
+  # Reset the password of the user "jsmith", reading it from stdin
+  cat password.txt | fmeflow users update --name jsmith --password-stdin
+
+  # Remove super user access and stop the user from sharing items
+  fmeflow users update --name jsmith --super-user=false --sharing-enabled=false`,
+		Args: NoArgs,
+		RunE: userUpdateRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the user to update.")
+	cmd.Flags().StringVar(&f.fullName, "full-name", "", "Full name of the user.")
+	cmd.Flags().StringVar(&f.email, "email", "", "Email address of the user.")
+	cmd.Flags().StringVar(&f.password, "password", "", "New password of the user.")
+	cmd.Flags().StringVar(&f.passwordFile, "password-file", "", "Path to a file containing the new password of the user.")
+	cmd.Flags().BoolVar(&f.passwordStdin, "password-stdin", false, "Read the new password of the user from stdin.")
+	cmd.Flags().StringVar(&f.passwordEnv, "password-env", "", "Name of an environment variable containing the new password of the user.")
+	cmd.Flags().BoolVar(&f.superUser, "super-user", false, "Whether the user has full access to everything on FME Flow.")
+	cmd.Flags().BoolVar(&f.sharingEnabled, "sharing-enabled", false, "Whether the user can share the items they own with other users.")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagsOneRequired("full-name", "email", "password", "password-file", "password-stdin", "password-env", "super-user", "sharing-enabled")
+	cmd.MarkFlagsMutuallyExclusive("password", "password-file", "password-stdin", "password-env")
+	return cmd
+}
+
+func newUserEnableCmd() *cobra.Command {
+	var name string
+	cmd := &cobra.Command{
+		Use:   "enable",
+		Short: "Enable a user account",
+		Long:  `Enable a user account so that the user can log in.`,
+		Example: `
+  # Enable the user "jsmith"
+  fmeflow users enable --name jsmith`,
+		Args: NoArgs,
+		RunE: userEnabledRun(&name, true),
+	}
+	cmd.Flags().StringVar(&name, "name", "", "Name of the user to enable.")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func newUserDisableCmd() *cobra.Command {
+	var name string
+	cmd := &cobra.Command{
+		Use:   "disable",
+		Short: "Disable a user account",
+		Long:  `Disable a user account so that the user can't log in. The items the user owns are kept and the account can be enabled again later.`,
+		Example: `
+  # Disable the user "jsmith"
+  fmeflow users disable --name jsmith`,
+		Args: NoArgs,
+		RunE: userEnabledRun(&name, false),
+	}
+	cmd.Flags().StringVar(&name, "name", "", "Name of the user to disable.")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func userUpdateRun(f *userUpdateFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		password, err := readPasswordFlags(cmd, f.password, f.passwordFile, f.passwordStdin, f.passwordEnv)
+		if err != nil {
+			return err
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		user, _, err := getAccountV4(client, f.name)
+		if err != nil {
+			return err
+		}
+
+		update := accountUpdate(user)
+		update.Password = password
+		if cmd.Flags().Changed("full-name") {
+			update.FullName = f.fullName
+		}
+		if cmd.Flags().Changed("email") {
+			update.Email = f.email
+		}
+		if cmd.Flags().Changed("super-user") {
+			update.IsSuperUser = f.superUser
+		}
+		if cmd.Flags().Changed("sharing-enabled") {
+			update.SharingEnabled = f.sharingEnabled
+		}
+
+		if _, err := sendFmeFlowJSON(client, "/fmeapiv4/accounts/"+url.PathEscape(user.ID), "PUT", update, http.StatusOK, http.StatusNoContent); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "User successfully updated.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
+
+func userEnabledRun(name *string, enabled bool) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		user, _, err := getAccountV4(client, *name)
+		if err != nil {
+			return err
+		}
+
+		action := "enabled"
+		if !enabled {
+			action = "disabled"
+		}
+
+		if user.Enabled != enabled {
+			update := accountUpdate(user)
+			update.Enabled = enabled
+			if _, err := sendFmeFlowJSON(client, "/fmeapiv4/accounts/"+url.PathEscape(user.ID), "PUT", update, http.StatusOK, http.StatusNoContent); err != nil {
+				return err
+			}
+		}
+
+		if !jsonOutput {
+			if user.Enabled == enabled {
+				fmt.Fprintln(cmd.OutOrStdout(), "User "+*name+" is already "+action+".")
+			} else {
+				fmt.Fprintln(cmd.OutOrStdout(), "User "+*name+" successfully "+action+".")
+			}
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
+
+// the update that leaves an account unchanged
+func accountUpdate(user account) UpdateAccountV4 {
+	return UpdateAccountV4{
+		FullName:       user.FullName,
+		Email:          user.Email,
+		IsSuperUser:    user.IsSuperUser,
+		SharingEnabled: user.SharingEnabled,
+		Enabled:        user.Enabled,
+	}
+}
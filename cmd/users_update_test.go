@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersUpdate(t *testing.T) {
+	usersListBody := `{
+	  "items": [
+	    {
+	      "id": "7d3b9d43-3b8a-4b52-9a1f-0c4a1d2f6e01",
+	      "name": "admin",
+	      "fullName": "Administrator",
+	      "email": "admin@example.com",
+	      "isSuperUser": true,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    },
+	    {
+	      "id": "c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02",
+	      "name": "jsmith",
+	      "fullName": "Jane Smith",
+	      "email": "jsmith@example.com",
+	      "isSuperUser": false,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    }
+	  ],
+	  "totalCount": 2,
+	  "limit": 2,
+	  "offset": 0
+	}`
+
+	userJsmithBody := `{
+	  "id": "c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02",
+	  "name": "jsmith",
+	  "fullName": "Jane Smith",
+	  "email": "jsmith@example.com",
+	  "isSuperUser": false,
+	  "enabled": true,
+	  "sharingEnabled": true,
+	  "type": "fme"
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(usersListBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts/c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(userJsmithBody))
+			require.NoError(t, err)
+		} else if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/accounts/c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02" {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// changes the email and makes the user a super user
+	customHttpServerHandlerUpdate := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/accounts/c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"fullName":"Jane Smith","email":"jane.smith@example.com","isSuperUser":true,"sharingEnabled":true,"enabled":true}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	// resets the password and turns off sharing
+	customHttpServerHandlerPassword := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/accounts/c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"fullName":"Jane Smith","email":"jsmith@example.com","password":"n3w","isSuperUser":false,"sharingEnabled":false,"enabled":true}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	cases := []testCase{
+		{
+			name:        "nothing to update",
+			args:        []string{"users", "update", "--name", "jsmith"},
+			wantErrText: "at least one of the flags in the group [full-name email password password-file password-stdin password-env super-user sharing-enabled] is required",
+		},
+		{
+			name:        "user does not exist",
+			httpServer:  httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+			args:        []string{"users", "update", "--name", "bjones", "--email", "bob@example.com"},
+			wantErrText: "account name 'bjones' not found",
+		},
+		{
+			name:            "update user",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerUpdate)),
+			args:            []string{"users", "update", "--name", "jsmith", "--email", "jane.smith@example.com", "--super-user"},
+			wantOutputRegex: "^User successfully updated.\n$",
+		},
+		{
+			name:           "reset password",
+			httpServer:     httptest.NewServer(http.HandlerFunc(customHttpServerHandlerPassword)),
+			args:           []string{"users", "update", "--name", "jsmith", "--password-stdin", "--sharing-enabled=false", "--json"},
+			stdin:          "n3w",
+			wantOutputJson: "{}",
+		},
+	}
+
+	runTests(cases, t)
+}
+
+func TestUsersEnableDisable(t *testing.T) {
+	usersListBody := `{
+	  "items": [
+	    {
+	      "id": "7d3b9d43-3b8a-4b52-9a1f-0c4a1d2f6e01",
+	      "name": "admin",
+	      "fullName": "Administrator",
+	      "email": "admin@example.com",
+	      "isSuperUser": true,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    },
+	    {
+	      "id": "c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02",
+	      "name": "jsmith",
+	      "fullName": "Jane Smith",
+	      "email": "jsmith@example.com",
+	      "isSuperUser": false,
+	      "enabled": true,
+	      "sharingEnabled": true,
+	      "type": "fme"
+	    }
+	  ],
+	  "totalCount": 2,
+	  "limit": 2,
+	  "offset": 0
+	}`
+
+	userJsmithBody := `{
+	  "id": "c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02",
+	  "name": "jsmith",
+	  "fullName": "Jane Smith",
+	  "email": "jsmith@example.com",
+	  "isSuperUser": false,
+	  "enabled": true,
+	  "sharingEnabled": true,
+	  "type": "fme"
+	}`
+
+	// this is the generic mock up for this test which will respond similar to how FME Flow should respond
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(usersListBody))
+			require.NoError(t, err)
+		} else if r.Method == "GET" && r.URL.Path == "/fmeapiv4/accounts/c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(userJsmithBody))
+			require.NoError(t, err)
+		} else if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/accounts/c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02" {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// the user is already enabled, so it shouldn't be updated
+	customHttpServerHandlerEnable := func(w http.ResponseWriter, r *http.Request) {
+		require.NotEqual(t, "PUT", r.Method, "the user should not have been updated")
+		customHttpServerHandler(w, r)
+	}
+
+	// disables the user and keeps everything else
+	customHttpServerHandlerDisable := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/accounts/c2b1f0e4-5d6a-4f3b-8e2d-9a7c6b5d4e02" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"fullName":"Jane Smith","email":"jsmith@example.com","isSuperUser":false,"sharingEnabled":true,"enabled":false}`, string(body))
+		}
+		customHttpServerHandler(w, r)
+	}
+
+	cases := []testCase{
+		{
+			name:        "missing flag",
+			args:        []string{"users", "disable"},
+			wantErrText: "required flag(s) \"name\" not set",
+		},
+		{
+			name:            "enable enabled user",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerEnable)),
+			args:            []string{"users", "enable", "--name", "jsmith"},
+			wantOutputRegex: "^User jsmith is already enabled.\n$",
+		},
+		{
+			name:            "disable user",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandlerDisable)),
+			args:            []string{"users", "disable", "--name", "jsmith"},
+			wantOutputRegex: "^User jsmith successfully disabled.\n$",
+		},
+	}
+
+	runTests(cases, t)
+}
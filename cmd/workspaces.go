@@ -15,28 +15,30 @@ import (
 )
 
 type FMEFlowWorkspacesV4 struct {
-	Items []struct {
-		AverageCPUPercent      float64   `json:"averageCpuPercent"`
-		AverageCPUTime         float64   `json:"averageCpuTime"`
-		AverageElapsedTime     float64   `json:"averageElapsedTime"`
-		AveragePeakMemoryUsage int       `json:"averagePeakMemoryUsage"`
-		Description            string    `json:"description"`
-		Favorite               bool      `json:"favorite"`
-		FileCount              int       `json:"fileCount"`
-		LastPublishDate        time.Time `json:"lastPublishDate"`
-		LastPublishUser        string    `json:"lastPublishUser"`
-		LastPublishUserID      string    `json:"lastPublishUserId"`
-		LastSaveDate           time.Time `json:"lastSaveDate"`
-		Name                   string    `json:"name"`
-		RepositoryName         string    `json:"repositoryName"`
-		Title                  string    `json:"title"`
-		TotalFileSize          int       `json:"totalFileSize"`
-		TotalRuns              int       `json:"totalRuns"`
-		Type                   string    `json:"type"`
-	} `json:"items"`
-	Limit      int `json:"limit"`
-	Offset     int `json:"offset"`
-	TotalCount int `json:"totalCount"`
+	Items      []FMEFlowWorkspaceV4 `json:"items"`
+	Limit      int                  `json:"limit"`
+	Offset     int                  `json:"offset"`
+	TotalCount int                  `json:"totalCount"`
+}
+
+type FMEFlowWorkspaceV4 struct {
+	AverageCPUPercent      float64   `json:"averageCpuPercent"`
+	AverageCPUTime         float64   `json:"averageCpuTime"`
+	AverageElapsedTime     float64   `json:"averageElapsedTime"`
+	AveragePeakMemoryUsage int       `json:"averagePeakMemoryUsage"`
+	Description            string    `json:"description"`
+	Favorite               bool      `json:"favorite"`
+	FileCount              int       `json:"fileCount"`
+	LastPublishDate        time.Time `json:"lastPublishDate"`
+	LastPublishUser        string    `json:"lastPublishUser"`
+	LastPublishUserID      string    `json:"lastPublishUserId"`
+	LastSaveDate           time.Time `json:"lastSaveDate"`
+	Name                   string    `json:"name"`
+	RepositoryName         string    `json:"repositoryName"`
+	Title                  string    `json:"title"`
+	TotalFileSize          int       `json:"totalFileSize"`
+	TotalRuns              int       `json:"totalRuns"`
+	Type                   string    `json:"type"`
 }
 
 type FMEFlowWorkspaceDetailedV4 struct {
@@ -47,109 +49,23 @@ type FMEFlowWorkspaceDetailedV4 struct {
 	BuildNumber            int     `json:"buildNumber"`
 	Category               string  `json:"category"`
 	Datasets               struct {
-		Destination []struct {
-			FeatureTypes []struct {
-				Attributes []struct {
-					Decimals int    `json:"decimals"`
-					Name     string `json:"name"`
-					Type     string `json:"type"`
-					Width    int    `json:"width"`
-				} `json:"attributes"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-				Properties  []struct {
-					Attributes struct {
-						AdditionalProp1 string `json:"additionalProp1"`
-						AdditionalProp2 string `json:"additionalProp2"`
-						AdditionalProp3 string `json:"additionalProp3"`
-					} `json:"attributes"`
-					Category string `json:"category"`
-					Name     string `json:"name"`
-					Value    string `json:"value"`
-				} `json:"properties"`
-			} `json:"featureTypes"`
-			Format     string `json:"format"`
-			Location   string `json:"location"`
-			Name       string `json:"name"`
-			Properties []struct {
-				Attributes struct {
-					AdditionalProp1 string `json:"additionalProp1"`
-					AdditionalProp2 string `json:"additionalProp2"`
-					AdditionalProp3 string `json:"additionalProp3"`
-				} `json:"attributes"`
-				Category string `json:"category"`
-				Name     string `json:"name"`
-				Value    string `json:"value"`
-			} `json:"properties"`
-			Source bool `json:"source"`
-		} `json:"destination"`
-		Source []struct {
-			FeatureTypes []struct {
-				Attributes []struct {
-					Decimals int    `json:"decimals"`
-					Name     string `json:"name"`
-					Type     string `json:"type"`
-					Width    int    `json:"width"`
-				} `json:"attributes"`
-				Description string `json:"description"`
-				Name        string `json:"name"`
-				Properties  []struct {
-					Attributes struct {
-						AdditionalProp1 string `json:"additionalProp1"`
-						AdditionalProp2 string `json:"additionalProp2"`
-						AdditionalProp3 string `json:"additionalProp3"`
-					} `json:"attributes"`
-					Category string `json:"category"`
-					Name     string `json:"name"`
-					Value    string `json:"value"`
-				} `json:"properties"`
-			} `json:"featureTypes"`
-			Format     string `json:"format"`
-			Location   string `json:"location"`
-			Name       string `json:"name"`
-			Properties []struct {
-				Attributes struct {
-					AdditionalProp1 string `json:"additionalProp1"`
-					AdditionalProp2 string `json:"additionalProp2"`
-					AdditionalProp3 string `json:"additionalProp3"`
-				} `json:"attributes"`
-				Category string `json:"category"`
-				Name     string `json:"name"`
-				Value    string `json:"value"`
-			} `json:"properties"`
-			Source bool `json:"source"`
-		} `json:"source"`
+		Destination []FMEFlowWorkspaceDatasetV4 `json:"destination"`
+		Source      []FMEFlowWorkspaceDatasetV4 `json:"source"`
 	} `json:"datasets"`
-	Description          string    `json:"description"`
-	Favorite             bool      `json:"favorite"`
-	FileSize             int       `json:"fileSize"`
-	History              string    `json:"history"`
-	LastPublishDate      time.Time `json:"lastPublishDate"`
-	LastSaveBuild        string    `json:"lastSaveBuild"`
-	LastSaveDate         time.Time `json:"lastSaveDate"`
-	LegalTermsConditions string    `json:"legalTermsConditions"`
-	Name                 string    `json:"name"`
-	Parameters           []struct {
-		AdditionalProp1 struct {
-		} `json:"additionalProp1"`
-		AdditionalProp2 struct {
-		} `json:"additionalProp2"`
-		AdditionalProp3 struct {
-		} `json:"additionalProp3"`
-	} `json:"parameters"`
-	Properties []struct {
-		Attributes struct {
-			AdditionalProp1 string `json:"additionalProp1"`
-			AdditionalProp2 string `json:"additionalProp2"`
-			AdditionalProp3 string `json:"additionalProp3"`
-		} `json:"attributes"`
-		Category string `json:"category"`
-		Name     string `json:"name"`
-		Value    string `json:"value"`
-	} `json:"properties"`
-	Requirements        string `json:"requirements"`
-	RequirementsKeyword string `json:"requirementsKeyword"`
-	Resources           []struct {
+	Description          string                        `json:"description"`
+	Favorite             bool                          `json:"favorite"`
+	FileSize             int                           `json:"fileSize"`
+	History              string                        `json:"history"`
+	LastPublishDate      time.Time                     `json:"lastPublishDate"`
+	LastSaveBuild        string                        `json:"lastSaveBuild"`
+	LastSaveDate         time.Time                     `json:"lastSaveDate"`
+	LegalTermsConditions string                        `json:"legalTermsConditions"`
+	Name                 string                        `json:"name"`
+	Parameters           []FMEFlowWorkspaceParameterV4 `json:"parameters"`
+	Properties           []FMEFlowWorkspacePropertyV4  `json:"properties"`
+	Requirements         string                        `json:"requirements"`
+	RequirementsKeyword  string                        `json:"requirementsKeyword"`
+	Resources            []struct {
 		Name string `json:"name"`
 		Size int    `json:"size"`
 	} `json:"resources"`
@@ -205,6 +121,53 @@ type FMEFlowWorkspaceDetailedV4 struct {
 	UserName  string `json:"userName"`
 }
 
+type FMEFlowWorkspaceDatasetV4 struct {
+	FeatureTypes []FMEFlowWorkspaceFeatureTypeV4 `json:"featureTypes"`
+	Format       string                          `json:"format"`
+	Location     string                          `json:"location"`
+	Name         string                          `json:"name"`
+	Properties   []FMEFlowWorkspacePropertyV4    `json:"properties"`
+	Source       bool                            `json:"source"`
+}
+
+type FMEFlowWorkspaceFeatureTypeV4 struct {
+	Attributes []struct {
+		Decimals int    `json:"decimals"`
+		Name     string `json:"name"`
+		Type     string `json:"type"`
+		Width    int    `json:"width"`
+	} `json:"attributes"`
+	Description string                       `json:"description"`
+	Name        string                       `json:"name"`
+	Properties  []FMEFlowWorkspacePropertyV4 `json:"properties"`
+}
+
+type FMEFlowWorkspacePropertyV4 struct {
+	Attributes struct {
+		AdditionalProp1 string `json:"additionalProp1"`
+		AdditionalProp2 string `json:"additionalProp2"`
+		AdditionalProp3 string `json:"additionalProp3"`
+	} `json:"attributes"`
+	Category string `json:"category"`
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+}
+
+type FMEFlowWorkspaceParameterV4 struct {
+	Name           string      `json:"name"`
+	Type           string      `json:"type"`
+	Prompt         string      `json:"prompt"`
+	DefaultValue   interface{} `json:"defaultValue"`
+	Required       bool        `json:"required"`
+	ChoiceSettings *struct {
+		ChoiceSet string `json:"choiceSet"`
+		Choices   []struct {
+			Display string `json:"display"`
+			Value   string `json:"value"`
+		} `json:"choices"`
+	} `json:"choiceSettings,omitempty"`
+}
+
 type FMEFlowWorkspacesV3 struct {
 	Offset     int                  `json:"offset"`
 	Limit      int                  `json:"limit"`
@@ -335,8 +298,8 @@ func newWorkspaceCmd() *cobra.Command {
 	f := workspaceFlags{}
 	cmd := &cobra.Command{
 		Use:   "workspaces",
-		Short: "List workspaces.",
-		Long:  `Lists workspaces that exist on the FME Server. Filter by repository, specify a name to retrieve a specific workspace, or specify a filter string to narrow down by name or title.`,
+		Short: "List, publish, download, copy and delete workspaces.",
+		Long:  `Lists workspaces that exist on the FME Server. Filter by repository, specify a name to retrieve a specific workspace, or specify a filter string to narrow down by name or title. Use the subcommands to publish, download, copy, move or delete workspaces, describe their parameters, datasets and feature types, or manage the services they are registered with.`,
 		Example: `
   # List all workspaces on the FME Server
   fmeflow workspaces
@@ -391,6 +354,13 @@ func newWorkspaceCmd() *cobra.Command {
 	cmd.Flags().Var(&f.apiVersion, "api-version", "The api version to use when contacting FME Server. Must be one of v3 or v4")
 	cmd.Flags().MarkHidden("api-version")
 	cmd.RegisterFlagCompletionFunc("api-version", apiVersionFlagCompletion)
+	cmd.AddCommand(newWorkspacePublishCmd())
+	cmd.AddCommand(newWorkspaceDownloadCmd())
+	cmd.AddCommand(newWorkspaceDeleteCmd())
+	cmd.AddCommand(newWorkspaceCopyCmd())
+	cmd.AddCommand(newWorkspaceMoveCmd())
+	cmd.AddCommand(newWorkspaceServicesCmd())
+	cmd.AddCommand(newWorkspaceDescribeCmd())
 	return cmd
 }
 
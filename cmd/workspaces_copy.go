@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type workspaceCopyFlags struct {
+	repository       string
+	name             string
+	targetRepository string
+	targetName       string
+	targetConfig     string
+	overwrite        bool
+	includeResources bool
+	noprompt         bool
+	move             bool
+}
+
+func newWorkspaceCopyCmd() *cobra.Command {
+	f := workspaceCopyFlags{}
+	cmd := &cobra.Command{
+		Use:   "copy",
+		Short: "Copy a workspace to another repository.",
+		Long: `Copy a workspace, its resources and its service registrations to another repository.
+The target repository can be on a different FME Flow by passing in the config file for that FME Flow with --target-config. Create that config file by running "fmeflow login" with the --config flag.`,
+		Example: `
+  # Copy the workspace "austinApartments.fmw" from the Samples repository to the repository "Production"
+  fmeflow workspaces copy --repository Samples --name austinApartments.fmw --target-repository Production
+
+  # Copy a workspace to a repository of the same name on another FME Flow, overwriting it if it exists
+  fmeflow workspaces copy --repository Samples --name austinApartments.fmw --target-config prod-config.yaml --overwrite
+
+  # Copy a workspace within the same repository with a new name
+  fmeflow workspaces copy --repository Samples --name austinApartments.fmw --target-name austinApartmentsCopy.fmw`,
+		Args: NoArgs,
+		RunE: workspaceCopyRun(&f),
+	}
+	addWorkspaceCopyFlags(cmd, &f)
+	return cmd
+}
+
+func newWorkspaceMoveCmd() *cobra.Command {
+	f := workspaceCopyFlags{move: true}
+	cmd := &cobra.Command{
+		Use:   "move",
+		Short: "Move a workspace to another repository.",
+		Long: `Move a workspace, its resources and its service registrations to another repository. The workspace is copied to the target and then deleted from the source repository.
+The target repository can be on a different FME Flow by passing in the config file for that FME Flow with --target-config. Create that config file by running "fmeflow login" with the --config flag.`,
+		Example: `
+  # Move the workspace "austinApartments.fmw" from the Samples repository to the repository "Archive"
+  fmeflow workspaces move --repository Samples --name austinApartments.fmw --target-repository Archive
+
+  # Move a workspace to another FME Flow with no confirmation
+  fmeflow workspaces move --repository Samples --name austinApartments.fmw --target-config prod-config.yaml --no-prompt`,
+		Args: NoArgs,
+		RunE: workspaceCopyRun(&f),
+	}
+	addWorkspaceCopyFlags(cmd, &f)
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	return cmd
+}
+
+// the copy and move commands share the same flags
+func addWorkspaceCopyFlags(cmd *cobra.Command, f *workspaceCopyFlags) {
+	cmd.Flags().StringVar(&f.repository, "repository", "", "Name of the repository containing the workspace.")
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the workspace.")
+	cmd.Flags().StringVar(&f.targetRepository, "target-repository", "", "Name of the repository to copy the workspace to. Defaults to the source repository.")
+	cmd.Flags().StringVar(&f.targetName, "target-name", "", "Name of the workspace in the target repository. Defaults to the source workspace name.")
+	cmd.Flags().StringVar(&f.targetConfig, "target-config", "", "Config file of the FME Flow to copy the workspace to. Defaults to the current FME Flow.")
+	cmd.Flags().BoolVar(&f.overwrite, "overwrite", false, "Overwrite the workspace if it already exists in the target repository.")
+	cmd.Flags().BoolVar(&f.includeResources, "include-resources", true, "Also copy the resource files published with the workspace.")
+	cmd.MarkFlagRequired("repository")
+	cmd.MarkFlagRequired("name")
+}
+
+func workspaceCopyRun(f *workspaceCopyFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		if f.targetRepository == "" {
+			f.targetRepository = f.repository
+		}
+		if f.targetName == "" {
+			f.targetName = f.name
+		}
+
+		sourceConfig := viper.GetViper()
+		targetConfig := sourceConfig
+		if f.targetConfig != "" {
+			var err error
+			targetConfig, err = loadFmeFlowConfig(f.targetConfig)
+			if err != nil {
+				return err
+			}
+		} else if f.targetRepository == f.repository && f.targetName == f.name {
+			return errors.New("the target is the same as the source. Specify a different target repository, target name or target config")
+		}
+
+		if f.move && !f.noprompt {
+			// prompt to confirm the move as it deletes the source
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: "Are you sure you want to move the workspace " + f.name + " out of repository " + f.repository + "?",
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		// download everything to a temporary directory before uploading
+		dir, err := os.MkdirTemp("", "fmeflow-workspace")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+
+		workspace, err := getWorkspaceDetailed(client, sourceConfig, f.repository, f.name)
+		if err != nil {
+			return err
+		}
+
+		workspaceFile := filepath.Join(dir, f.targetName)
+		url := "/fmeapiv4/repositories/" + f.repository + "/items/" + f.name + "/download"
+		if err := downloadRepositoryFile(client, sourceConfig, url, workspaceFile); err != nil {
+			return err
+		}
+
+		url = "/fmeapiv4/repositories/" + f.targetRepository + "/items"
+		if err := uploadRepositoryFile(client, targetConfig, url, workspaceFile, f.overwrite); err != nil {
+			return err
+		}
+
+		if f.includeResources {
+			resourceDir := filepath.Join(dir, "resources")
+			if err := os.Mkdir(resourceDir, 0700); err != nil {
+				return err
+			}
+			for _, resource := range workspace.Resources {
+				resourceFile := filepath.Join(resourceDir, resource.Name)
+				url := "/fmeapiv4/repositories/" + f.repository + "/items/" + f.name + "/resources/" + resource.Name + "/download"
+				if err := downloadRepositoryFile(client, sourceConfig, url, resourceFile); err != nil {
+					return err
+				}
+				url = "/fmeapiv4/repositories/" + f.targetRepository + "/items/" + f.targetName + "/resources"
+				if err := uploadRepositoryFile(client, targetConfig, url, resourceFile, f.overwrite); err != nil {
+					return err
+				}
+			}
+		}
+
+		// carry over the service registrations
+		var services WorkspaceServicesV4
+		if workspace.Services.JobSubmitter.Registered {
+			services.JobSubmitter = &WorkspaceServiceRegistrationV4{Registered: true, Reader: workspace.Services.JobSubmitter.Reader}
+		}
+		if workspace.Services.DataDownload.Registered {
+			services.DataDownload = &WorkspaceServiceRegistrationV4{Registered: true, Reader: workspace.Services.DataDownload.Reader, Writers: workspace.Services.DataDownload.Writers}
+		}
+		if workspace.Services.DataStreaming.Registered {
+			services.DataStreaming = &WorkspaceServiceRegistrationV4{Registered: true, Reader: workspace.Services.DataStreaming.Reader, Writers: workspace.Services.DataStreaming.Writers}
+		}
+		if workspace.Services.KmlNetworkLink.Registered {
+			kml := workspace.Services.KmlNetworkLink
+			services.KmlNetworkLink = &WorkspaceServiceRegistrationV4{Registered: true, Writers: kml.Writers, Name: kml.Name, Description: kml.Description, Visibility: kml.Visibility}
+		}
+		if !isEmpty(services) {
+			if err := registerWorkspaceServices(client, targetConfig, f.targetRepository, f.targetName, services); err != nil {
+				return err
+			}
+		}
+
+		if f.move {
+			request, err := buildFmeFlowRequest("/fmeapiv4/repositories/"+f.repository+"/items/"+f.name, "DELETE", nil)
+			if err != nil {
+				return err
+			}
+			response, err := client.Do(&request)
+			if err != nil {
+				return err
+			} else if response.StatusCode != http.StatusNoContent {
+				return fmt.Errorf("workspace was copied but could not be deleted from the source repository: %w", parseResponseMessage(response))
+			}
+		}
+
+		if !jsonOutput {
+			if f.move {
+				fmt.Fprintln(cmd.OutOrStdout(), "Workspace successfully moved.")
+			} else {
+				fmt.Fprintln(cmd.OutOrStdout(), "Workspace successfully copied.")
+			}
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspacesCopy(t *testing.T) {
+	workspaceDetail := `{
+		"name": "austinDownload.fmw",
+		"resources": [
+		  {
+			"name": "landmarks.sqlite",
+			"size": 8
+		  }
+		],
+		"services": {
+		  "dataDownload": {
+			"registered": true
+		  },
+		  "jobSubmitter": {
+			"registered": true
+		  }
+		}
+	  }`
+
+	// the source server serves the workspace and accepts uploads to other repositories
+	sourceHandler := func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/fmeapiv4/workspaces/Samples/austinDownload.fmw":
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(workspaceDetail))
+			require.NoError(t, err)
+		case r.Method == "GET" && r.URL.Path == "/fmeapiv4/repositories/Samples/items/austinDownload.fmw/download":
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte("workspace contents"))
+			require.NoError(t, err)
+		case r.Method == "GET" && r.URL.Path == "/fmeapiv4/repositories/Samples/items/austinDownload.fmw/resources/landmarks.sqlite/download":
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte("resource"))
+			require.NoError(t, err)
+		case r.Method == "POST" && r.URL.Path == "/fmeapiv4/repositories/Production/items":
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == "POST" && r.URL.Path == "/fmeapiv4/repositories/Production/items/austinDownload.fmw/resources":
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == "PUT" && r.URL.Path == "/fmeapiv4/workspaces/Production/austinDownload.fmw/services":
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"jobSubmitter":{"registered":true},"dataDownload":{"registered":true}}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "DELETE" && r.URL.Path == "/fmeapiv4/repositories/Samples/items/austinDownload.fmw":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// the target server only accepts uploads into the Samples repository
+	targetServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "fmetoken token=target-token", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/fmeapiv4/repositories/Samples/items":
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == "POST" && r.URL.Path == "/fmeapiv4/repositories/Samples/items/austinDownload.fmw/resources":
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == "PUT" && r.URL.Path == "/fmeapiv4/workspaces/Samples/austinDownload.fmw/services":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer targetServer.Close()
+
+	targetConfig, err := os.CreateTemp("", "target-config*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(targetConfig.Name())
+	_, err = targetConfig.WriteString("build: 25208\ntoken: target-token\nurl: " + targetServer.URL + "\n")
+	require.NoError(t, err)
+	require.NoError(t, targetConfig.Close())
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"workspaces", "copy", "--repository", "Samples", "--name", "austinDownload.fmw", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flag",
+			wantErrText: "required flag(s) \"name\", \"repository\" not set",
+			args:        []string{"workspaces", "copy"},
+		},
+		{
+			name:        "copy to same location",
+			wantErrText: "the target is the same as the source. Specify a different target repository, target name or target config",
+			args:        []string{"workspaces", "copy", "--repository", "Samples", "--name", "austinDownload.fmw"},
+		},
+		{
+			name:        "missing target config",
+			wantErrText: "open does-not-exist.yaml: no such file or directory: could not read the config file does-not-exist.yaml. Have you called the login command? ",
+			args:        []string{"workspaces", "copy", "--repository", "Samples", "--name", "austinDownload.fmw", "--target-config", "does-not-exist.yaml"},
+		},
+		{
+			name:            "copy to another repository",
+			args:            []string{"workspaces", "copy", "--repository", "Samples", "--name", "austinDownload.fmw", "--target-repository", "Production"},
+			wantOutputRegex: "^Workspace successfully copied.[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(sourceHandler)),
+		},
+		{
+			name:            "copy to another server",
+			args:            []string{"workspaces", "copy", "--repository", "Samples", "--name", "austinDownload.fmw", "--target-config", targetConfig.Name()},
+			wantOutputRegex: "^Workspace successfully copied.[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(sourceHandler)),
+		},
+		{
+			name:            "move to another repository",
+			args:            []string{"workspaces", "move", "--repository", "Samples", "--name", "austinDownload.fmw", "--target-repository", "Production", "--no-prompt"},
+			wantOutputRegex: "^Workspace successfully moved.[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(sourceHandler)),
+		},
+		{
+			name:        "copy workspace not found",
+			statusCode:  http.StatusNotFound,
+			wantErrText: "404 Not Found: check that the specified repository and workspace exist",
+			args:        []string{"workspaces", "copy", "--repository", "Samples", "--name", "austinDownload.fmw", "--target-repository", "Production"},
+		},
+	}
+
+	runTests(cases, t)
+
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+type workspaceDeleteFlags struct {
+	repository string
+	name       string
+	noprompt   bool
+}
+
+func newWorkspaceDeleteCmd() *cobra.Command {
+	f := workspaceDeleteFlags{}
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a workspace from a repository.",
+		Long:  `Delete a workspace and its resources from a repository.`,
+		Example: `
+  # Delete the workspace "myWorkspace.fmw" from the repository "MyRepository"
+  fmeflow workspaces delete --repository MyRepository --name myWorkspace.fmw
+
+  # Delete the workspace "myWorkspace.fmw" from the repository "MyRepository" with no confirmation
+  fmeflow workspaces delete --repository MyRepository --name myWorkspace.fmw --no-prompt`,
+		Args: NoArgs,
+		RunE: workspaceDeleteRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.repository, "repository", "", "Name of the repository containing the workspace.")
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the workspace to delete.")
+	cmd.Flags().BoolVarP(&f.noprompt, "no-prompt", "y", false, "Do not prompt for confirmation.")
+	cmd.MarkFlagRequired("repository")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func workspaceDeleteRun(f *workspaceDeleteFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+
+		// set up http
+		client := &http.Client{}
+
+		if !f.noprompt {
+			// prompt to confirm deletion
+			confirm := false
+			promptUser := &survey.Confirm{
+				Message: "Are you sure you want to delete the workspace " + f.name + " from repository " + f.repository + "?",
+			}
+			survey.AskOne(promptUser, &confirm)
+			if !confirm {
+				return nil
+			}
+		}
+
+		request, err := buildFmeFlowRequest("/fmeapiv4/repositories/"+f.repository+"/items/"+f.name, "DELETE", nil)
+		if err != nil {
+			return err
+		}
+
+		response, err := client.Do(&request)
+		if err != nil {
+			return err
+		} else if response.StatusCode != http.StatusNoContent {
+			// attempt to parse the body into JSON as there could be a valuable message in there
+			// if fail, just output the status code
+			responseData, err := io.ReadAll(response.Body)
+			if err == nil {
+				var responseMessage Message
+				if err := json.Unmarshal(responseData, &responseMessage); err == nil {
+
+					// if json output is requested, output the JSON to stdout before erroring
+					if jsonOutput {
+						prettyJSON, err := prettyPrintJSON(responseData)
+						if err == nil {
+							fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+						} else {
+							return errors.New(response.Status)
+						}
+					}
+					return errors.New(responseMessage.Message)
+				} else {
+					return errors.New(response.Status)
+				}
+			} else {
+				return errors.New(response.Status)
+			}
+		}
+
+		if !jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "Workspace successfully deleted.")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestWorkspacesDelete(t *testing.T) {
+	workspaceMissingBody := `{
+		"message": "Unauthorized request by user admin due to lack of proper permissions or the object does not exist."
+	  }`
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"workspaces", "delete", "--repository", "MyRepo", "--name", "my.fmw", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "500 Internal Server Error",
+			args:        []string{"workspaces", "delete", "--repository", "MyRepo", "--name", "my.fmw", "--no-prompt"},
+		},
+		{
+			name:        "missing flag",
+			wantErrText: "required flag(s) \"name\", \"repository\" not set",
+			args:        []string{"workspaces", "delete"},
+		},
+		{
+			name:            "delete workspace",
+			statusCode:      http.StatusNoContent,
+			args:            []string{"workspaces", "delete", "--repository", "MyRepo", "--name", "my.fmw", "--no-prompt"},
+			wantOutputRegex: "^Workspace successfully deleted.[\\s]*$",
+			wantURLContains: "/fmeapiv4/repositories/MyRepo/items/my.fmw",
+		},
+		{
+			name:        "delete workspace not found",
+			statusCode:  http.StatusNotFound,
+			body:        workspaceMissingBody,
+			args:        []string{"workspaces", "delete", "--repository", "MyRepo", "--name", "my.fmw", "--no-prompt"},
+			wantErrText: "Unauthorized request by user admin due to lack of proper permissions or the object does not exist.",
+		},
+	}
+
+	runTests(cases, t)
+
+}
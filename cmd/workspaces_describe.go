@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+type WorkspaceDatasetSummaryV4 struct {
+	Name         string `json:"name"`
+	Direction    string `json:"direction"`
+	Format       string `json:"format"`
+	Location     string `json:"location"`
+	FeatureTypes int    `json:"featureTypes"`
+}
+
+type WorkspaceFeatureTypeAttributeV4 struct {
+	Dataset     string `json:"dataset"`
+	Direction   string `json:"direction"`
+	FeatureType string `json:"featureType"`
+	Attribute   string `json:"attribute"`
+	Type        string `json:"type"`
+	Width       int    `json:"width"`
+	Decimals    int    `json:"decimals"`
+}
+
+type workspaceDescribeFlags struct {
+	repository string
+	name       string
+	show       string
+	outputType string
+	noHeaders  bool
+}
+
+var workspaceDescribeShowModes = []string{"parameters", "datasets", "featuretypes", "properties"}
+
+func newWorkspaceDescribeCmd() *cobra.Command {
+	f := workspaceDescribeFlags{}
+	cmd := &cobra.Command{
+		Use:   "describe",
+		Short: "Show the parameters, datasets, feature types or properties of a workspace.",
+		Long: `Show the details of a workspace as a table. Use --show to choose what to describe:
+  parameters: the published parameters of the workspace
+  datasets: the source and destination datasets read and written by the workspace
+  featuretypes: the attributes of each feature type in the source and destination datasets
+  properties: the properties of the workspace, such as service settings
+When showing parameters, --output yaml outputs a template of the published parameters and their default values that can be edited and passed to "fmeflow run" with --parameters-file.`,
+		Example: `
+  # Show the published parameters of the workspace "austinDownload.fmw"
+  fmeflow workspaces describe --repository Samples --name austinDownload.fmw
+
+  # Show the source and destination datasets of the workspace
+  fmeflow workspaces describe --repository Samples --name austinDownload.fmw --show datasets
+
+  # Show the names and types of the attributes of each feature type
+  fmeflow workspaces describe --repository Samples --name austinDownload.fmw --show featuretypes --output=custom-columns=FEATURE TYPE:.featureType,ATTRIBUTE:.attribute,TYPE:.type
+
+  # Create a parameters file and use it to run the workspace
+  fmeflow workspaces describe --repository Samples --name austinDownload.fmw --show parameters --output yaml > params.yaml
+  fmeflow run --repository Samples --workspace austinDownload.fmw --parameters-file params.yaml`,
+		Args: NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains(workspaceDescribeShowModes, f.show) {
+				return fmt.Errorf("invalid value %q for --show. Must be one of %s", f.show, strings.Join(workspaceDescribeShowModes, ", "))
+			}
+			if f.outputType == "yaml" && f.show != "parameters" {
+				return errors.New("yaml output is only supported when showing parameters")
+			}
+			return nil
+		},
+		RunE: workspaceDescribeRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.repository, "repository", "", "Name of the repository containing the workspace.")
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the workspace.")
+	cmd.Flags().StringVar(&f.show, "show", "parameters", "What to describe. Should be one of parameters, datasets, featuretypes or properties.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, custom-columns or yaml. yaml is only supported when showing parameters.")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.RegisterFlagCompletionFunc("show", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return workspaceDescribeShowModes, cobra.ShellCompDirectiveDefault
+	})
+	cmd.MarkFlagRequired("repository")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func workspaceDescribeRun(f *workspaceDescribeFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		workspace, err := getWorkspaceDetailed(client, viper.GetViper(), f.repository, f.name)
+		if err != nil {
+			return err
+		}
+
+		if f.outputType == "yaml" {
+			template, err := workspaceParametersTemplate(f.repository, workspace)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), template)
+			return nil
+		}
+
+		// build up the rows and items for the selected mode
+		var header table.Row
+		rows := []table.Row{}
+		items := []interface{}{}
+		switch f.show {
+		case "parameters":
+			header = table.Row{"Name", "Type", "Prompt", "Default", "Required", "Choices"}
+			for _, parameter := range workspace.Parameters {
+				choices := []string{}
+				if parameter.ChoiceSettings != nil {
+					for _, choice := range parameter.ChoiceSettings.Choices {
+						choices = append(choices, choice.Value)
+					}
+				}
+				rows = append(rows, table.Row{parameter.Name, parameter.Type, parameter.Prompt, formatParameterValue(parameter.DefaultValue), parameter.Required, strings.Join(choices, ", ")})
+				items = append(items, parameter)
+			}
+		case "datasets":
+			header = table.Row{"Name", "Direction", "Format", "Location", "Feature Types"}
+			for _, dataset := range workspaceDatasets(workspace) {
+				summary := WorkspaceDatasetSummaryV4{Name: dataset.Name, Direction: datasetDirection(dataset), Format: dataset.Format, Location: dataset.Location, FeatureTypes: len(dataset.FeatureTypes)}
+				rows = append(rows, table.Row{summary.Name, summary.Direction, summary.Format, summary.Location, summary.FeatureTypes})
+				items = append(items, summary)
+			}
+		case "featuretypes":
+			header = table.Row{"Dataset", "Direction", "Feature Type", "Attribute", "Type", "Width", "Decimals"}
+			for _, dataset := range workspaceDatasets(workspace) {
+				for _, featureType := range dataset.FeatureTypes {
+					for _, attribute := range featureType.Attributes {
+						row := WorkspaceFeatureTypeAttributeV4{Dataset: dataset.Name, Direction: datasetDirection(dataset), FeatureType: featureType.Name, Attribute: attribute.Name, Type: attribute.Type, Width: attribute.Width, Decimals: attribute.Decimals}
+						rows = append(rows, table.Row{row.Dataset, row.Direction, row.FeatureType, row.Attribute, row.Type, row.Width, row.Decimals})
+						items = append(items, row)
+					}
+				}
+			}
+		case "properties":
+			header = table.Row{"Category", "Name", "Value"}
+			for _, property := range workspace.Properties {
+				rows = append(rows, table.Row{property.Category, property.Name, property.Value})
+				items = append(items, property)
+			}
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(header)
+			t.AppendRows(rows)
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			jsonData, err := json.Marshal(items)
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(jsonData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			// we have to marshal the Items array, then create an array of marshalled items
+			// to pass to the creation of the table.
+			marshalledItems := [][]byte{}
+			for _, element := range items {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// source datasets followed by destination datasets
+func workspaceDatasets(workspace FMEFlowWorkspaceDetailedV4) []FMEFlowWorkspaceDatasetV4 {
+	return append(slices.Clone(workspace.Datasets.Source), workspace.Datasets.Destination...)
+}
+
+func datasetDirection(dataset FMEFlowWorkspaceDatasetV4) string {
+	if dataset.Source {
+		return "source"
+	}
+	return "destination"
+}
+
+// format a parameter value for display in a table
+func formatParameterValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		values := []string{}
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return strings.Join(values, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// create a yaml file of the published parameters and their default values, with the
+// type, prompt and choices of each parameter as a comment. The output can be passed to
+// the run command with --parameters-file
+func workspaceParametersTemplate(repository string, workspace FMEFlowWorkspaceDetailedV4) (string, error) {
+	document := &yaml.Node{Kind: yaml.MappingNode}
+	for _, parameter := range workspace.Parameters {
+		comment := parameter.Name + " (" + parameter.Type
+		if parameter.Required {
+			comment += ", required"
+		}
+		comment += ")"
+		if parameter.Prompt != "" {
+			comment += ": " + parameter.Prompt
+		}
+		if parameter.ChoiceSettings != nil && len(parameter.ChoiceSettings.Choices) != 0 {
+			choices := []string{}
+			for _, choice := range parameter.ChoiceSettings.Choices {
+				choices = append(choices, choice.Value)
+			}
+			comment += "\nChoices: " + strings.Join(choices, ", ")
+		}
+
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: parameter.Name, HeadComment: comment}
+		value := &yaml.Node{}
+		defaultValue := parameter.DefaultValue
+		if defaultValue == nil {
+			defaultValue = ""
+		}
+		if err := value.Encode(defaultValue); err != nil {
+			return "", err
+		}
+		document.Content = append(document.Content, key, value)
+	}
+
+	header := "Published parameters for " + repository + "/" + workspace.Name + "\n" +
+		"Use with: fmeflow run --repository " + repository + " --workspace " + workspace.Name + " --parameters-file <file>"
+	if len(document.Content) == 0 {
+		return "# " + strings.ReplaceAll(header, "\n", "\n# ") + "\n{}\n", nil
+	}
+	document.HeadComment = header
+
+	var out strings.Builder
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestWorkspacesDescribe(t *testing.T) {
+	workspaceDetail := `{
+		"name": "austinDownload.fmw",
+		"datasets": {
+		  "source": [
+			{
+			  "format": "SQLITE3FDO",
+			  "location": "$(FME_SHAREDRESOURCE_DATA)/Landmarks.sqlite",
+			  "name": "SQLITE3FDO_1",
+			  "source": true,
+			  "featureTypes": [
+				{
+				  "name": "railroad",
+				  "description": "",
+				  "attributes": [
+					{
+					  "name": "OBJECTID",
+					  "type": "fme_int32",
+					  "width": 0,
+					  "decimals": 0
+					},
+					{
+					  "name": "NAME",
+					  "type": "fme_varchar",
+					  "width": 50,
+					  "decimals": 0
+					}
+				  ],
+				  "properties": []
+				}
+			  ],
+			  "properties": []
+			}
+		  ],
+		  "destination": [
+			{
+			  "format": "GEOJSON",
+			  "location": "$(FME_SHAREDRESOURCE_TEMP)/output.json",
+			  "name": "GEOJSON_1",
+			  "source": false,
+			  "featureTypes": [],
+			  "properties": []
+			}
+		  ]
+		},
+		"parameters": [
+		  {
+			"name": "THEMES",
+			"type": "LISTBOX",
+			"prompt": "Layers to download",
+			"defaultValue": [
+			  "railroad"
+			],
+			"required": true,
+			"choiceSettings": {
+			  "choiceSet": "userDefined",
+			  "choices": [
+				{
+				  "display": "Railroads",
+				  "value": "railroad"
+				},
+				{
+				  "display": "Airports",
+				  "value": "airports"
+				}
+			  ]
+			}
+		  },
+		  {
+			"name": "COORDSYS",
+			"type": "COORDSYS",
+			"prompt": "Output coordinate system",
+			"defaultValue": "TX83-CF",
+			"required": false
+		  }
+		],
+		"properties": [
+		  {
+			"name": "OUTPUT_WRITER",
+			"attributes": {},
+			"category": "fmedatastreaming_FMEUSERPROPDATA",
+			"value": "OGCKML_1"
+		  }
+		]
+	  }`
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"workspaces", "describe", "--repository", "Samples", "--name", "austinDownload.fmw", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flag",
+			wantErrText: "required flag(s) \"name\", \"repository\" not set",
+			args:        []string{"workspaces", "describe"},
+		},
+		{
+			name:        "invalid show",
+			wantErrText: "invalid value \"resources\" for --show. Must be one of parameters, datasets, featuretypes, properties",
+			args:        []string{"workspaces", "describe", "--repository", "Samples", "--name", "austinDownload.fmw", "--show", "resources"},
+		},
+		{
+			name:        "yaml output for datasets",
+			wantErrText: "yaml output is only supported when showing parameters",
+			args:        []string{"workspaces", "describe", "--repository", "Samples", "--name", "austinDownload.fmw", "--show", "datasets", "--output", "yaml"},
+		},
+		{
+			name:        "workspace not found",
+			statusCode:  http.StatusNotFound,
+			wantErrText: "404 Not Found: check that the specified repository and workspace exist",
+			args:        []string{"workspaces", "describe", "--repository", "Samples", "--name", "austinDownload.fmw"},
+		},
+		{
+			name:            "show parameters",
+			statusCode:      http.StatusOK,
+			body:            workspaceDetail,
+			args:            []string{"workspaces", "describe", "--repository", "Samples", "--name", "austinDownload.fmw"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*TYPE[\\s]*PROMPT[\\s]*DEFAULT[\\s]*REQUIRED[\\s]*CHOICES[\\s]*THEMES[\\s]*LISTBOX[\\s]*Layers to download[\\s]*railroad[\\s]*true[\\s]*railroad, airports[\\s]*COORDSYS[\\s]*COORDSYS[\\s]*Output coordinate system[\\s]*TX83-CF[\\s]*false[\\s]*$",
+			wantURLContains: "/fmeapiv4/workspaces/Samples/austinDownload.fmw",
+		},
+		{
+			name:            "show datasets",
+			statusCode:      http.StatusOK,
+			body:            workspaceDetail,
+			args:            []string{"workspaces", "describe", "--repository", "Samples", "--name", "austinDownload.fmw", "--show", "datasets"},
+			wantOutputRegex: "^[\\s]*NAME[\\s]*DIRECTION[\\s]*FORMAT[\\s]*LOCATION[\\s]*FEATURE TYPES[\\s]*SQLITE3FDO_1[\\s]*source[\\s]*SQLITE3FDO[\\s]*\\$\\(FME_SHAREDRESOURCE_DATA\\)/Landmarks.sqlite[\\s]*1[\\s]*GEOJSON_1[\\s]*destination[\\s]*GEOJSON[\\s]*\\$\\(FME_SHAREDRESOURCE_TEMP\\)/output.json[\\s]*0[\\s]*$",
+		},
+		{
+			name:            "show feature types",
+			statusCode:      http.StatusOK,
+			body:            workspaceDetail,
+			args:            []string{"workspaces", "describe", "--repository", "Samples", "--name", "austinDownload.fmw", "--show", "featuretypes", "--no-headers"},
+			wantOutputRegex: "^[\\s]*SQLITE3FDO_1[\\s]*source[\\s]*railroad[\\s]*OBJECTID[\\s]*fme_int32[\\s]*0[\\s]*0[\\s]*SQLITE3FDO_1[\\s]*source[\\s]*railroad[\\s]*NAME[\\s]*fme_varchar[\\s]*50[\\s]*0[\\s]*$",
+		},
+		{
+			name:            "show properties",
+			statusCode:      http.StatusOK,
+			body:            workspaceDetail,
+			args:            []string{"workspaces", "describe", "--repository", "Samples", "--name", "austinDownload.fmw", "--show", "properties"},
+			wantOutputRegex: "^[\\s]*CATEGORY[\\s]*NAME[\\s]*VALUE[\\s]*fmedatastreaming_FMEUSERPROPDATA[\\s]*OUTPUT_WRITER[\\s]*OGCKML_1[\\s]*$",
+		},
+		{
+			name:            "show feature types custom columns",
+			statusCode:      http.StatusOK,
+			body:            workspaceDetail,
+			args:            []string{"workspaces", "describe", "--repository", "Samples", "--name", "austinDownload.fmw", "--show", "featuretypes", "--output", "custom-columns=ATTRIBUTE:.attribute,TYPE:.type", "--no-headers"},
+			wantOutputRegex: "^[\\s]*OBJECTID[\\s]*fme_int32[\\s]*NAME[\\s]*fme_varchar[\\s]*$",
+		},
+		{
+			name:       "show datasets json",
+			statusCode: http.StatusOK,
+			body:       workspaceDetail,
+			args:       []string{"workspaces", "describe", "--repository", "Samples", "--name", "austinDownload.fmw", "--show", "datasets", "--json"},
+			wantOutputJson: `[
+				{"name":"SQLITE3FDO_1","direction":"source","format":"SQLITE3FDO","location":"$(FME_SHAREDRESOURCE_DATA)/Landmarks.sqlite","featureTypes":1},
+				{"name":"GEOJSON_1","direction":"destination","format":"GEOJSON","location":"$(FME_SHAREDRESOURCE_TEMP)/output.json","featureTypes":0}
+			]`,
+		},
+		{
+			name:            "parameters yaml template",
+			statusCode:      http.StatusOK,
+			body:            workspaceDetail,
+			args:            []string{"workspaces", "describe", "--repository", "Samples", "--name", "austinDownload.fmw", "--output", "yaml"},
+			wantOutputRegex: "^# Published parameters for Samples/austinDownload.fmw\n# Use with: fmeflow run --repository Samples --workspace austinDownload.fmw --parameters-file <file>\n# THEMES \\(LISTBOX, required\\): Layers to download\n# Choices: railroad, airports\nTHEMES:\n  - railroad\n# COORDSYS \\(COORDSYS\\): Output coordinate system\nCOORDSYS: TX83-CF\n$",
+		},
+	}
+
+	runTests(cases, t)
+
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type workspaceDownloadFlags struct {
+	repository       string
+	name             string
+	file             string
+	includeResources bool
+}
+
+func newWorkspaceDownloadCmd() *cobra.Command {
+	f := workspaceDownloadFlags{}
+	cmd := &cobra.Command{
+		Use:   "download",
+		Short: "Download a workspace from a repository.",
+		Long:  `Download a workspace from a repository to a local file. Use --include-resources to also download the resource files published with the workspace. Resources are saved to the same directory as the workspace.`,
+		Example: `
+  # Download the workspace "austinApartments.fmw" from the Samples repository to the current directory
+  fmeflow workspaces download --repository Samples --name austinApartments.fmw
+
+  # Download a workspace and its resources to a specific file
+  fmeflow workspaces download --repository Samples --name austinDownload.fmw --include-resources -f workspaces/austinDownload.fmw`,
+		Args: NoArgs,
+		RunE: workspaceDownloadRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.repository, "repository", "", "Name of the repository containing the workspace.")
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the workspace to download.")
+	cmd.Flags().StringVarP(&f.file, "file", "f", "", "Path to the file to download the workspace to. Defaults to the name of the workspace in the current directory.")
+	cmd.Flags().BoolVar(&f.includeResources, "include-resources", false, "Also download the resource files published with the workspace.")
+	cmd.MarkFlagRequired("repository")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func workspaceDownloadRun(f *workspaceDownloadFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		if f.file == "" {
+			f.file = f.name
+		}
+
+		url := "/fmeapiv4/repositories/" + f.repository + "/items/" + f.name + "/download"
+		if err := downloadRepositoryFile(client, viper.GetViper(), url, f.file); err != nil {
+			return err
+		}
+
+		downloaded := []string{f.file}
+
+		if f.includeResources {
+			workspace, err := getWorkspaceDetailed(client, viper.GetViper(), f.repository, f.name)
+			if err != nil {
+				return err
+			}
+
+			for _, resource := range workspace.Resources {
+				resourceFile := filepath.Join(filepath.Dir(f.file), resource.Name)
+				url := "/fmeapiv4/repositories/" + f.repository + "/items/" + f.name + "/resources/" + resource.Name + "/download"
+				if err := downloadRepositoryFile(client, viper.GetViper(), url, resourceFile); err != nil {
+					return err
+				}
+				downloaded = append(downloaded, resourceFile)
+			}
+		}
+
+		if !jsonOutput {
+			for _, file := range downloaded {
+				fmt.Fprintln(cmd.OutOrStdout(), "Downloaded "+file)
+			}
+		} else {
+			jsonData, err := json.Marshal(downloaded)
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(jsonData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		}
+
+		return nil
+	}
+}
+
+// download a file from the given endpoint to a local path
+func downloadRepositoryFile(client *http.Client, config *viper.Viper, endpoint string, path string) error {
+	request, err := buildFmeFlowRequestForConfig(config, endpoint, "GET", nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Add("Accept", "application/octet-stream")
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		if response.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%w: check that the specified repository and workspace exist", errors.New(response.Status))
+		}
+		return parseResponseMessage(response)
+	}
+	defer response.Body.Close()
+
+	// Create the output file
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	// use Copy so that it doesn't store the entire file in memory
+	_, err = io.Copy(out, response.Body)
+	return err
+}
+
+// get the full details of a single workspace
+func getWorkspaceDetailed(client *http.Client, config *viper.Viper, repository string, name string) (FMEFlowWorkspaceDetailedV4, error) {
+	var result FMEFlowWorkspaceDetailedV4
+
+	request, err := buildFmeFlowRequestForConfig(config, "/fmeapiv4/workspaces/"+repository+"/"+name, "GET", nil)
+	if err != nil {
+		return result, err
+	}
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return result, err
+	} else if response.StatusCode != http.StatusOK {
+		if response.StatusCode == http.StatusNotFound {
+			return result, fmt.Errorf("%w: check that the specified repository and workspace exist", errors.New(response.Status))
+		}
+		return result, parseResponseMessage(response)
+	}
+
+	responseData, err := io.ReadAll(response.Body)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(responseData, &result)
+	return result, err
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspacesDownload(t *testing.T) {
+	dir, err := os.MkdirTemp("", "workspaces")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	workspaceFile := filepath.Join(dir, "austinDownload.fmw")
+
+	workspaceDetail := `{
+		"name": "austinDownload.fmw",
+		"title": "City of Austin: Data Download",
+		"resources": [
+		  {
+			"name": "landmarks.sqlite",
+			"size": 8
+		  }
+		]
+	  }`
+
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fmeapiv4/repositories/Samples/items/austinDownload.fmw/download":
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte("workspace contents"))
+			require.NoError(t, err)
+		case "/fmeapiv4/workspaces/Samples/austinDownload.fmw":
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(workspaceDetail))
+			require.NoError(t, err)
+		case "/fmeapiv4/repositories/Samples/items/austinDownload.fmw/resources/landmarks.sqlite/download":
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte("resource"))
+			require.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"workspaces", "download", "--repository", "Samples", "--name", "austinDownload.fmw", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flag",
+			wantErrText: "required flag(s) \"name\", \"repository\" not set",
+			args:        []string{"workspaces", "download"},
+		},
+		{
+			name:        "workspace not found",
+			statusCode:  http.StatusNotFound,
+			wantErrText: "404 Not Found: check that the specified repository and workspace exist",
+			args:        []string{"workspaces", "download", "--repository", "Samples", "--name", "austinDownload.fmw", "-f", workspaceFile},
+		},
+		{
+			name:             "download workspace",
+			statusCode:       http.StatusOK,
+			body:             "workspace contents",
+			args:             []string{"workspaces", "download", "--repository", "Samples", "--name", "austinDownload.fmw", "-f", workspaceFile},
+			wantOutputRegex:  "^Downloaded .*austinDownload.fmw[\\s]*$",
+			wantURLContains:  "/fmeapiv4/repositories/Samples/items/austinDownload.fmw/download",
+			wantFileContents: fileContents{file: workspaceFile, contents: "workspace contents"},
+		},
+		{
+			name:             "download workspace with resources",
+			args:             []string{"workspaces", "download", "--repository", "Samples", "--name", "austinDownload.fmw", "-f", workspaceFile, "--include-resources"},
+			wantOutputRegex:  "^Downloaded .*austinDownload.fmw\nDownloaded .*landmarks.sqlite[\\s]*$",
+			wantFileContents: fileContents{file: filepath.Join(dir, "landmarks.sqlite"), contents: "resource"},
+			httpServer:       httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+		},
+	}
+
+	runTests(cases, t)
+
+}
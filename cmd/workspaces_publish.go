@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type WorkspaceServiceRegistrationV4 struct {
+	Registered  bool     `json:"registered"`
+	Reader      string   `json:"reader,omitempty"`
+	Writers     []string `json:"writers,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Visibility  string   `json:"visibility,omitempty"`
+}
+
+type WorkspaceServicesV4 struct {
+	DataDownload   *WorkspaceServiceRegistrationV4 `json:"dataDownload,omitempty"`
+	DataStreaming  *WorkspaceServiceRegistrationV4 `json:"dataStreaming,omitempty"`
+	JobSubmitter   *WorkspaceServiceRegistrationV4 `json:"jobSubmitter,omitempty"`
+	KmlNetworkLink *WorkspaceServiceRegistrationV4 `json:"kmlNetworkLink,omitempty"`
+}
+
+type workspacePublishFlags struct {
+	repository      string
+	file            string
+	resourceFiles   []string
+	registerService []string
+	overwrite       bool
+	recursive       bool
+}
+
+// the services a workspace can be registered with
+var workspaceServiceNames = []string{"job-submitter", "data-download", "data-streaming", "kml-network-link"}
+
+func newWorkspacePublishCmd() *cobra.Command {
+	f := workspacePublishFlags{}
+	cmd := &cobra.Command{
+		Use:   "publish",
+		Short: "Publish workspaces to a repository.",
+		Long: `Publish a workspace to a repository on FME Flow. The workspace can optionally be registered with one or more services and have resource files uploaded along with it.
+If a directory is passed in to the --file flag, every .fmw file in that directory will be published. Use the --recursive flag to also publish workspaces in subdirectories.`,
+		Example: `
+  # Publish a workspace to the repository "MyRepository"
+  fmeflow workspaces publish --repository MyRepository --file my.fmw
+
+  # Publish a workspace, overwriting it if it already exists, and register it with the job submitter and data download services
+  fmeflow workspaces publish --repository MyRepository --file my.fmw --overwrite --register-service job-submitter --register-service data-download
+
+  # Publish a workspace along with a resource file it reads from
+  fmeflow workspaces publish --repository MyRepository --file my.fmw --resource-file data/roads.gpkg
+
+  # Publish all workspaces in the directory "workspaces" and all of its subdirectories
+  fmeflow workspaces publish --repository MyRepository --file ./workspaces --recursive`,
+		Args: NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			for _, service := range f.registerService {
+				if !slices.Contains(workspaceServiceNames, service) {
+					return fmt.Errorf("invalid service %q. Must be one of %s", service, strings.Join(workspaceServiceNames, ", "))
+				}
+			}
+			return nil
+		},
+		RunE: workspacePublishRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.repository, "repository", "", "Name of the repository to publish to.")
+	cmd.Flags().StringVarP(&f.file, "file", "f", "", "Path to the workspace to publish. If this is a directory, all workspaces in the directory will be published.")
+	cmd.Flags().StringArrayVar(&f.resourceFiles, "resource-file", []string{}, "Path to a resource file to upload along with the workspace. Can be passed in multiple times. Only valid when publishing a single workspace.")
+	cmd.Flags().StringArrayVar(&f.registerService, "register-service", []string{}, "Service to register the workspace with. Must be one of job-submitter, data-download, data-streaming or kml-network-link. Can be passed in multiple times.")
+	cmd.Flags().BoolVar(&f.overwrite, "overwrite", false, "Overwrite the workspace if it already exists in the repository.")
+	cmd.Flags().BoolVarP(&f.recursive, "recursive", "r", false, "When publishing a directory, also publish workspaces in all subdirectories.")
+	cmd.RegisterFlagCompletionFunc("register-service", workspaceServiceCompletion)
+	cmd.MarkFlagRequired("repository")
+	cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func workspacePublishRun(f *workspacePublishFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// set up http
+		client := &http.Client{}
+
+		fileInfo, err := os.Stat(f.file)
+		if err != nil {
+			return err
+		}
+
+		// build the list of workspaces to publish
+		workspaces := []string{}
+		if fileInfo.IsDir() {
+			if len(f.resourceFiles) != 0 {
+				return errors.New("cannot specify resource files when publishing a directory")
+			}
+			err := filepath.WalkDir(f.file, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if d.IsDir() {
+					if path != f.file && !f.recursive {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				if strings.EqualFold(filepath.Ext(path), ".fmw") {
+					workspaces = append(workspaces, path)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if len(workspaces) == 0 {
+				return errors.New("no workspaces found in directory " + f.file)
+			}
+
+			// workspaces are published under their file name, so two with the same name would overwrite each other
+			paths := map[string][]string{}
+			for _, workspace := range workspaces {
+				paths[filepath.Base(workspace)] = append(paths[filepath.Base(workspace)], workspace)
+			}
+			duplicates := []string{}
+			for _, name := range sortedKeys(paths) {
+				if len(paths[name]) > 1 {
+					duplicates = append(duplicates, name+" ("+strings.Join(paths[name], ", ")+")")
+				}
+			}
+			if len(duplicates) != 0 {
+				return errors.New("multiple workspaces have the same name and would overwrite each other in the repository: " + strings.Join(duplicates, "; "))
+			}
+		} else {
+			workspaces = append(workspaces, f.file)
+		}
+
+		for _, workspace := range workspaces {
+			if err := publishWorkspace(client, f, workspace); err != nil {
+				return fmt.Errorf("failed to publish %s: %w", workspace, err)
+			}
+			if !jsonOutput {
+				fmt.Fprintln(cmd.OutOrStdout(), "Workspace "+filepath.Base(workspace)+" successfully published to repository "+f.repository+".")
+			}
+		}
+
+		if jsonOutput {
+			fmt.Fprintln(cmd.OutOrStdout(), "{}")
+		}
+
+		return nil
+	}
+}
+
+// publish a single workspace and its resources to the repository, then register any services
+func publishWorkspace(client *http.Client, f *workspacePublishFlags, workspacePath string) error {
+	workspaceName := filepath.Base(workspacePath)
+
+	url := "/fmeapiv4/repositories/" + f.repository + "/items"
+	if err := uploadRepositoryFile(client, viper.GetViper(), url, workspacePath, f.overwrite); err != nil {
+		return err
+	}
+
+	for _, resource := range f.resourceFiles {
+		url := "/fmeapiv4/repositories/" + f.repository + "/items/" + workspaceName + "/resources"
+		if err := uploadRepositoryFile(client, viper.GetViper(), url, resource, f.overwrite); err != nil {
+			return err
+		}
+	}
+
+	if len(f.registerService) != 0 {
+		var services WorkspaceServicesV4
+		for _, service := range f.registerService {
+			services.set(service, &WorkspaceServiceRegistrationV4{Registered: true})
+		}
+
+		if err := registerWorkspaceServices(client, viper.GetViper(), f.repository, workspaceName, services); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// update which services a workspace is registered with
+func registerWorkspaceServices(client *http.Client, config *viper.Viper, repository string, name string, services WorkspaceServicesV4) error {
+	jsonData, err := json.Marshal(services)
+	if err != nil {
+		return err
+	}
+
+	request, err := buildFmeFlowRequestForConfig(config, "/fmeapiv4/workspaces/"+repository+"/"+name+"/services", "PUT", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	request.Header.Add("Content-Type", "application/json")
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK {
+		return parseResponseMessage(response)
+	}
+	return nil
+}
+
+// upload a local file as a multipart form to the given endpoint
+func uploadRepositoryFile(client *http.Client, config *viper.Viper, endpoint string, path string, overwrite bool) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Create a buffer to store our request body as bytes
+	var requestBody bytes.Buffer
+
+	// Create a multipart writer
+	multiPartWriter := multipart.NewWriter(&requestBody)
+
+	// Create a form file writer for the file field
+	fileWriter, err := multiPartWriter.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	// Copy the file data to the form file writer
+	if _, err = io.Copy(fileWriter, file); err != nil {
+		return err
+	}
+
+	// Close the multipart writer to get the terminating boundary
+	if err = multiPartWriter.Close(); err != nil {
+		return err
+	}
+
+	request, err := buildFmeFlowRequestForConfig(config, endpoint, "POST", &requestBody)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", multiPartWriter.FormDataContentType())
+
+	q := request.URL.Query()
+	q.Add("overwrite", strconv.FormatBool(overwrite))
+	request.URL.RawQuery = q.Encode()
+
+	response, err := client.Do(&request)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusOK {
+		if response.StatusCode == http.StatusConflict {
+			return fmt.Errorf("%w: %s already exists. Use --overwrite to replace it", errors.New(response.Status), filepath.Base(path))
+		}
+		return parseResponseMessage(response)
+	}
+	return nil
+}
+
+// enable tab completion
+func workspaceServiceCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{
+		"job-submitter\tJob Submitter service",
+		"data-download\tData Download service",
+		"data-streaming\tData Streaming service",
+		"kml-network-link\tKML Network Link service",
+	}, cobra.ShellCompDirectiveDefault
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspacesPublish(t *testing.T) {
+	// create a directory of workspaces to publish
+	dir, err := os.MkdirTemp("", "workspaces")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0755))
+	workspaceFile := filepath.Join(dir, "top.fmw")
+	require.NoError(t, os.WriteFile(workspaceFile, []byte("workspace"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "nested.fmw"), []byte("workspace"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0644))
+	resourceFile := filepath.Join(dir, "roads.gpkg")
+	require.NoError(t, os.WriteFile(resourceFile, []byte("resource"), 0644))
+
+	// a directory with two workspaces of the same name in different subdirectories
+	duplicateDir, err := os.MkdirTemp("", "workspaces")
+	require.NoError(t, err)
+	defer os.RemoveAll(duplicateDir)
+	require.NoError(t, os.Mkdir(filepath.Join(duplicateDir, "a"), 0755))
+	require.NoError(t, os.Mkdir(filepath.Join(duplicateDir, "b"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(duplicateDir, "a", "load.fmw"), []byte("workspace"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(duplicateDir, "b", "load.fmw"), []byte("workspace"), 0644))
+
+	customHttpServerHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/repositories/MyRepo/items" {
+			require.Equal(t, "true", r.URL.Query().Get("overwrite"))
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		if r.Method == "POST" && r.URL.Path == "/fmeapiv4/repositories/MyRepo/items/top.fmw/resources" {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/workspaces/MyRepo/top.fmw/services" {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"jobSubmitter":{"registered":true},"dataDownload":{"registered":true}}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}
+
+	workspaceExistsBody := `{
+		"message": "The item top.fmw already exists in repository MyRepo."
+	  }`
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"workspaces", "publish", "--repository", "MyRepo", "--file", workspaceFile, "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flag",
+			wantErrText: "required flag(s) \"file\", \"repository\" not set",
+			args:        []string{"workspaces", "publish"},
+		},
+		{
+			name:        "invalid service",
+			wantErrText: "invalid service \"kml\". Must be one of job-submitter, data-download, data-streaming, kml-network-link",
+			args:        []string{"workspaces", "publish", "--repository", "MyRepo", "--file", workspaceFile, "--register-service", "kml"},
+		},
+		{
+			name:        "500 bad status code",
+			statusCode:  http.StatusInternalServerError,
+			wantErrText: "failed to publish " + workspaceFile + ": 500 Internal Server Error",
+			args:        []string{"workspaces", "publish", "--repository", "MyRepo", "--file", workspaceFile},
+		},
+		{
+			name:        "workspace already exists",
+			statusCode:  http.StatusConflict,
+			body:        workspaceExistsBody,
+			wantErrText: "failed to publish " + workspaceFile + ": 409 Conflict: top.fmw already exists. Use --overwrite to replace it",
+			args:        []string{"workspaces", "publish", "--repository", "MyRepo", "--file", workspaceFile},
+		},
+		{
+			name:            "publish single workspace",
+			statusCode:      http.StatusCreated,
+			args:            []string{"workspaces", "publish", "--repository", "MyRepo", "--file", workspaceFile},
+			wantOutputRegex: "^Workspace top.fmw successfully published to repository MyRepo.[\\s]*$",
+		},
+		{
+			name:            "publish with resources and services",
+			args:            []string{"workspaces", "publish", "--repository", "MyRepo", "--file", workspaceFile, "--overwrite", "--resource-file", resourceFile, "--register-service", "job-submitter", "--register-service", "data-download"},
+			wantOutputRegex: "^Workspace top.fmw successfully published to repository MyRepo.[\\s]*$",
+			httpServer:      httptest.NewServer(http.HandlerFunc(customHttpServerHandler)),
+		},
+		{
+			name:            "publish directory",
+			statusCode:      http.StatusCreated,
+			args:            []string{"workspaces", "publish", "--repository", "MyRepo", "--file", dir},
+			wantOutputRegex: "^Workspace top.fmw successfully published to repository MyRepo.[\\s]*$",
+		},
+		{
+			name:            "publish directory recursively",
+			statusCode:      http.StatusCreated,
+			args:            []string{"workspaces", "publish", "--repository", "MyRepo", "--file", dir, "--recursive"},
+			wantOutputRegex: "^Workspace nested.fmw successfully published to repository MyRepo.\nWorkspace top.fmw successfully published to repository MyRepo.[\\s]*$",
+		},
+		{
+			name:        "duplicate workspace names",
+			statusCode:  http.StatusCreated,
+			wantErrText: "multiple workspaces have the same name and would overwrite each other in the repository: load.fmw (" + filepath.Join(duplicateDir, "a", "load.fmw") + ", " + filepath.Join(duplicateDir, "b", "load.fmw") + ")",
+			args:        []string{"workspaces", "publish", "--repository", "MyRepo", "--file", duplicateDir, "--recursive"},
+		},
+		{
+			name:        "resource files with directory",
+			wantErrText: "cannot specify resource files when publishing a directory",
+			args:        []string{"workspaces", "publish", "--repository", "MyRepo", "--file", dir, "--resource-file", resourceFile},
+		},
+		{
+			name:            "publish json output",
+			statusCode:      http.StatusCreated,
+			args:            []string{"workspaces", "publish", "--repository", "MyRepo", "--file", workspaceFile, "--json"},
+			wantOutputRegex: "^{}[\\s]*$",
+		},
+	}
+
+	runTests(cases, t)
+
+}
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type WorkspaceServiceV4 struct {
+	Service    string   `json:"service"`
+	Registered bool     `json:"registered"`
+	Reader     string   `json:"reader"`
+	Writers    []string `json:"writers"`
+}
+
+type workspaceServicesFlags struct {
+	repository string
+	name       string
+	register   []string
+	unregister []string
+	properties []string
+	outputType string
+	noHeaders  bool
+}
+
+// the properties that can be set on each service when registering
+var workspaceServiceProperties = map[string][]string{
+	"job-submitter":    {"reader"},
+	"data-download":    {"reader", "writers"},
+	"data-streaming":   {"reader", "writers"},
+	"kml-network-link": {"writers", "name", "description", "visibility"},
+}
+
+func newWorkspaceServicesCmd() *cobra.Command {
+	f := workspaceServicesFlags{}
+	cmd := &cobra.Command{
+		Use:   "services",
+		Short: "List and change the services a workspace is registered with.",
+		Long: `Lists the services a workspace is registered with. Use --register and --unregister to change the registrations.
+When registering a service, properties of that service can be set with --property in the form service.property=value. The supported properties are:
+  job-submitter: reader
+  data-download: reader, writers
+  data-streaming: reader, writers
+  kml-network-link: writers, name, description, visibility
+The writers property is a comma separated list of the output formats offered by the service, with the first being the default.`,
+		Example: `
+  # List the services the workspace "austinApartments.fmw" is registered with
+  fmeflow workspaces services --repository Samples --name austinApartments.fmw
+
+  # Register the workspace with the job submitter and data download services
+  fmeflow workspaces services --repository Samples --name austinApartments.fmw --register job-submitter --register data-download
+
+  # Register the workspace with the data download service with GeoJSON as the default output format
+  fmeflow workspaces services --repository Samples --name austinApartments.fmw --register data-download --property data-download.writers=GEOJSON,SHAPEFILE
+
+  # Unregister the workspace from the data streaming service
+  fmeflow workspaces services --repository Samples --name austinApartments.fmw --unregister data-streaming`,
+		Args: NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			for _, service := range append(f.register, f.unregister...) {
+				if !slices.Contains(workspaceServiceNames, service) {
+					return fmt.Errorf("invalid service %q. Must be one of %s", service, strings.Join(workspaceServiceNames, ", "))
+				}
+			}
+			for _, service := range f.register {
+				if slices.Contains(f.unregister, service) {
+					return fmt.Errorf("cannot both register and unregister the service %q", service)
+				}
+			}
+			for _, property := range f.properties {
+				service, key, _, err := parseWorkspaceServiceProperty(property)
+				if err != nil {
+					return err
+				}
+				if !slices.Contains(f.register, service) {
+					return fmt.Errorf("property %q can only be set when registering the service %q", property, service)
+				}
+				if !slices.Contains(workspaceServiceProperties[service], key) {
+					return fmt.Errorf("invalid property %q for service %q. Must be one of %s", key, service, strings.Join(workspaceServiceProperties[service], ", "))
+				}
+			}
+			return nil
+		},
+		RunE: workspaceServicesRun(&f),
+	}
+
+	cmd.Flags().StringVar(&f.repository, "repository", "", "Name of the repository containing the workspace.")
+	cmd.Flags().StringVar(&f.name, "name", "", "Name of the workspace.")
+	cmd.Flags().StringArrayVar(&f.register, "register", []string{}, "Service to register the workspace with. Must be one of job-submitter, data-download, data-streaming or kml-network-link. Can be passed in multiple times.")
+	cmd.Flags().StringArrayVar(&f.unregister, "unregister", []string{}, "Service to unregister the workspace from. Must be one of job-submitter, data-download, data-streaming or kml-network-link. Can be passed in multiple times.")
+	cmd.Flags().StringArrayVar(&f.properties, "property", []string{}, "Property of a service being registered in the form service.property=value. Can be passed in multiple times.")
+	cmd.Flags().StringVarP(&f.outputType, "output", "o", "table", "Specify the output type. Should be one of table, json, or custom-columns")
+	cmd.Flags().BoolVar(&f.noHeaders, "no-headers", false, "Don't print column headers")
+	cmd.RegisterFlagCompletionFunc("register", workspaceServiceCompletion)
+	cmd.RegisterFlagCompletionFunc("unregister", workspaceServiceCompletion)
+	cmd.MarkFlagRequired("repository")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func workspaceServicesRun(f *workspaceServicesFlags) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// --json overrides --output
+		if jsonOutput {
+			f.outputType = "json"
+		}
+
+		// set up http
+		client := &http.Client{}
+
+		if len(f.register) != 0 || len(f.unregister) != 0 {
+			var services WorkspaceServicesV4
+			for _, service := range f.register {
+				services.set(service, &WorkspaceServiceRegistrationV4{Registered: true})
+			}
+			for _, service := range f.unregister {
+				services.set(service, &WorkspaceServiceRegistrationV4{Registered: false})
+			}
+			for _, property := range f.properties {
+				// already validated in PreRunE
+				service, key, value, _ := parseWorkspaceServiceProperty(property)
+				registration := services.get(service)
+				switch key {
+				case "reader":
+					registration.Reader = value
+				case "writers":
+					registration.Writers = strings.Split(value, ",")
+				case "name":
+					registration.Name = value
+				case "description":
+					registration.Description = value
+				case "visibility":
+					registration.Visibility = value
+				}
+			}
+
+			if err := registerWorkspaceServices(client, viper.GetViper(), f.repository, f.name, services); err != nil {
+				return err
+			}
+
+			if !jsonOutput {
+				fmt.Fprintln(cmd.OutOrStdout(), "Workspace services successfully updated.")
+			} else {
+				fmt.Fprintln(cmd.OutOrStdout(), "{}")
+			}
+			return nil
+		}
+
+		workspace, err := getWorkspaceDetailed(client, viper.GetViper(), f.repository, f.name)
+		if err != nil {
+			return err
+		}
+
+		services := []WorkspaceServiceV4{
+			{Service: "job-submitter", Registered: workspace.Services.JobSubmitter.Registered, Reader: workspace.Services.JobSubmitter.Reader, Writers: []string{}},
+			{Service: "data-download", Registered: workspace.Services.DataDownload.Registered, Reader: workspace.Services.DataDownload.Reader, Writers: workspace.Services.DataDownload.Writers},
+			{Service: "data-streaming", Registered: workspace.Services.DataStreaming.Registered, Reader: workspace.Services.DataStreaming.Reader, Writers: workspace.Services.DataStreaming.Writers},
+			{Service: "kml-network-link", Registered: workspace.Services.KmlNetworkLink.Registered, Writers: workspace.Services.KmlNetworkLink.Writers},
+		}
+		for i := range services {
+			if services[i].Writers == nil {
+				services[i].Writers = []string{}
+			}
+		}
+
+		if f.outputType == "table" {
+
+			t := table.NewWriter()
+			t.SetStyle(defaultStyle)
+
+			t.AppendHeader(table.Row{"Service", "Registered", "Reader", "Writers"})
+
+			for _, element := range services {
+				t.AppendRow(table.Row{element.Service, element.Registered, element.Reader, strings.Join(element.Writers, ", ")})
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else if f.outputType == "json" {
+			jsonData, err := json.Marshal(services)
+			if err != nil {
+				return err
+			}
+			prettyJSON, err := prettyPrintJSON(jsonData)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), prettyJSON)
+		} else if strings.HasPrefix(f.outputType, "custom-columns") {
+			// parse the columns and json queries
+			columnsString := ""
+			if strings.HasPrefix(f.outputType, "custom-columns=") {
+				columnsString = f.outputType[len("custom-columns="):]
+			}
+			if len(columnsString) == 0 {
+				return errors.New("custom-columns format specified but no custom columns given")
+			}
+
+			// we have to marshal the Items array, then create an array of marshalled items
+			// to pass to the creation of the table.
+			marshalledItems := [][]byte{}
+			for _, element := range services {
+				mJson, err := json.Marshal(element)
+				if err != nil {
+					return err
+				}
+				marshalledItems = append(marshalledItems, mJson)
+			}
+
+			columnsInput := strings.Split(columnsString, ",")
+			t, err := createTableFromCustomColumns(marshalledItems, columnsInput)
+			if err != nil {
+				return err
+			}
+			if f.noHeaders {
+				t.ResetHeaders()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Render())
+
+		} else {
+			return errors.New("invalid output format specified")
+		}
+
+		return nil
+	}
+}
+
+// split a property in the form service.property=value
+func parseWorkspaceServiceProperty(property string) (string, string, string, error) {
+	key, value, found := strings.Cut(property, "=")
+	if !found {
+		return "", "", "", fmt.Errorf("invalid property %q. Must be in the form service.property=value", property)
+	}
+	service, key, found := strings.Cut(key, ".")
+	if !found || !slices.Contains(workspaceServiceNames, service) {
+		return "", "", "", fmt.Errorf("invalid property %q. Must be in the form service.property=value", property)
+	}
+	return service, key, value, nil
+}
+
+// set the registration for a service by its command line name
+func (s *WorkspaceServicesV4) set(service string, registration *WorkspaceServiceRegistrationV4) {
+	switch service {
+	case "job-submitter":
+		s.JobSubmitter = registration
+	case "data-download":
+		s.DataDownload = registration
+	case "data-streaming":
+		s.DataStreaming = registration
+	case "kml-network-link":
+		s.KmlNetworkLink = registration
+	}
+}
+
+// get the registration for a service by its command line name
+func (s *WorkspaceServicesV4) get(service string) *WorkspaceServiceRegistrationV4 {
+	switch service {
+	case "job-submitter":
+		return s.JobSubmitter
+	case "data-download":
+		return s.DataDownload
+	case "data-streaming":
+		return s.DataStreaming
+	case "kml-network-link":
+		return s.KmlNetworkLink
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspacesServices(t *testing.T) {
+	workspaceDetail := `{
+		"name": "austinDownload.fmw",
+		"services": {
+		  "dataDownload": {
+			"reader": "SQLITE3FDO",
+			"registered": true,
+			"writers": [
+			  "GEOJSON",
+			  "SHAPEFILE"
+			]
+		  },
+		  "dataStreaming": {
+			"registered": false
+		  },
+		  "jobSubmitter": {
+			"reader": "SQLITE3FDO",
+			"registered": true
+		  },
+		  "kmlNetworkLink": {
+			"registered": false
+		  }
+		}
+	  }`
+
+	newUpdateHandler := func(wantBody string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "PUT" && r.URL.Path == "/fmeapiv4/workspaces/Samples/austinDownload.fmw/services" {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, wantBody, string(body))
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	cases := []testCase{
+		{
+			name:               "unknown flag",
+			statusCode:         http.StatusOK,
+			args:               []string{"workspaces", "services", "--repository", "Samples", "--name", "austinDownload.fmw", "--badflag"},
+			wantErrOutputRegex: "unknown flag: --badflag",
+		},
+		{
+			name:        "missing flag",
+			wantErrText: "required flag(s) \"name\", \"repository\" not set",
+			args:        []string{"workspaces", "services"},
+		},
+		{
+			name:        "invalid service",
+			wantErrText: "invalid service \"kml\". Must be one of job-submitter, data-download, data-streaming, kml-network-link",
+			args:        []string{"workspaces", "services", "--repository", "Samples", "--name", "austinDownload.fmw", "--register", "kml"},
+		},
+		{
+			name:        "register and unregister same service",
+			wantErrText: "cannot both register and unregister the service \"data-download\"",
+			args:        []string{"workspaces", "services", "--repository", "Samples", "--name", "austinDownload.fmw", "--register", "data-download", "--unregister", "data-download"},
+		},
+		{
+			name:        "malformed property",
+			wantErrText: "invalid property \"writers=GEOJSON\". Must be in the form service.property=value",
+			args:        []string{"workspaces", "services", "--repository", "Samples", "--name", "austinDownload.fmw", "--register", "data-download", "--property", "writers=GEOJSON"},
+		},
+		{
+			name:        "property for service not being registered",
+			wantErrText: "property \"data-streaming.writers=GEOJSON\" can only be set when registering the service \"data-streaming\"",
+			args:        []string{"workspaces", "services", "--repository", "Samples", "--name", "austinDownload.fmw", "--register", "data-download", "--property", "data-streaming.writers=GEOJSON"},
+		},
+		{
+			name:        "invalid property for service",
+			wantErrText: "invalid property \"writers\" for service \"job-submitter\". Must be one of reader",
+			args:        []string{"workspaces", "services", "--repository", "Samples", "--name", "austinDownload.fmw", "--register", "job-submitter", "--property", "job-submitter.writers=GEOJSON"},
+		},
+		{
+			name:        "workspace not found",
+			statusCode:  http.StatusNotFound,
+			wantErrText: "404 Not Found: check that the specified repository and workspace exist",
+			args:        []string{"workspaces", "services", "--repository", "Samples", "--name", "austinDownload.fmw"},
+		},
+		{
+			name:            "list services",
+			statusCode:      http.StatusOK,
+			body:            workspaceDetail,
+			args:            []string{"workspaces", "services", "--repository", "Samples", "--name", "austinDownload.fmw"},
+			wantOutputRegex: "^[\\s]*SERVICE[\\s]*REGISTERED[\\s]*READER[\\s]*WRITERS[\\s]*job-submitter[\\s]*true[\\s]*SQLITE3FDO[\\s]*data-download[\\s]*true[\\s]*SQLITE3FDO[\\s]*GEOJSON, SHAPEFILE[\\s]*data-streaming[\\s]*false[\\s]*kml-network-link[\\s]*false[\\s]*$",
+			wantURLContains: "/fmeapiv4/workspaces/Samples/austinDownload.fmw",
+		},
+		{
+			name:            "list services custom columns",
+			statusCode:      http.StatusOK,
+			body:            workspaceDetail,
+			args:            []string{"workspaces", "services", "--repository", "Samples", "--name", "austinDownload.fmw", "--output", "custom-columns=SERVICE:.service,REGISTERED:.registered", "--no-headers"},
+			wantOutputRegex: "^[\\s]*job-submitter[\\s]*true[\\s]*data-download[\\s]*true[\\s]*data-streaming[\\s]*false[\\s]*kml-network-link[\\s]*false[\\s]*$",
+		},
+		{
+			name:       "list services json",
+			statusCode: http.StatusOK,
+			body:       workspaceDetail,
+			args:       []string{"workspaces", "services", "--repository", "Samples", "--name", "austinDownload.fmw", "--json"},
+			wantOutputJson: `[
+				{"service":"job-submitter","registered":true,"reader":"SQLITE3FDO","writers":[]},
+				{"service":"data-download","registered":true,"reader":"SQLITE3FDO","writers":["GEOJSON","SHAPEFILE"]},
+				{"service":"data-streaming","registered":false,"reader":"","writers":[]},
+				{"service":"kml-network-link","registered":false,"reader":"","writers":[]}
+			]`,
+		},
+		{
+			name:            "register and unregister services",
+			args:            []string{"workspaces", "services", "--repository", "Samples", "--name", "austinDownload.fmw", "--register", "kml-network-link", "--unregister", "data-download"},
+			wantOutputRegex: "^Workspace services successfully updated.[\\s]*$",
+			httpServer:      httptest.NewServer(newUpdateHandler(`{"kmlNetworkLink":{"registered":true},"dataDownload":{"registered":false}}`)),
+		},
+		{
+			name:            "register with properties",
+			args:            []string{"workspaces", "services", "--repository", "Samples", "--name", "austinDownload.fmw", "--register", "data-download", "--property", "data-download.writers=GEOJSON,SHAPEFILE", "--property", "data-download.reader=SQLITE3FDO", "--json"},
+			wantOutputRegex: "^{}[\\s]*$",
+			httpServer:      httptest.NewServer(newUpdateHandler(`{"dataDownload":{"registered":true,"reader":"SQLITE3FDO","writers":["GEOJSON","SHAPEFILE"]}}`)),
+		},
+	}
+
+	runTests(cases, t)
+
+}